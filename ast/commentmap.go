@@ -0,0 +1,62 @@
+package ast
+
+import "sort"
+
+// A CommentMap maps an AST node to a list of comment groups associated with
+// it.
+// ref: https://golang.org/pkg/go/ast/#CommentMap
+type CommentMap map[Node][]*CommentGroup
+
+// NewCommentMap creates a new comment map by associating comment groups of
+// the comments list with the nodes of the AST specified by node.
+//
+// Comments in File.Comments are always placed in the logical line of an Ex
+// command; either at the end of the line or in line continuations. Thus a
+// comment group is associated with the last statement which begins before
+// the comment group, e.g. the If node for `if x " comment` and the EndIf node
+// for `endif " comment`.
+func NewCommentMap(node Node, comments []*CommentGroup) CommentMap {
+	cmap := make(CommentMap)
+	if len(comments) == 0 {
+		return cmap
+	}
+
+	var stmts []Statement
+	Inspect(node, func(n Node) bool {
+		switch n := n.(type) {
+		case *Comment, *CommentGroup:
+			return false
+		case Statement:
+			stmts = append(stmts, n)
+		}
+		return true
+	})
+	// statements are visited in source order except for unusual trees, but
+	// make sure they are sorted.
+	sort.SliceStable(stmts, func(i, j int) bool {
+		return posLess(stmts[i].Pos(), stmts[j].Pos())
+	})
+
+	for _, g := range comments {
+		i := sort.Search(len(stmts), func(i int) bool {
+			return !posLess(stmts[i].Pos(), g.Pos())
+		})
+		if i == 0 {
+			// no statement before the comment.
+			cmap[node] = append(cmap[node], g)
+			continue
+		}
+		s := stmts[i-1]
+		cmap[s] = append(cmap[s], g)
+	}
+	return cmap
+}
+
+// posLess reports whether p is before q. It compares line and column instead
+// of offset because offset is not available in line continuations.
+func posLess(p, q Pos) bool {
+	if p.Line != q.Line {
+		return p.Line < q.Line
+	}
+	return p.Column < q.Column
+}
@@ -0,0 +1,46 @@
+package ast_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+func TestNewCommentMap(t *testing.T) {
+	src := `let x = 1 " x
+if x " if
+  call F(1,
+  "\ arg
+  \ 2)
+endif " endif
+`
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmap := ast.NewCommentMap(f, f.Comments)
+	tests := []struct {
+		node ast.Node
+		want string
+	}{
+		{node: f.Body[0], want: "x"},
+		{node: f.Body[1], want: "if"},
+		{node: f.Body[1].(*ast.If).Body[0], want: "arg"},
+		{node: f.Body[1].(*ast.If).EndIf, want: "endif"},
+	}
+	for _, tt := range tests {
+		groups := cmap[tt.node]
+		if len(groups) != 1 {
+			t.Errorf("cmap[%T] = %v, want 1 comment group", tt.node, groups)
+			continue
+		}
+		if got := groups[0].Text(); got != tt.want {
+			t.Errorf("cmap[%T] = %q, want %q", tt.node, got, tt.want)
+		}
+	}
+	if len(cmap) != len(tests) {
+		t.Errorf("len(cmap) = %d, want %d", len(cmap), len(tests))
+	}
+}
@@ -0,0 +1,294 @@
+package ast
+
+import "strings"
+
+// Nodes of Ex commands which vim-vimlparser parses as EXCMD. They only exist
+// in the Go port. Text is the whole command as Excmd.Command.
+
+// vimlparser: AUTOCMD .ea .str .autocmd .body
+// :autocmd[!] [group] {event} {aupat} [++once] [++nested] {cmd}
+type Autocmd struct {
+	Autocmd  Pos      // position of starting the :autocmd
+	EndPos   Pos      // position immediately after the command
+	ExArg    ExArg    // Ex command arg
+	Text     string   // Ex command
+	Group    string   // augroup name; or empty
+	Events   []string // event names as written, e.g. "BufRead" and "*"
+	Patterns []string // patterns, e.g. "*.vim" and "<buffer>"
+	Once     bool     // ++once
+	Nested   bool     // ++nested or nested
+
+	// Commands to execute. "|" doesn't separate :autocmd from the next
+	// command, so there may be more than one. It's nil if :autocmd doesn't
+	// have commands, e.g. :autocmd! to remove autocmds.
+	Command []Statement
+}
+
+func (a *Autocmd) Pos() Pos { return a.Autocmd }
+func (a *Autocmd) End() Pos { return a.EndPos }
+func (a *Autocmd) Cmd() Cmd { return *a.ExArg.Cmd }
+
+// vimlparser: AUGROUP .ea .str .value
+// :augroup[!] {name}
+type Augroup struct {
+	Augroup Pos    // position of starting the :augroup
+	EndPos  Pos    // position immediately after the command
+	ExArg   ExArg  // Ex command arg
+	Text    string // Ex command
+	Name    string // group name; or empty to list groups
+	IsEnd   bool   // :augroup END, which ends the group
+}
+
+func (a *Augroup) Pos() Pos { return a.Augroup }
+func (a *Augroup) End() Pos { return a.EndPos }
+func (a *Augroup) Cmd() Cmd { return *a.ExArg.Cmd }
+
+// vimlparser: MAP .ea .str .mapping
+// :{mode}map [<buffer>] ... {lhs} {rhs}, :{mode}noremap and :{mode}unmap
+type Map struct {
+	Map     Pos     // position of starting the mapping command
+	EndPos  Pos     // position immediately after the command
+	ExArg   ExArg   // Ex command arg
+	Text    string  // Ex command
+	Modes   string  // modes of the mapping, e.g. "n" and "xs" of :vmap
+	Noremap bool    // :noremap; the rhs isn't remapped
+	Unmap   bool    // :unmap; the mapping is removed
+	Attr    MapAttr // special arguments, e.g. <buffer>
+	Lhs     string  // keys as written
+	Rhs     string  // keys as written; or empty
+
+	// RhsExpr is the rhs parsed as an expression if Attr.Expr is set.
+	// RhsCmds are commands of <Cmd>...<CR> and <ScriptCmd>...<CR> in the
+	// rhs. Key notations <Bar>, <lt>, <Bslash> and <Space> in them are
+	// replaced with the characters. The rhs isn't checked until the mapping
+	// is used, so they are nil if it can't be parsed.
+	RhsExpr Expr
+	RhsCmds []Statement
+}
+
+// MapAttr is special arguments of mapping commands.
+type MapAttr struct {
+	Buffer  bool // <buffer>
+	Nowait  bool // <nowait>
+	Silent  bool // <silent>
+	Special bool // <special>
+	Script  bool // <script>
+	Expr    bool // <expr>
+	Unique  bool // <unique>
+}
+
+func (m *Map) Pos() Pos { return m.Map }
+func (m *Map) End() Pos { return m.EndPos }
+func (m *Map) Cmd() Cmd { return *m.ExArg.Cmd }
+
+// vimlparser: USERCOMMAND .ea .str .usercmd
+// :command[!] [{attr}...] {cmd} {rep}
+type UserCommand struct {
+	Command      Pos             // position of starting the :command
+	EndPos       Pos             // position immediately after the command
+	ExArg        ExArg           // Ex command arg
+	Text         string          // Ex command
+	Attr         UserCommandAttr // attributes, e.g. -nargs=1
+	Name         string          // command name; or empty to list commands
+	Replacement  string          // {rep} as written; or empty
+	Placeholders []*Placeholder  // <args> and the like in Replacement, including ones in strings
+
+	// Body is Replacement parsed as commands. Placeholders in expressions
+	// are *Placeholder, e.g. <f-args> of "call F(<f-args>)". Other
+	// placeholders are parsed as "1" for <line1>, <line2>, <range> and
+	// <count>, "!" for <bang> and nothing for <mods>. Vim doesn't check
+	// Replacement until the command is used, so Body is nil if it can't be
+	// parsed.
+	Body []Statement
+}
+
+// UserCommandAttr is attributes of :command.
+type UserCommandAttr struct {
+	Nargs        string // -nargs: "0", "1", "*", "?" or "+"; or empty
+	Range        string // -range: "." without the value, "%" or N; or empty
+	Count        string // -count: "0" without the value or N; or empty
+	Complete     string // -complete: completion type, e.g. "file" and "customlist"
+	CompleteFunc string // function of -complete=custom,{func} and customlist
+	Addr         string // -addr
+	Bang         bool   // -bang
+	Bar          bool   // -bar
+	Buffer       bool   // -buffer
+	Register     bool   // -register
+	Keepscript   bool   // -keepscript
+}
+
+func (u *UserCommand) Pos() Pos { return u.Command }
+func (u *UserCommand) End() Pos { return u.EndPos }
+func (u *UserCommand) Cmd() Cmd { return *u.ExArg.Cmd }
+
+// vimlparser: PLACEHOLDER .value
+// Placeholder is a special text in the replacement of :command, e.g.
+// <q-args>.
+type Placeholder struct {
+	ValuePos Pos    // position of "<"
+	Name     string // name in lower case without "<>", e.g. "q-args"
+	EndPos   Pos    // position immediately after ">"
+}
+
+func (p *Placeholder) Pos() Pos { return p.ValuePos }
+func (p *Placeholder) End() Pos { return p.EndPos }
+
+func (*Placeholder) exprNode() {}
+
+// vimlparser: HIGHLIGHT .ea .str .highlight
+// :highlight[!] [default] {group} {key}={arg} .., :highlight [default] link
+// {from} {to} and :highlight clear [{group}]
+type Highlight struct {
+	Highlight Pos              // position of starting the :highlight
+	EndPos    Pos              // position immediately after the command
+	ExArg     ExArg            // Ex command arg
+	Text      string           // Ex command
+	Default   bool             // default; the highlight is kept if it's set
+	Clear     bool             // clear; reset highlights of Group or all
+	Link      bool             // link; Group is linked to To
+	None      bool             // {group} NONE; disable the highlight
+	Group     string           // group name as written; or empty
+	To        string           // group linked from Group; "NONE" to remove the link
+	Attrs     []*HighlightAttr // {key}={arg}
+}
+
+// HighlightAttr is {key}={arg} of :highlight, e.g. guifg=#ffffff.
+type HighlightAttr struct {
+	Pos   Pos    // position of Key
+	Key   string // as written
+	Value string // as written, e.g. "'Monospace 10'" with quotes
+}
+
+func (h *Highlight) Pos() Pos { return h.Highlight }
+func (h *Highlight) End() Pos { return h.EndPos }
+func (h *Highlight) Cmd() Cmd { return *h.ExArg.Cmd }
+
+// vimlparser: SYNTAX .ea .str .syntax
+// :syntax {subcommand} ...
+type Syntax struct {
+	Syntax Pos    // position of starting the :syntax
+	EndPos Pos    // position immediately after the command
+	ExArg  ExArg  // Ex command arg
+	Text   string // Ex command
+	Sub    string // subcommand, e.g. "keyword"; or empty to list groups
+	Args   string // arguments after Sub as written
+
+	// Arguments of keyword, match, region, cluster and include. Group is
+	// the group name, the cluster name or @{grouplist} of include.
+	Group    string
+	Keywords []string         // keywords of keyword
+	Patterns []*SyntaxPattern // pattern of match; start, skip and end of region
+	Options  []*SyntaxOption  // options, e.g. contained and contains=Foo
+	File     string           // file of include
+}
+
+// SyntaxPattern is a pattern of :syntax match and :syntax region.
+type SyntaxPattern struct {
+	Pos        Pos    // position of Key or the delimiter
+	Key        string // "start", "skip" or "end" of region; empty for match
+	Pattern    string // pattern without delimiters
+	Delim      string // delimiter, e.g. "/"
+	Offsets    string // offsets after the pattern, e.g. "ms=s+1"; or empty
+	MatchGroup string // matchgroup for the pattern of region; or empty
+}
+
+// SyntaxOption is an option of :syntax.
+type SyntaxOption struct {
+	Pos   Pos    // position of Name
+	Name  string // lower case name, e.g. "contains"
+	Value string // value after "="; or empty for flags, e.g. contained
+}
+
+func (s *Syntax) Pos() Pos { return s.Syntax }
+func (s *Syntax) End() Pos { return s.EndPos }
+func (s *Syntax) Cmd() Cmd { return *s.ExArg.Cmd }
+
+// vimlparser: SET .ea .str .options
+// :set, :setlocal and :setglobal
+type Set struct {
+	Set     Pos             // position of starting the :set
+	EndPos  Pos             // position immediately after the command
+	ExArg   ExArg           // Ex command arg
+	Text    string          // Ex command
+	Options []*OptionAssign // arguments; empty for :set without arguments
+}
+
+// OptionAssign is an argument of :set, e.g. ts=4, nowrap and ft?.
+type OptionAssign struct {
+	Pos    Pos    // position of the argument
+	EndPos Pos    // position immediately after the argument
+	Prefix string // "no" or "inv"; or empty
+	Name   string // name as written, e.g. "ts"
+	Full   string // full name, e.g. "tabstop", "all"; or empty if unknown
+	Op     string // "=", "+=", "-=", "^=", "!", "&", "<" or "?"; or empty
+	Value  string // value after "=" as written, or "vi" and "vim" after "&"
+}
+
+func (s *Set) Pos() Pos { return s.Set }
+func (s *Set) End() Pos { return s.EndPos }
+func (s *Set) Cmd() Cmd { return *s.ExArg.Cmd }
+
+// vimlparser: FOREIGNCODE .ea .str .foreign
+// Code of other languages, e.g. :lua {chunk} and :lua << [trim] {endmarker}
+type ForeignCode struct {
+	ForeignCode Pos      // position of starting the command
+	EndPos      Pos      // position immediately after the end marker or the command
+	ExArg       ExArg    // Ex command arg
+	Text        string   // Ex command with the heredoc
+	Language    string   // "lua", "mzscheme", "perl", "python", "python3", "ruby" or "tcl"
+	Marker      string   // end marker of heredoc, e.g. "EOF"; or empty for one line
+	Trim        bool     // trim; Vim removes the indent of the first line from Lines
+	Lines       []string // lines of the code as written without the end marker
+	BodyPos     Pos      // position of the first line; the lines follow it line by line
+}
+
+func (f *ForeignCode) Pos() Pos { return f.ForeignCode }
+func (f *ForeignCode) End() Pos { return f.EndPos }
+func (f *ForeignCode) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// Code returns the code which Vim runs, i.e. Lines joined by newlines. The
+// indent of the first line is removed from the lines if Trim is true.
+func (f *ForeignCode) Code() string {
+	if !f.Trim || len(f.Lines) == 0 {
+		return strings.Join(f.Lines, "\n")
+	}
+	first := f.Lines[0]
+	indent := first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+	var b strings.Builder
+	for i, line := range f.Lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		n := 0
+		for n < len(indent) && n < len(line) && line[n] == indent[n] {
+			n++
+		}
+		b.WriteString(line[n:])
+	}
+	return b.String()
+}
+
+func (*Autocmd) stmtNode()     {}
+func (*Augroup) stmtNode()     {}
+func (*Map) stmtNode()         {}
+func (*UserCommand) stmtNode() {}
+func (*Highlight) stmtNode()   {}
+func (*Syntax) stmtNode()      {}
+func (*Set) stmtNode()         {}
+func (*ForeignCode) stmtNode() {}
+
+// ExcmdNode is implemented by the nodes in this file. ExcmdText returns
+// Text, so that the nodes can be handled as Excmd.
+type ExcmdNode interface {
+	ExCommand
+	ExcmdText() string
+}
+
+func (a *Autocmd) ExcmdText() string     { return a.Text }
+func (a *Augroup) ExcmdText() string     { return a.Text }
+func (m *Map) ExcmdText() string         { return m.Text }
+func (u *UserCommand) ExcmdText() string { return u.Text }
+func (h *Highlight) ExcmdText() string   { return h.Text }
+func (s *Syntax) ExcmdText() string      { return s.Text }
+func (s *Set) ExcmdText() string         { return s.Text }
+func (f *ForeignCode) ExcmdText() string { return f.Text }
@@ -3,12 +3,15 @@
 package ast
 
 import (
+	"strings"
+
 	"github.com/vim-jp/go-vimlparser/token"
 )
 
 // Node is the interface for all node types to implement.
 type Node interface {
 	Pos() Pos // position of first character belonging to the node
+	End() Pos // position of first character immediately after the node
 }
 
 // Statement is the interface for statement (Ex command or Comment).
@@ -35,32 +38,75 @@ type Expr interface {
 type File struct {
 	Start Pos         // position of start of node.
 	Body  []Statement // top-level declarations; or nil
+
+	// Comments which are not Comment statements in Body: trailing comments
+	// after commands and comments in line continuations ("\ ).
+	Comments []*CommentGroup
+
+	BlankLines []int // line numbers of empty lines in increasing order
+
+	Vim9 bool // Vim9 script starting with :vim9script
 }
 
 func (f *File) Pos() Pos { return f.Start }
+func (f *File) End() Pos {
+	if n := len(f.Body); n > 0 {
+		return f.Body[n-1].End()
+	}
+	return f.Start
+}
 
 // vimlparser: COMMENT .str
 type Comment struct {
 	Statement
-	Quote Pos    // position of `"` starting the comment
+	Quote Pos    // position of `"` (or `#` in Vim9 script) starting the comment
 	Text  string // comment text (excluding '\n')
+	Vim9  bool   // `#` comment of Vim9 script
 }
 
 func (c *Comment) Pos() Pos { return c.Quote }
+func (c *Comment) End() Pos { return shift(c.Quote, len(`"`)+len(c.Text)) }
+
+// A CommentGroup represents a sequence of comments with no other tokens and
+// no empty lines between. It's either a trailing comment or comments in line
+// continuations.
+type CommentGroup struct {
+	List []*Comment // len(List) > 0
+}
+
+func (g *CommentGroup) Pos() Pos { return g.List[0].Pos() }
+func (g *CommentGroup) End() Pos { return g.List[len(g.List)-1].End() }
+
+// Text returns the text of the comment group. Comment markers (", "\ ) are
+// removed and lines are separated by '\n'.
+func (g *CommentGroup) Text() string {
+	lines := make([]string, 0, len(g.List))
+	for _, c := range g.List {
+		text := c.Text
+		if strings.HasPrefix(text, `\ `) {
+			text = text[2:]
+		}
+		lines = append(lines, strings.TrimSpace(text))
+	}
+	return strings.Join(lines, "\n")
+}
 
 // vimlparser: EXCMD .ea .str
 type Excmd struct {
 	Excmd   Pos    // position of starting the excmd
+	EndPos  Pos    // position immediately after the command
 	Command string // Ex comamnd
 	ExArg   ExArg  // Ex command arg
 }
 
 func (e *Excmd) Pos() Pos { return e.Excmd }
+func (e *Excmd) End() Pos { return e.EndPos }
 func (e *Excmd) Cmd() Cmd { return *e.ExArg.Cmd }
 
 // vimlparser: FUNCTION .ea .body .left .rlist .attr .endfunction
 type Function struct {
 	Func        Pos          // position of starting the :function
+	EndPos      Pos          // position immediately after the command
 	ExArg       ExArg        // Ex command arg
 	Body        []Statement  // function body
 	Name        Expr         // function name
@@ -78,53 +124,63 @@ type FuncAttr struct {
 }
 
 func (f *Function) Pos() Pos { return f.Func }
+func (f *Function) End() Pos { return f.EndPos }
 func (f *Function) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ENDFUNCTION .ea
 type EndFunction struct {
 	EndFunc Pos   // position of starting the :endfunction
+	EndPos  Pos   // position immediately after the command
 	ExArg   ExArg // Ex command arg
 }
 
 func (f *EndFunction) Pos() Pos { return f.EndFunc }
+func (f *EndFunction) End() Pos { return f.EndPos }
 func (f *EndFunction) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: DELFUNCTION .ea
 type DelFunction struct {
 	DelFunc Pos   // position of starting the :delfunction
+	EndPos  Pos   // position immediately after the command
 	ExArg   ExArg // Ex command arg
 	Name    Expr  // function name to delete
 }
 
 func (f *DelFunction) Pos() Pos { return f.DelFunc }
+func (f *DelFunction) End() Pos { return f.EndPos }
 func (f *DelFunction) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: RETURN .ea .left
 type Return struct {
 	Return Pos   // position of starting the :return
+	EndPos Pos   // position immediately after the command
 	ExArg  ExArg // Ex command arg
 	Result Expr  // expression to return
 }
 
 func (f *Return) Pos() Pos { return f.Return }
+func (f *Return) End() Pos { return f.EndPos }
 func (f *Return) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: EXCALL .ea .left
 type ExCall struct {
 	ExCall   Pos       // position of starting the :call
+	EndPos   Pos       // position immediately after the command
 	ExArg    ExArg     // Ex command arg
 	FuncCall *CallExpr // a function call
 }
 
 func (f *ExCall) Pos() Pos { return f.ExCall }
+func (f *ExCall) End() Pos { return f.EndPos }
 func (f *ExCall) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: LET .ea .op .left .list .rest .right
 // vimlparser: CONST .ea .op .left .list .rest .right
 type Let struct {
-	Let   Pos    // position of starting the :let
-	ExArg ExArg  // Ex command arg
-	Op    string // operator
+	Let    Pos    // position of starting the :let
+	EndPos Pos    // position immediately after the command
+	ExArg  ExArg  // Ex command arg
+	Op     string // operator
 
 	// :let {'a'} = 1
 	//      ^^^^^ Left
@@ -140,43 +196,51 @@ type Let struct {
 }
 
 func (f *Let) Pos() Pos { return f.Let }
+func (f *Let) End() Pos { return f.EndPos }
 func (f *Let) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: UNLET .ea .list
 type UnLet struct {
-	UnLet Pos    // position of starting the :unlet
-	ExArg ExArg  // Ex command arg
-	List  []Expr // list to unlet
+	UnLet  Pos    // position of starting the :unlet
+	EndPos Pos    // position immediately after the command
+	ExArg  ExArg  // Ex command arg
+	List   []Expr // list to unlet
 }
 
 func (f *UnLet) Pos() Pos { return f.UnLet }
+func (f *UnLet) End() Pos { return f.EndPos }
 func (f *UnLet) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: LOCKVAR .ea .depth .list
 type LockVar struct {
 	LockVar Pos    // position of starting the :lockvar
+	EndPos  Pos    // position immediately after the command
 	ExArg   ExArg  // Ex command arg
 	Depth   int    // default: 0
 	List    []Expr // list to lockvar
 }
 
 func (f *LockVar) Pos() Pos { return f.LockVar }
+func (f *LockVar) End() Pos { return f.EndPos }
 func (f *LockVar) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: UNLOCKVAR .ea .depth .list
 type UnLockVar struct {
 	UnLockVar Pos    // position of starting the :lockvar
+	EndPos    Pos    // position immediately after the command
 	ExArg     ExArg  // Ex command arg
 	Depth     int    // default: 0
 	List      []Expr // list to lockvar
 }
 
 func (f *UnLockVar) Pos() Pos { return f.UnLockVar }
+func (f *UnLockVar) End() Pos { return f.EndPos }
 func (f *UnLockVar) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: IF .ea .body .cond .elseif .else .endif
 type If struct {
 	If        Pos         // position of starting the :if
+	EndPos    Pos         // position immediately after the command
 	ExArg     ExArg       // Ex command arg
 	Body      []Statement // body of if statement
 	Condition Expr        // condition
@@ -186,41 +250,49 @@ type If struct {
 }
 
 func (f *If) Pos() Pos { return f.If }
+func (f *If) End() Pos { return f.EndPos }
 func (f *If) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ELSEIF .ea .body .cond
 type ElseIf struct {
 	ElseIf    Pos         // position of starting the :elseif
+	EndPos    Pos         // position immediately after the command
 	ExArg     ExArg       // Ex command arg
 	Body      []Statement // body of elseif statement
 	Condition Expr        // condition
 }
 
 func (f *ElseIf) Pos() Pos { return f.ElseIf }
+func (f *ElseIf) End() Pos { return f.EndPos }
 func (f *ElseIf) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ELSE .ea .body
 type Else struct {
-	Else  Pos         // position of starting the :else
-	ExArg ExArg       // Ex command arg
-	Body  []Statement // body of else statement
+	Else   Pos         // position of starting the :else
+	EndPos Pos         // position immediately after the command
+	ExArg  ExArg       // Ex command arg
+	Body   []Statement // body of else statement
 }
 
 func (f *Else) Pos() Pos { return f.Else }
+func (f *Else) End() Pos { return f.EndPos }
 func (f *Else) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ENDIF .ea
 type EndIf struct {
-	EndIf Pos   // position of starting the :endif
-	ExArg ExArg // Ex command arg
+	EndIf  Pos   // position of starting the :endif
+	EndPos Pos   // position immediately after the command
+	ExArg  ExArg // Ex command arg
 }
 
 func (f *EndIf) Pos() Pos { return f.EndIf }
+func (f *EndIf) End() Pos { return f.EndPos }
 func (f *EndIf) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: WHILE .ea .body .cond .endwhile
 type While struct {
 	While     Pos         // position of starting the :while
+	EndPos    Pos         // position immediately after the command
 	ExArg     ExArg       // Ex command arg
 	Body      []Statement // body of while statement
 	Condition Expr        // condition
@@ -228,22 +300,26 @@ type While struct {
 }
 
 func (f *While) Pos() Pos { return f.While }
+func (f *While) End() Pos { return f.EndPos }
 func (f *While) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ENDWHILE .ea
 type EndWhile struct {
 	EndWhile Pos   // position of starting the :endwhile
+	EndPos   Pos   // position immediately after the command
 	ExArg    ExArg // Ex command arg
 }
 
 func (f *EndWhile) Pos() Pos { return f.EndWhile }
+func (f *EndWhile) End() Pos { return f.EndPos }
 func (f *EndWhile) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: FOR .ea .body .left .list .rest .right .endfor
 type For struct {
-	For   Pos         // position of starting the :for
-	ExArg ExArg       // Ex command arg
-	Body  []Statement // body of for statement
+	For    Pos         // position of starting the :for
+	EndPos Pos         // position immediately after the command
+	ExArg  ExArg       // Ex command arg
+	Body   []Statement // body of for statement
 
 	// :for {'a'} in right
 	//      ^^^^^ Left
@@ -260,38 +336,46 @@ type For struct {
 }
 
 func (f *For) Pos() Pos { return f.For }
+func (f *For) End() Pos { return f.EndPos }
 func (f *For) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ENDFOR .ea
 type EndFor struct {
 	EndFor Pos   // position of starting the :endfor
+	EndPos Pos   // position immediately after the command
 	ExArg  ExArg // Ex command arg
 }
 
 func (f *EndFor) Pos() Pos { return f.EndFor }
+func (f *EndFor) End() Pos { return f.EndPos }
 func (f *EndFor) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: CONTINUE .ea
 type Continue struct {
 	Continue Pos   // position of starting the :continue
+	EndPos   Pos   // position immediately after the command
 	ExArg    ExArg // Ex command arg
 }
 
 func (f *Continue) Pos() Pos { return f.Continue }
+func (f *Continue) End() Pos { return f.EndPos }
 func (f *Continue) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: BREAK .ea
 type Break struct {
-	Break Pos   // position of starting the :break
-	ExArg ExArg // Ex command arg
+	Break  Pos   // position of starting the :break
+	EndPos Pos   // position immediately after the command
+	ExArg  ExArg // Ex command arg
 }
 
 func (f *Break) Pos() Pos { return f.Break }
+func (f *Break) End() Pos { return f.EndPos }
 func (f *Break) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: TRY .ea .body .catch .finally .endtry
 type Try struct {
 	Try     Pos         // position of starting the :try
+	EndPos  Pos         // position immediately after the command
 	ExArg   ExArg       // Ex command arg
 	Body    []Statement // body of try statement
 	Catch   []*Catch
@@ -300,58 +384,69 @@ type Try struct {
 }
 
 func (f *Try) Pos() Pos { return f.Try }
+func (f *Try) End() Pos { return f.EndPos }
 func (f *Try) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: CATCH .ea .body .pattern
 type Catch struct {
 	Catch   Pos         // position of starting the :catch
+	EndPos  Pos         // position immediately after the command
 	ExArg   ExArg       // Ex command arg
 	Body    []Statement // body of catch statement
 	Pattern string      // pattern
 }
 
 func (f *Catch) Pos() Pos { return f.Catch }
+func (f *Catch) End() Pos { return f.EndPos }
 func (f *Catch) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: FINALLY .ea .body
 type Finally struct {
 	Finally Pos         // position of starting the :finally
+	EndPos  Pos         // position immediately after the command
 	ExArg   ExArg       // Ex command arg
 	Body    []Statement // body of else statement
 }
 
 func (f *Finally) Pos() Pos { return f.Finally }
+func (f *Finally) End() Pos { return f.EndPos }
 func (f *Finally) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ENDTRY .ea
 type EndTry struct {
 	EndTry Pos   // position of starting the :endtry
+	EndPos Pos   // position immediately after the command
 	ExArg  ExArg // Ex command arg
 }
 
 func (f *EndTry) Pos() Pos { return f.EndTry }
+func (f *EndTry) End() Pos { return f.EndPos }
 func (f *EndTry) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: THROW .ea .left
 // :throw {Expr}
 type Throw struct {
-	Throw Pos   // position of starting the :throw
-	ExArg ExArg // Ex command arg
-	Expr  Expr
+	Throw  Pos   // position of starting the :throw
+	EndPos Pos   // position immediately after the command
+	ExArg  ExArg // Ex command arg
+	Expr   Expr
 }
 
 func (f *Throw) Pos() Pos { return f.Throw }
+func (f *Throw) End() Pos { return f.EndPos }
 func (f *Throw) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: EVAL .ea .left
 // :eval {Expr}
 type Eval struct {
-	Eval  Pos   // position of starting the :eval
-	ExArg ExArg // Ex command arg
-	Expr  Expr
+	Eval   Pos   // position of starting the :eval
+	EndPos Pos   // position immediately after the command
+	ExArg  ExArg // Ex command arg
+	Expr   Expr
 }
 
 func (f *Eval) Pos() Pos { return f.Eval }
+func (f *Eval) End() Pos { return f.EndPos }
 func (f *Eval) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ECHO .ea .list
@@ -362,34 +457,40 @@ func (f *Eval) Cmd() Cmd { return *f.ExArg.Cmd }
 // {echocmd}: echo, echon, echomsg, echoerr
 type EchoCmd struct {
 	Start   Pos    // position of starting the echo-command
+	EndPos  Pos    // position immediately after the command
 	CmdName string // echo-command name
 	ExArg   ExArg  // Ex command arg
 	Exprs   []Expr
 }
 
 func (f *EchoCmd) Pos() Pos { return f.Start }
+func (f *EchoCmd) End() Pos { return f.EndPos }
 func (f *EchoCmd) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: ECHOHL .ea .str
 // :echohl {name}
 type Echohl struct {
 	Echohl Pos   // position of starting the :echohl
+	EndPos Pos   // position immediately after the command
 	ExArg  ExArg // Ex command arg
 	Name   string
 }
 
 func (f *Echohl) Pos() Pos { return f.Echohl }
+func (f *Echohl) End() Pos { return f.EndPos }
 func (f *Echohl) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: EXECUTE .ea .list
 // :execute {Expr}..
 type Execute struct {
 	Execute Pos   // position of starting the :execute
+	EndPos  Pos   // position immediately after the command
 	ExArg   ExArg // Ex command arg
 	Exprs   []Expr
 }
 
 func (f *Execute) Pos() Pos { return f.Execute }
+func (f *Execute) End() Pos { return f.EndPos }
 func (f *Execute) Cmd() Cmd { return *f.ExArg.Cmd }
 
 // vimlparser: TERNARY .cond .left .right
@@ -402,6 +503,7 @@ type TernaryExpr struct {
 }
 
 func (f *TernaryExpr) Pos() Pos { return f.Ternary }
+func (f *TernaryExpr) End() Pos { return f.Right.End() }
 
 type BinaryExpr struct {
 	Left  Expr        // left operand
@@ -411,6 +513,7 @@ type BinaryExpr struct {
 }
 
 func (f *BinaryExpr) Pos() Pos { return f.OpPos }
+func (f *BinaryExpr) End() Pos { return f.Right.End() }
 
 type UnaryExpr struct {
 	OpPos Pos         // position of Op
@@ -419,15 +522,18 @@ type UnaryExpr struct {
 }
 
 func (f *UnaryExpr) Pos() Pos { return f.OpPos }
+func (f *UnaryExpr) End() Pos { return f.X.End() }
 
 // Left[Right]
 type SubscriptExpr struct {
 	Lbrack Pos // position of "["
 	Left   Expr
 	Right  Expr
+	Rbrack Pos // position of "]"
 }
 
 func (f *SubscriptExpr) Pos() Pos { return f.Lbrack }
+func (f *SubscriptExpr) End() Pos { return shift(f.Rbrack, 1) }
 
 // X[Low:High]
 type SliceExpr struct {
@@ -435,9 +541,11 @@ type SliceExpr struct {
 	Lbrack Pos  // position of "["
 	Low    Expr // begin of slice range; or nil
 	High   Expr // end of slice range; or nil
+	Rbrack Pos  // position of "]"
 }
 
 func (f *SliceExpr) Pos() Pos { return f.Lbrack }
+func (f *SliceExpr) End() Pos { return shift(f.Rbrack, 1) }
 
 // vimlparser: METHOD .left .right
 type MethodExpr struct {
@@ -445,18 +553,22 @@ type MethodExpr struct {
 	Method Expr   // method
 	Lparen Pos    // position of "("
 	Args   []Expr // function arguments; or nil
+	Rparen Pos    // position of ")"
 }
 
 func (c *MethodExpr) Pos() Pos { return c.Lparen }
+func (c *MethodExpr) End() Pos { return shift(c.Rparen, 1) }
 
 // vimlparser: CALL .left .rlist
 type CallExpr struct {
 	Fun    Expr   // function expression
 	Lparen Pos    // position of "("
 	Args   []Expr // function arguments; or nil
+	Rparen Pos    // position of ")"
 }
 
 func (c *CallExpr) Pos() Pos { return c.Lparen }
+func (c *CallExpr) End() Pos { return shift(c.Rparen, 1) }
 
 // Left.Right
 // vimlparser: Dot .left .right
@@ -467,28 +579,36 @@ type DotExpr struct {
 }
 
 func (c *DotExpr) Pos() Pos { return c.Dot }
+func (c *DotExpr) End() Pos { return c.Right.End() }
 
 type BasicLit struct {
 	ValuePos Pos         // literal position
 	Kind     token.Token // token.INT, token.STRING, token.OPTION, token.ENV, token.REG
 	Value    string
+	EndPos   Pos // position immediately after the literal
 }
 
 func (c *BasicLit) Pos() Pos { return c.ValuePos }
+func (c *BasicLit) End() Pos { return c.EndPos }
 
 type List struct {
 	Lsquare Pos // position of "["
 	Values  []Expr
+	Rsquare Pos // position of "]"
 }
 
 func (c *List) Pos() Pos { return c.Lsquare }
+func (c *List) End() Pos { return shift(c.Rsquare, 1) }
 
 type Dict struct {
-	Lcurlybrace Pos // position of "{"
+	Lcurlybrace Pos // position of "{", or "#" of #{}
 	Entries     []KeyValue
+	Rcurlybrace Pos  // position of "}"
+	Literal     bool // #{} whose keys are literal strings
 }
 
 func (c *Dict) Pos() Pos { return c.Lcurlybrace }
+func (c *Dict) End() Pos { return shift(c.Rcurlybrace, 1) }
 
 type KeyValue struct {
 	Key   Expr
@@ -504,6 +624,7 @@ type CurlyName struct {
 }
 
 func (c *CurlyName) Pos() Pos { return c.CurlyName }
+func (c *CurlyName) End() Pos { return c.Parts[len(c.Parts)-1].End() }
 
 type CurlyNamePart interface {
 	Expr
@@ -515,9 +636,11 @@ type CurlyNamePart interface {
 type CurlyNameLit struct {
 	CurlyNameLit Pos // position
 	Value        string
+	EndPos       Pos // position immediately after the literal
 }
 
 func (c *CurlyNameLit) Pos() Pos          { return c.CurlyNameLit }
+func (c *CurlyNameLit) End() Pos          { return c.EndPos }
 func (c *CurlyNameLit) IsCurlyExpr() bool { return false }
 
 // aaa{x{y{1+2}}}bbb
@@ -525,18 +648,22 @@ func (c *CurlyNameLit) IsCurlyExpr() bool { return false }
 type CurlyNameExpr struct {
 	CurlyNameExpr Pos // position
 	Value         Expr
+	Rcurlybrace   Pos // position of "}"
 }
 
 func (c *CurlyNameExpr) Pos() Pos          { return c.CurlyNameExpr }
+func (c *CurlyNameExpr) End() Pos          { return shift(c.Rcurlybrace, 1) }
 func (c *CurlyNameExpr) IsCurlyExpr() bool { return true }
 
 // An Ident node represents an identifier.
 type Ident struct {
 	NamePos Pos    // identifier position
 	Name    string // identifier name
+	EndPos  Pos    // position immediately after the identifier
 }
 
 func (i *Ident) Pos() Pos { return i.NamePos }
+func (i *Ident) End() Pos { return i.EndPos }
 
 // LambdaExpr node represents lambda.
 // vimlparser: LAMBDA .rlist .left
@@ -545,18 +672,22 @@ type LambdaExpr struct {
 	Lcurlybrace Pos      // position of "{"
 	Params      []*Ident // parameters
 	Expr        Expr
+	Rcurlybrace Pos // position of "}"
 }
 
 func (i *LambdaExpr) Pos() Pos { return i.Lcurlybrace }
+func (i *LambdaExpr) End() Pos { return shift(i.Rcurlybrace, 1) }
 
 // ParenExpr node represents a parenthesized expression.
 // vimlparser: PARENEXPR .value
 type ParenExpr struct {
 	Lparen Pos  // position of "("
 	X      Expr // parenthesized expression
+	Rparen Pos  // position of ")"
 }
 
 func (i *ParenExpr) Pos() Pos { return i.Lparen }
+func (i *ParenExpr) End() Pos { return shift(i.Rparen, 1) }
 
 // HeredocExpr node represents a heredoc expression.
 // vimlparser: HEREDOC .rlist .op .body
@@ -565,9 +696,35 @@ type HeredocExpr struct {
 	Flags     []Expr // modifiers [trim]; or nil
 	EndMarker string // {endmarker}
 	Body      []Expr // body
+	EndPos    Pos    // position immediately after {endmarker} line
 }
 
 func (i *HeredocExpr) Pos() Pos { return i.OpPos }
+func (i *HeredocExpr) End() Pos { return i.EndPos }
+
+// A BadStmt node is a placeholder for statements containing syntax errors
+// for which no correct statement nodes can be created.
+// It is only produced when parsing in error-recovering mode.
+type BadStmt struct {
+	From Pos    // position of start of bad statement
+	To   Pos    // position of end of bad statement (exclusive)
+	Text string // source text of bad statement
+}
+
+func (b *BadStmt) Pos() Pos { return b.From }
+func (b *BadStmt) End() Pos { return b.To }
+
+// A BadExpr node is a placeholder for expressions containing syntax errors
+// for which no correct expression nodes can be created.
+// It is only produced when parsing in error-recovering mode.
+type BadExpr struct {
+	From Pos    // position of start of bad expression
+	To   Pos    // position of end of bad expression (exclusive)
+	Text string // source text of bad expression
+}
+
+func (b *BadExpr) Pos() Pos { return b.From }
+func (b *BadExpr) End() Pos { return b.To }
 
 // stmtNode() ensures that only ExComamnd and Comment nodes can be assigned to
 // an Statement.
@@ -603,6 +760,7 @@ func (*UnLockVar) stmtNode()  {}
 func (*While) stmtNode()      {}
 
 func (*Comment) stmtNode() {}
+func (*BadStmt) stmtNode() {}
 
 // exprNode() ensures that only expression nodes can be assigned to an Expr.
 //
@@ -624,3 +782,4 @@ func (*Ident) exprNode()         {}
 func (*LambdaExpr) exprNode()    {}
 func (*ParenExpr) exprNode()     {}
 func (*HeredocExpr) exprNode()   {}
+func (*BadExpr) exprNode()       {}
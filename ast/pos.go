@@ -25,3 +25,10 @@ func (pos Pos) String() string {
 	s += fmt.Sprintf("%d:%d", pos.Line, pos.Column)
 	return s
 }
+
+// shift returns the position n bytes after pos in the same line.
+func shift(pos Pos, n int) Pos {
+	pos.Offset += n
+	pos.Column += n
+	return pos
+}
@@ -0,0 +1,235 @@
+package ast
+
+// Nodes of Vim9 script. They only exist in the Go port and don't have
+// vim-vimlparser equivalents.
+
+// Vim9Attr represents modifiers of Vim9 declarations.
+type Vim9Attr struct {
+	Export   bool // export def, export var, export class...
+	Public   bool // public var in classes
+	Static   bool // static def and static var in classes
+	Abstract bool // abstract class and abstract def
+}
+
+// vimlparser: DEF .ea .left .params .rtype .body .enddef .vim9attr
+// :def {name}({params}): {type}
+type Def struct {
+	Def    Pos         // position of starting the :def
+	EndPos Pos         // position immediately after the command
+	ExArg  ExArg       // Ex command arg
+	Attr   Vim9Attr    // modifiers
+	Body   []Statement // function body
+	Name   Expr        // function name
+	Params []*Param    // parameters
+	Result *Type       // return type; or nil
+
+	// :enddef; or nil for abstract methods and methods of interfaces,
+	// which don't have body.
+	EndDef *EndDef
+}
+
+func (f *Def) Pos() Pos { return f.Def }
+func (f *Def) End() Pos { return f.EndPos }
+func (f *Def) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// vimlparser: ENDDEF .ea
+type EndDef struct {
+	EndDef Pos   // position of starting the :enddef
+	EndPos Pos   // position immediately after the command
+	ExArg  ExArg // Ex command arg
+}
+
+func (f *EndDef) Pos() Pos { return f.EndDef }
+func (f *EndDef) End() Pos { return f.EndPos }
+func (f *EndDef) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// Param is a parameter of :def function or Vim9 lambda.
+// vimlparser: PARAM .left .rtype .right .op
+// {name}: {type} = {default}
+type Param struct {
+	Start    Pos    // position of the name; or "..." of variadic parameter
+	Name     *Ident // parameter name, e.g. "x" and "this.x"
+	Type     *Type  // type; or nil
+	Default  Expr   // default value; or nil
+	Variadic bool   // ...{name}
+}
+
+func (p *Param) Pos() Pos { return p.Start }
+func (p *Param) End() Pos {
+	switch {
+	case p.Default != nil:
+		return p.Default.End()
+	case p.Type != nil:
+		return p.Type.End()
+	}
+	return p.Name.End()
+}
+
+// Type is a type in Vim9 script, e.g. number, list<string> and
+// func(any): bool.
+// vimlparser: TYPE .str
+type Type struct {
+	TypePos Pos    // position of the type
+	Name    string // type as written in the source
+	EndPos  Pos    // position immediately after the type
+}
+
+func (t *Type) Pos() Pos { return t.TypePos }
+func (t *Type) End() Pos { return t.EndPos }
+
+// VarDecl node represents variable declaration of Vim9 script.
+// vimlparser: VAR .ea .left .list .rest .rtype .right .vim9attr
+// :var {name}: {type} = {expr}
+// :final and :const in Vim9 script are also VarDecl.
+type VarDecl struct {
+	Var    Pos      // position of starting the :var, :final or :const
+	EndPos Pos      // position immediately after the command
+	ExArg  ExArg    // Ex command arg
+	Attr   Vim9Attr // modifiers
+
+	// :var [a, b; rest] = [1, 2, 3]
+	Left Expr   // variable name; or nil
+	List []Expr // variable names; or nil
+	Rest Expr   // rest of variable names; or nil
+
+	Type  *Type // type; or nil
+	Right Expr  // initial value; or nil
+}
+
+func (f *VarDecl) Pos() Pos { return f.Var }
+func (f *VarDecl) End() Pos { return f.EndPos }
+func (f *VarDecl) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// Assign node represents assignment without :let in Vim9 script.
+// vimlparser: ASSIGN .ea .op .left .list .rest .right
+// {lhs} = {expr}, {lhs} += {expr}, ++{lhs}...
+type Assign struct {
+	Start  Pos    // position of lhs; or "++" and "--"
+	EndPos Pos    // position immediately after the statement
+	ExArg  ExArg  // command modifiers; Cmd is nil
+	Op     string // "=", "+=", "-=", "*=", "/=", "%=", "..=", "++" or "--"
+
+	Left Expr   // lhs; or nil
+	List []Expr // lhs list; or nil
+	Rest Expr   // rest of lhs list; or nil
+
+	Right Expr // rhs expression; or nil for "++" and "--"
+}
+
+func (f *Assign) Pos() Pos { return f.Start }
+func (f *Assign) End() Pos { return f.EndPos }
+
+// ExprStmt node represents expression used as a statement in Vim9 script,
+// e.g. function call without :call.
+// vimlparser: EXPRSTMT .ea .left
+type ExprStmt struct {
+	Start Pos   // position of the expression
+	ExArg ExArg // command modifiers; Cmd is nil
+	X     Expr  // expression
+}
+
+func (f *ExprStmt) Pos() Pos { return f.Start }
+func (f *ExprStmt) End() Pos { return f.X.End() }
+
+// vimlparser: IMPORT .ea .op .left .right
+// :import [autoload] {path} [as {name}]
+type Import struct {
+	Import   Pos    // position of starting the :import
+	EndPos   Pos    // position immediately after the command
+	ExArg    ExArg  // Ex command arg
+	Autoload bool   // import autoload
+	Path     Expr   // script to import, usually a string literal
+	As       *Ident // name of the imported script; or nil
+}
+
+func (f *Import) Pos() Pos { return f.Import }
+func (f *Import) End() Pos { return f.EndPos }
+func (f *Import) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// Class node represents class, interface and enum.
+// vimlparser: CLASS .ea .left .right .list .rlist .body .endclass .vim9attr
+// :class {name} [extends {base}] [implements {interface}, ...]
+// :interface {name} [extends {interface}]
+// :enum {name} [implements {interface}, ...]
+type Class struct {
+	Class      Pos         // position of starting the :class, :interface or :enum
+	EndPos     Pos         // position immediately after the command
+	ExArg      ExArg       // Ex command arg
+	Attr       Vim9Attr    // modifiers
+	Name       *Ident      // class name
+	Extends    Expr        // base class; or nil
+	Implements []Expr      // implemented interfaces; or nil
+	Values     []Expr      // values of enum, *Ident or *CallExpr; or nil
+	Body       []Statement // members and methods
+	EndClass   *EndClass   // :endclass, :endinterface or :endenum
+}
+
+func (f *Class) Pos() Pos { return f.Class }
+func (f *Class) End() Pos { return f.EndPos }
+func (f *Class) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// vimlparser: ENDCLASS .ea
+// :endclass, :endinterface and :endenum
+type EndClass struct {
+	EndClass Pos   // position of starting the end command
+	EndPos   Pos   // position immediately after the command
+	ExArg    ExArg // Ex command arg
+}
+
+func (f *EndClass) Pos() Pos { return f.EndClass }
+func (f *EndClass) End() Pos { return f.EndPos }
+func (f *EndClass) Cmd() Cmd { return *f.ExArg.Cmd }
+
+// Vim9LambdaExpr node represents lambda of Vim9 script.
+// vimlparser: LAMBDA9 .params .rtype .left .body
+// (Params): Result => Expr
+// (Params): Result => { Body }
+type Vim9LambdaExpr struct {
+	Lparen Pos         // position of "("
+	Params []*Param    // parameters
+	Result *Type       // return type; or nil
+	Expr   Expr        // expression; or nil for block
+	Body   []Statement // statements of block; or nil
+	EndPos Pos         // position immediately after the lambda
+}
+
+func (i *Vim9LambdaExpr) Pos() Pos { return i.Lparen }
+func (i *Vim9LambdaExpr) End() Pos { return i.EndPos }
+
+// CastExpr node represents type cast of Vim9 script.
+// vimlparser: CAST .rtype .left
+// <Type>X
+type CastExpr struct {
+	Lt   Pos   // position of "<"
+	Type *Type // type
+	X    Expr  // expression
+}
+
+func (c *CastExpr) Pos() Pos { return c.Lt }
+func (c *CastExpr) End() Pos { return c.X.End() }
+
+// InterpolatedString node represents string interpolation, e.g.
+// $"x = {x}".
+// vimlparser: INTERPOLATED .value .list
+type InterpolatedString struct {
+	ValuePos Pos    // position of "$"
+	Value    string // string literal including "$" and quotes
+	Exprs    []Expr // expressions in {}
+	EndPos   Pos    // position immediately after the literal
+}
+
+func (i *InterpolatedString) Pos() Pos { return i.ValuePos }
+func (i *InterpolatedString) End() Pos { return i.EndPos }
+
+func (*Def) stmtNode()      {}
+func (*EndDef) stmtNode()   {}
+func (*VarDecl) stmtNode()  {}
+func (*Assign) stmtNode()   {}
+func (*ExprStmt) stmtNode() {}
+func (*Import) stmtNode()   {}
+func (*Class) stmtNode()    {}
+func (*EndClass) stmtNode() {}
+
+func (*Vim9LambdaExpr) exprNode()     {}
+func (*CastExpr) exprNode()           {}
+func (*InterpolatedString) exprNode() {}
@@ -32,16 +32,27 @@ func Walk(v Visitor, node Node) {
 	switch n := node.(type) {
 	case *File:
 		walkStmtList(v, n.Body)
+		for _, g := range n.Comments {
+			Walk(v, g)
+		}
 
 	case *Comment: // nothing to do
 
+	case *CommentGroup:
+		for _, c := range n.List {
+			Walk(v, c)
+		}
+
 	case *Excmd: // nothing to do
 
 	case *Function:
 		Walk(v, n.Name)
 		walkIdentList(v, n.Params)
+		walkExprList(v, n.DefaultArgs)
 		walkStmtList(v, n.Body)
-		Walk(v, n.EndFunction)
+		if n.EndFunction != nil {
+			Walk(v, n.EndFunction)
+		}
 
 	case *EndFunction: // nothing to do
 
@@ -94,7 +105,9 @@ func Walk(v Visitor, node Node) {
 	case *While:
 		Walk(v, n.Condition)
 		walkStmtList(v, n.Body)
-		Walk(v, n.EndWhile)
+		if n.EndWhile != nil {
+			Walk(v, n.EndWhile)
+		}
 
 	case *EndWhile: // nothing to do
 
@@ -104,7 +117,9 @@ func Walk(v Visitor, node Node) {
 		Walk(v, n.Rest)
 		Walk(v, n.Right)
 		walkStmtList(v, n.Body)
-		Walk(v, n.EndFor)
+		if n.EndFor != nil {
+			Walk(v, n.EndFor)
+		}
 
 	case *EndFor: // nothing to do
 
@@ -205,6 +220,7 @@ func Walk(v Visitor, node Node) {
 
 	case *LambdaExpr:
 		walkIdentList(v, n.Params)
+		Walk(v, n.Expr)
 
 	case *ParenExpr:
 		Walk(v, n.X)
@@ -213,6 +229,107 @@ func Walk(v Visitor, node Node) {
 		walkExprList(v, n.Flags)
 		walkExprList(v, n.Body)
 
+	case *Def:
+		Walk(v, n.Name)
+		walkParamList(v, n.Params)
+		if n.Result != nil {
+			Walk(v, n.Result)
+		}
+		walkStmtList(v, n.Body)
+		if n.EndDef != nil {
+			Walk(v, n.EndDef)
+		}
+
+	case *EndDef: // nothing to do
+
+	case *Param:
+		Walk(v, n.Name)
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
+		Walk(v, n.Default)
+
+	case *Type: // nothing to do
+
+	case *VarDecl:
+		Walk(v, n.Left)
+		walkExprList(v, n.List)
+		Walk(v, n.Rest)
+		if n.Type != nil {
+			Walk(v, n.Type)
+		}
+		Walk(v, n.Right)
+
+	case *Assign:
+		Walk(v, n.Left)
+		walkExprList(v, n.List)
+		Walk(v, n.Rest)
+		Walk(v, n.Right)
+
+	case *ExprStmt:
+		Walk(v, n.X)
+
+	case *Import:
+		Walk(v, n.Path)
+		if n.As != nil {
+			Walk(v, n.As)
+		}
+
+	case *Class:
+		Walk(v, n.Name)
+		Walk(v, n.Extends)
+		walkExprList(v, n.Implements)
+		walkExprList(v, n.Values)
+		walkStmtList(v, n.Body)
+		if n.EndClass != nil {
+			Walk(v, n.EndClass)
+		}
+
+	case *EndClass: // nothing to do
+
+	case *Vim9LambdaExpr:
+		walkParamList(v, n.Params)
+		if n.Result != nil {
+			Walk(v, n.Result)
+		}
+		Walk(v, n.Expr)
+		walkStmtList(v, n.Body)
+
+	case *CastExpr:
+		Walk(v, n.Type)
+		Walk(v, n.X)
+
+	case *InterpolatedString:
+		walkExprList(v, n.Exprs)
+
+	case *Autocmd:
+		walkStmtList(v, n.Command)
+
+	case *Augroup: // nothing to do
+
+	case *Map:
+		if n.RhsExpr != nil {
+			Walk(v, n.RhsExpr)
+		}
+		walkStmtList(v, n.RhsCmds)
+
+	case *UserCommand:
+		walkStmtList(v, n.Body)
+
+	case *Placeholder: // nothing to do
+
+	case *Highlight: // nothing to do
+
+	case *Syntax: // nothing to do
+
+	case *Set: // nothing to do
+
+	case *ForeignCode: // nothing to do
+
+	case *BadStmt: // nothing to do
+
+	case *BadExpr: // nothing to do
+
 	default:
 		panic(fmt.Sprintf("ast.Walk: unexpected node type %T", n))
 	}
@@ -227,6 +344,12 @@ func walkIdentList(v Visitor, list []*Ident) {
 	}
 }
 
+func walkParamList(v Visitor, list []*Param) {
+	for _, x := range list {
+		Walk(v, x)
+	}
+}
+
 func walkExprList(v Visitor, list []Expr) {
 	for _, x := range list {
 		Walk(v, x)
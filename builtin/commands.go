@@ -0,0 +1,26 @@
+package builtin
+
+// Command is a builtin Ex command.
+type Command struct {
+	Name    string // full name, e.g. "cexpr"
+	Editors Editor // editors which have the command
+
+	// Since is the version which introduced the command, e.g. "7.4.858".
+	// It's the version of Neovim, e.g. "0.1.0", for commands of Neovim
+	// only. It's empty if unknown.
+	Since string
+}
+
+// LookupCommand returns the builtin command by the full name. It returns nil
+// if name is not a builtin command.
+func LookupCommand(name string) *Command {
+	return commandsByName[name]
+}
+
+var commandsByName = func() map[string]*Command {
+	m := make(map[string]*Command, len(commands))
+	for i := range commands {
+		m[commands[i].Name] = &commands[i]
+	}
+	return m
+}()
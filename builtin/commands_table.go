@@ -0,0 +1,587 @@
+// Code generated by gen_builtin.go; DO NOT EDIT.
+// source: vim90/doc/index.txt and version*.txt
+
+package builtin
+
+var commands = []Command{
+	{Name: "Next", Editors: Both, Since: ""},
+	{Name: "Print", Editors: Vim, Since: "5.2"},
+	{Name: "X", Editors: Vim, Since: ""},
+	{Name: "abbreviate", Editors: Both, Since: ""},
+	{Name: "abclear", Editors: Both, Since: ""},
+	{Name: "aboveleft", Editors: Both, Since: "6.0"},
+	{Name: "all", Editors: Both, Since: ""},
+	{Name: "amenu", Editors: Both, Since: "5.0"},
+	{Name: "anoremenu", Editors: Both, Since: ""},
+	{Name: "append", Editors: Both, Since: ""},
+	{Name: "argadd", Editors: Both, Since: ""},
+	{Name: "argdedupe", Editors: Both, Since: "9.0"},
+	{Name: "argdelete", Editors: Both, Since: ""},
+	{Name: "argdo", Editors: Both, Since: ""},
+	{Name: "argedit", Editors: Both, Since: ""},
+	{Name: "argglobal", Editors: Both, Since: ""},
+	{Name: "arglocal", Editors: Both, Since: ""},
+	{Name: "args", Editors: Both, Since: ""},
+	{Name: "argument", Editors: Both, Since: ""},
+	{Name: "ascii", Editors: Both, Since: ""},
+	{Name: "augroup", Editors: Both, Since: "5.0"},
+	{Name: "aunmenu", Editors: Both, Since: ""},
+	{Name: "autocmd", Editors: Both, Since: ""},
+	{Name: "bNext", Editors: Both, Since: ""},
+	{Name: "badd", Editors: Both, Since: "5.2"},
+	{Name: "ball", Editors: Both, Since: ""},
+	{Name: "balt", Editors: Both, Since: "8.2.1967"},
+	{Name: "bdelete", Editors: Both, Since: ""},
+	{Name: "behave", Editors: Both, Since: "5.2"},
+	{Name: "belowright", Editors: Both, Since: "6.0"},
+	{Name: "bfirst", Editors: Both, Since: ""},
+	{Name: "blast", Editors: Both, Since: ""},
+	{Name: "bmodified", Editors: Both, Since: ""},
+	{Name: "bnext", Editors: Both, Since: ""},
+	{Name: "botright", Editors: Both, Since: ""},
+	{Name: "bprevious", Editors: Both, Since: ""},
+	{Name: "break", Editors: Both, Since: ""},
+	{Name: "breakadd", Editors: Both, Since: "8.0.1505"},
+	{Name: "breakdel", Editors: Both, Since: ""},
+	{Name: "breaklist", Editors: Both, Since: ""},
+	{Name: "brewind", Editors: Both, Since: ""},
+	{Name: "browse", Editors: Both, Since: "5.2"},
+	{Name: "bufdo", Editors: Both, Since: "6.0"},
+	{Name: "buffer", Editors: Both, Since: ""},
+	{Name: "buffers", Editors: Both, Since: ""},
+	{Name: "bunload", Editors: Both, Since: ""},
+	{Name: "bwipeout", Editors: Both, Since: ""},
+	{Name: "cNext", Editors: Both, Since: ""},
+	{Name: "cNfile", Editors: Both, Since: "6.2.249"},
+	{Name: "cabbrev", Editors: Both, Since: ""},
+	{Name: "cabclear", Editors: Both, Since: ""},
+	{Name: "cabove", Editors: Both, Since: "8.1.1256"},
+	{Name: "caddbuffer", Editors: Both, Since: "7.0"},
+	{Name: "caddexpr", Editors: Both, Since: "7.0"},
+	{Name: "caddfile", Editors: Both, Since: "7.0"},
+	{Name: "cafter", Editors: Both, Since: "8.1.1275"},
+	{Name: "call", Editors: Both, Since: "5.2"},
+	{Name: "catch", Editors: Both, Since: ""},
+	{Name: "cbefore", Editors: Both, Since: "8.1.1275"},
+	{Name: "cbelow", Editors: Both, Since: "8.1.1256"},
+	{Name: "cbottom", Editors: Both, Since: "7.4.1997"},
+	{Name: "cbuffer", Editors: Both, Since: "7.0"},
+	{Name: "cc", Editors: Both, Since: ""},
+	{Name: "cclose", Editors: Both, Since: ""},
+	{Name: "cd", Editors: Both, Since: ""},
+	{Name: "cdo", Editors: Both, Since: "7.4.858"},
+	{Name: "center", Editors: Both, Since: ""},
+	{Name: "cexpr", Editors: Both, Since: "7.0"},
+	{Name: "cfdo", Editors: Both, Since: "7.4.858"},
+	{Name: "cfile", Editors: Both, Since: ""},
+	{Name: "cfirst", Editors: Both, Since: ""},
+	{Name: "cgetbuffer", Editors: Both, Since: "7.0"},
+	{Name: "cgetexpr", Editors: Both, Since: "7.0"},
+	{Name: "cgetfile", Editors: Both, Since: "6.1.032"},
+	{Name: "change", Editors: Both, Since: ""},
+	{Name: "changes", Editors: Both, Since: "6.3"},
+	{Name: "chdir", Editors: Both, Since: ""},
+	{Name: "checkpath", Editors: Both, Since: ""},
+	{Name: "checktime", Editors: Both, Since: "6.0"},
+	{Name: "chistory", Editors: Both, Since: "7.4.2049"},
+	{Name: "class", Editors: Vim, Since: "9.0"},
+	{Name: "clast", Editors: Both, Since: "5.0"},
+	{Name: "clearjumps", Editors: Both, Since: "7.4.1925"},
+	{Name: "clist", Editors: Both, Since: "7.4.1971"},
+	{Name: "close", Editors: Both, Since: ""},
+	{Name: "cmap", Editors: Both, Since: ""},
+	{Name: "cmapclear", Editors: Both, Since: ""},
+	{Name: "cmenu", Editors: Both, Since: ""},
+	{Name: "cnewer", Editors: Both, Since: "5.2"},
+	{Name: "cnext", Editors: Both, Since: ""},
+	{Name: "cnfile", Editors: Both, Since: ""},
+	{Name: "cnoreabbrev", Editors: Both, Since: ""},
+	{Name: "cnoremap", Editors: Both, Since: ""},
+	{Name: "cnoremenu", Editors: Both, Since: ""},
+	{Name: "colder", Editors: Both, Since: "5.2"},
+	{Name: "colorscheme", Editors: Both, Since: ""},
+	{Name: "comclear", Editors: Both, Since: "5.2"},
+	{Name: "command", Editors: Both, Since: "5.2"},
+	{Name: "compiler", Editors: Both, Since: "6.0"},
+	{Name: "confirm", Editors: Both, Since: "5.2"},
+	{Name: "const", Editors: Both, Since: "8.1.1539"},
+	{Name: "continue", Editors: Both, Since: "5.2"},
+	{Name: "copen", Editors: Both, Since: ""},
+	{Name: "copy", Editors: Both, Since: ""},
+	{Name: "cpfile", Editors: Both, Since: "6.2.249"},
+	{Name: "cprevious", Editors: Both, Since: ""},
+	{Name: "cquit", Editors: Both, Since: ""},
+	{Name: "crewind", Editors: Both, Since: "5.0"},
+	{Name: "cscope", Editors: Both, Since: "5.2"},
+	{Name: "cstag", Editors: Both, Since: "5.2"},
+	{Name: "cunabbrev", Editors: Both, Since: ""},
+	{Name: "cunmap", Editors: Both, Since: ""},
+	{Name: "cunmenu", Editors: Both, Since: ""},
+	{Name: "cwindow", Editors: Both, Since: ""},
+	{Name: "debug", Editors: Both, Since: ""},
+	{Name: "debuggreedy", Editors: Both, Since: "6.1.398"},
+	{Name: "def", Editors: Vim, Since: "9.0"},
+	{Name: "defcompile", Editors: Vim, Since: "8.2.0818"},
+	{Name: "defer", Editors: Both, Since: ""},
+	{Name: "delcommand", Editors: Both, Since: "5.2"},
+	{Name: "delete", Editors: Both, Since: ""},
+	{Name: "delfunction", Editors: Both, Since: "5.2"},
+	{Name: "delmarks", Editors: Both, Since: "7.0"},
+	{Name: "diffget", Editors: Both, Since: ""},
+	{Name: "diffoff", Editors: Both, Since: "7.0"},
+	{Name: "diffpatch", Editors: Both, Since: ""},
+	{Name: "diffput", Editors: Both, Since: ""},
+	{Name: "diffsplit", Editors: Both, Since: ""},
+	{Name: "diffthis", Editors: Both, Since: ""},
+	{Name: "diffupdate", Editors: Both, Since: ""},
+	{Name: "digraphs", Editors: Both, Since: ""},
+	{Name: "disassemble", Editors: Vim, Since: "9.0"},
+	{Name: "display", Editors: Both, Since: ""},
+	{Name: "djump", Editors: Both, Since: ""},
+	{Name: "dl", Editors: Both, Since: ""},
+	{Name: "dlist", Editors: Both, Since: ""},
+	{Name: "doautoall", Editors: Both, Since: "5.0"},
+	{Name: "doautocmd", Editors: Both, Since: ""},
+	{Name: "dp", Editors: Both, Since: ""},
+	{Name: "drop", Editors: Both, Since: ""},
+	{Name: "dsearch", Editors: Both, Since: ""},
+	{Name: "dsplit", Editors: Both, Since: ""},
+	{Name: "earlier", Editors: Both, Since: "7.0"},
+	{Name: "echo", Editors: Both, Since: "5.0"},
+	{Name: "echoconsole", Editors: Both, Since: "8.2.2638"},
+	{Name: "echoerr", Editors: Both, Since: "6.0"},
+	{Name: "echohl", Editors: Both, Since: ""},
+	{Name: "echomsg", Editors: Both, Since: "6.0"},
+	{Name: "echon", Editors: Both, Since: ""},
+	{Name: "echowindow", Editors: Both, Since: ""},
+	{Name: "edit", Editors: Both, Since: ""},
+	{Name: "else", Editors: Both, Since: ""},
+	{Name: "elseif", Editors: Both, Since: ""},
+	{Name: "emenu", Editors: Both, Since: "6.0"},
+	{Name: "endclass", Editors: Vim, Since: "9.0"},
+	{Name: "enddef", Editors: Vim, Since: "9.0"},
+	{Name: "endfor", Editors: Both, Since: "7.0"},
+	{Name: "endfunction", Editors: Both, Since: "5.2"},
+	{Name: "endif", Editors: Both, Since: ""},
+	{Name: "endtry", Editors: Both, Since: ""},
+	{Name: "endwhile", Editors: Both, Since: ""},
+	{Name: "enew", Editors: Both, Since: "6.0"},
+	{Name: "eval", Editors: Both, Since: "8.1.1807"},
+	{Name: "ex", Editors: Both, Since: ""},
+	{Name: "execute", Editors: Both, Since: "5.0"},
+	{Name: "exit", Editors: Both, Since: ""},
+	{Name: "export", Editors: Vim, Since: "9.0"},
+	{Name: "exusage", Editors: Both, Since: "7.0"},
+	{Name: "file", Editors: Both, Since: ""},
+	{Name: "files", Editors: Both, Since: ""},
+	{Name: "filetype", Editors: Both, Since: ""},
+	{Name: "filter", Editors: Both, Since: "7.4.2244"},
+	{Name: "final", Editors: Vim, Since: "9.0"},
+	{Name: "finally", Editors: Both, Since: ""},
+	{Name: "find", Editors: Both, Since: ""},
+	{Name: "finish", Editors: Both, Since: "6.0"},
+	{Name: "first", Editors: Both, Since: "6.0"},
+	{Name: "fixdel", Editors: Vim, Since: ""},
+	{Name: "fold", Editors: Both, Since: ""},
+	{Name: "foldclose", Editors: Both, Since: ""},
+	{Name: "folddoclosed", Editors: Both, Since: ""},
+	{Name: "folddoopen", Editors: Both, Since: ""},
+	{Name: "foldopen", Editors: Both, Since: ""},
+	{Name: "for", Editors: Both, Since: "7.0"},
+	{Name: "function", Editors: Both, Since: "5.2"},
+	{Name: "global", Editors: Both, Since: ""},
+	{Name: "goto", Editors: Both, Since: ""},
+	{Name: "grep", Editors: Both, Since: "5.2"},
+	{Name: "grepadd", Editors: Both, Since: "6.0"},
+	{Name: "gui", Editors: Both, Since: ""},
+	{Name: "gvim", Editors: Vim, Since: ""},
+	{Name: "hardcopy", Editors: Both, Since: "6.0"},
+	{Name: "help", Editors: Both, Since: ""},
+	{Name: "helpclose", Editors: Both, Since: "7.4.449"},
+	{Name: "helpfind", Editors: Vim, Since: ""},
+	{Name: "helpgrep", Editors: Both, Since: ""},
+	{Name: "helptags", Editors: Both, Since: ""},
+	{Name: "hide", Editors: Both, Since: "5.0"},
+	{Name: "highlight", Editors: Both, Since: ""},
+	{Name: "history", Editors: Both, Since: ""},
+	{Name: "horizontal", Editors: Both, Since: ""},
+	{Name: "iabbrev", Editors: Both, Since: ""},
+	{Name: "iabclear", Editors: Both, Since: ""},
+	{Name: "if", Editors: Both, Since: "5.0"},
+	{Name: "ijump", Editors: Both, Since: ""},
+	{Name: "ilist", Editors: Both, Since: ""},
+	{Name: "imap", Editors: Both, Since: ""},
+	{Name: "imapclear", Editors: Both, Since: ""},
+	{Name: "imenu", Editors: Both, Since: ""},
+	{Name: "import", Editors: Vim, Since: "9.0"},
+	{Name: "inoreabbrev", Editors: Both, Since: ""},
+	{Name: "inoremap", Editors: Both, Since: ""},
+	{Name: "inoremenu", Editors: Both, Since: ""},
+	{Name: "insert", Editors: Both, Since: ""},
+	{Name: "intro", Editors: Both, Since: "5.0"},
+	{Name: "isearch", Editors: Both, Since: ""},
+	{Name: "isplit", Editors: Both, Since: ""},
+	{Name: "iunabbrev", Editors: Both, Since: ""},
+	{Name: "iunmap", Editors: Both, Since: ""},
+	{Name: "iunmenu", Editors: Both, Since: ""},
+	{Name: "join", Editors: Both, Since: ""},
+	{Name: "jumps", Editors: Both, Since: ""},
+	{Name: "k", Editors: Both, Since: ""},
+	{Name: "keepalt", Editors: Both, Since: ""},
+	{Name: "keepjumps", Editors: Both, Since: "6.2.298"},
+	{Name: "keepmarks", Editors: Both, Since: "6.2.190"},
+	{Name: "keeppatterns", Editors: Both, Since: "7.4.083"},
+	{Name: "lNext", Editors: Both, Since: "7.0"},
+	{Name: "lNfile", Editors: Both, Since: "7.0"},
+	{Name: "labove", Editors: Both, Since: "8.1.1256"},
+	{Name: "laddbuffer", Editors: Both, Since: "7.0"},
+	{Name: "laddexpr", Editors: Both, Since: "7.0"},
+	{Name: "laddfile", Editors: Both, Since: "7.0"},
+	{Name: "lafter", Editors: Both, Since: ""},
+	{Name: "language", Editors: Both, Since: ""},
+	{Name: "last", Editors: Both, Since: ""},
+	{Name: "later", Editors: Both, Since: "7.0"},
+	{Name: "lbefore", Editors: Both, Since: ""},
+	{Name: "lbelow", Editors: Both, Since: "8.1.1256"},
+	{Name: "lbottom", Editors: Both, Since: "7.4.2010"},
+	{Name: "lbuffer", Editors: Both, Since: "7.0"},
+	{Name: "lcd", Editors: Both, Since: "6.0"},
+	{Name: "lchdir", Editors: Both, Since: ""},
+	{Name: "lclose", Editors: Both, Since: "7.0"},
+	{Name: "lcscope", Editors: Both, Since: "7.0"},
+	{Name: "ldo", Editors: Both, Since: "7.4.858"},
+	{Name: "left", Editors: Both, Since: ""},
+	{Name: "leftabove", Editors: Both, Since: "6.0"},
+	{Name: "legacy", Editors: Vim, Since: "8.2.2805"},
+	{Name: "let", Editors: Both, Since: "5.0"},
+	{Name: "lexpr", Editors: Both, Since: "7.0"},
+	{Name: "lfdo", Editors: Both, Since: "7.4.858"},
+	{Name: "lfile", Editors: Both, Since: "7.0"},
+	{Name: "lfirst", Editors: Both, Since: "7.0"},
+	{Name: "lgetbuffer", Editors: Both, Since: "7.0"},
+	{Name: "lgetexpr", Editors: Both, Since: "7.0"},
+	{Name: "lgetfile", Editors: Both, Since: "7.0"},
+	{Name: "lgrep", Editors: Both, Since: "7.0"},
+	{Name: "lgrepadd", Editors: Both, Since: "7.0"},
+	{Name: "lhelpgrep", Editors: Both, Since: "7.0"},
+	{Name: "lhistory", Editors: Both, Since: "7.4.2049"},
+	{Name: "list", Editors: Both, Since: ""},
+	{Name: "ll", Editors: Both, Since: "7.0"},
+	{Name: "llast", Editors: Both, Since: "7.0"},
+	{Name: "llist", Editors: Both, Since: "7.0"},
+	{Name: "lmake", Editors: Both, Since: "7.0"},
+	{Name: "lmap", Editors: Both, Since: ""},
+	{Name: "lmapclear", Editors: Both, Since: ""},
+	{Name: "lnewer", Editors: Both, Since: "7.0"},
+	{Name: "lnext", Editors: Both, Since: "7.0"},
+	{Name: "lnfile", Editors: Both, Since: "7.0"},
+	{Name: "lnoremap", Editors: Both, Since: ""},
+	{Name: "loadkeymap", Editors: Both, Since: ""},
+	{Name: "loadview", Editors: Both, Since: ""},
+	{Name: "lockmarks", Editors: Both, Since: "6.2.190"},
+	{Name: "lockvar", Editors: Both, Since: "7.0"},
+	{Name: "lolder", Editors: Both, Since: "7.0"},
+	{Name: "lopen", Editors: Both, Since: "7.0"},
+	{Name: "lpfile", Editors: Both, Since: "7.0"},
+	{Name: "lprevious", Editors: Both, Since: "7.0"},
+	{Name: "lrewind", Editors: Both, Since: "7.0"},
+	{Name: "ls", Editors: Both, Since: ""},
+	{Name: "ltag", Editors: Both, Since: "7.0"},
+	{Name: "lua", Editors: Both, Since: ""},
+	{Name: "luado", Editors: Both, Since: ""},
+	{Name: "luafile", Editors: Both, Since: ""},
+	{Name: "lunmap", Editors: Both, Since: ""},
+	{Name: "lvimgrep", Editors: Both, Since: "7.0"},
+	{Name: "lvimgrepadd", Editors: Both, Since: "7.0"},
+	{Name: "lwindow", Editors: Both, Since: "7.0"},
+	{Name: "make", Editors: Both, Since: ""},
+	{Name: "map", Editors: Both, Since: "6.0"},
+	{Name: "mapclear", Editors: Both, Since: ""},
+	{Name: "mark", Editors: Both, Since: ""},
+	{Name: "marks", Editors: Both, Since: ""},
+	{Name: "match", Editors: Both, Since: "6.0"},
+	{Name: "menu", Editors: Both, Since: "6.0"},
+	{Name: "menutranslate", Editors: Both, Since: ""},
+	{Name: "messages", Editors: Both, Since: ""},
+	{Name: "mkexrc", Editors: Both, Since: ""},
+	{Name: "mksession", Editors: Both, Since: "5.2"},
+	{Name: "mkspell", Editors: Both, Since: "7.0"},
+	{Name: "mkview", Editors: Both, Since: ""},
+	{Name: "mkvimrc", Editors: Both, Since: ""},
+	{Name: "mode", Editors: Both, Since: ""},
+	{Name: "move", Editors: Both, Since: ""},
+	{Name: "mzfile", Editors: Vim, Since: "7.0"},
+	{Name: "mzscheme", Editors: Vim, Since: "7.0"},
+	{Name: "nbclose", Editors: Both, Since: ""},
+	{Name: "nbkey", Editors: Both, Since: "7.0"},
+	{Name: "nbstart", Editors: Both, Since: ""},
+	{Name: "new", Editors: Both, Since: ""},
+	{Name: "next", Editors: Both, Since: ""},
+	{Name: "nmap", Editors: Both, Since: ""},
+	{Name: "nmapclear", Editors: Both, Since: ""},
+	{Name: "nmenu", Editors: Both, Since: ""},
+	{Name: "nnoremap", Editors: Both, Since: ""},
+	{Name: "nnoremenu", Editors: Both, Since: ""},
+	{Name: "noautocmd", Editors: Both, Since: ""},
+	{Name: "nohlsearch", Editors: Both, Since: "5.2"},
+	{Name: "noreabbrev", Editors: Both, Since: ""},
+	{Name: "noremap", Editors: Both, Since: ""},
+	{Name: "noremenu", Editors: Both, Since: ""},
+	{Name: "normal", Editors: Both, Since: ""},
+	{Name: "noswapfile", Editors: Both, Since: "7.4.213"},
+	{Name: "number", Editors: Both, Since: ""},
+	{Name: "nunmap", Editors: Both, Since: ""},
+	{Name: "nunmenu", Editors: Both, Since: ""},
+	{Name: "oldfiles", Editors: Both, Since: "7.2.031"},
+	{Name: "omap", Editors: Both, Since: "5.0"},
+	{Name: "omapclear", Editors: Both, Since: ""},
+	{Name: "omenu", Editors: Both, Since: ""},
+	{Name: "only", Editors: Both, Since: ""},
+	{Name: "onoremap", Editors: Both, Since: ""},
+	{Name: "onoremenu", Editors: Both, Since: ""},
+	{Name: "open", Editors: Vim, Since: ""},
+	{Name: "options", Editors: Both, Since: ""},
+	{Name: "ounmap", Editors: Both, Since: ""},
+	{Name: "ounmenu", Editors: Both, Since: ""},
+	{Name: "ownsyntax", Editors: Both, Since: ""},
+	{Name: "packadd", Editors: Both, Since: "7.4.1480"},
+	{Name: "packloadall", Editors: Both, Since: "7.4.1550"},
+	{Name: "pclose", Editors: Both, Since: ""},
+	{Name: "pedit", Editors: Both, Since: ""},
+	{Name: "perl", Editors: Both, Since: ""},
+	{Name: "perldo", Editors: Both, Since: ""},
+	{Name: "pop", Editors: Both, Since: ""},
+	{Name: "popup", Editors: Both, Since: "6.0"},
+	{Name: "ppop", Editors: Both, Since: ""},
+	{Name: "preserve", Editors: Both, Since: ""},
+	{Name: "previous", Editors: Both, Since: ""},
+	{Name: "print", Editors: Both, Since: ""},
+	{Name: "profdel", Editors: Both, Since: "7.0"},
+	{Name: "profile", Editors: Both, Since: "7.0"},
+	{Name: "promptfind", Editors: Vim, Since: "5.2"},
+	{Name: "promptrepl", Editors: Vim, Since: "5.2"},
+	{Name: "psearch", Editors: Both, Since: ""},
+	{Name: "ptNext", Editors: Both, Since: ""},
+	{Name: "ptag", Editors: Both, Since: ""},
+	{Name: "ptfirst", Editors: Both, Since: ""},
+	{Name: "ptjump", Editors: Both, Since: ""},
+	{Name: "ptlast", Editors: Both, Since: ""},
+	{Name: "ptnext", Editors: Both, Since: ""},
+	{Name: "ptprevious", Editors: Both, Since: ""},
+	{Name: "ptrewind", Editors: Both, Since: ""},
+	{Name: "ptselect", Editors: Both, Since: ""},
+	{Name: "public", Editors: Vim, Since: ""},
+	{Name: "put", Editors: Both, Since: ""},
+	{Name: "pwd", Editors: Both, Since: ""},
+	{Name: "py3", Editors: Both, Since: ""},
+	{Name: "py3do", Editors: Both, Since: ""},
+	{Name: "py3file", Editors: Both, Since: ""},
+	{Name: "pydo", Editors: Both, Since: "7.3.966"},
+	{Name: "pyfile", Editors: Both, Since: ""},
+	{Name: "python", Editors: Both, Since: ""},
+	{Name: "python3", Editors: Both, Since: ""},
+	{Name: "pythonx", Editors: Both, Since: ""},
+	{Name: "pyx", Editors: Both, Since: ""},
+	{Name: "pyxdo", Editors: Both, Since: ""},
+	{Name: "pyxfile", Editors: Both, Since: ""},
+	{Name: "qall", Editors: Both, Since: ""},
+	{Name: "quit", Editors: Both, Since: ""},
+	{Name: "quitall", Editors: Both, Since: "6.0"},
+	{Name: "range", Editors: Both, Since: ""},
+	{Name: "read", Editors: Both, Since: ""},
+	{Name: "recover", Editors: Both, Since: ""},
+	{Name: "redir", Editors: Both, Since: "5.0"},
+	{Name: "redo", Editors: Both, Since: ""},
+	{Name: "redraw", Editors: Both, Since: "6.0"},
+	{Name: "redrawstatus", Editors: Both, Since: "6.2.197"},
+	{Name: "redrawtabline", Editors: Both, Since: "8.1.0706"},
+	{Name: "registers", Editors: Both, Since: ""},
+	{Name: "resize", Editors: Both, Since: ""},
+	{Name: "retab", Editors: Both, Since: ""},
+	{Name: "return", Editors: Both, Since: "5.2"},
+	{Name: "rewind", Editors: Both, Since: ""},
+	{Name: "right", Editors: Both, Since: ""},
+	{Name: "rightbelow", Editors: Both, Since: "6.0"},
+	{Name: "rshada", Editors: Neovim, Since: "0.1.0"},
+	{Name: "ruby", Editors: Both, Since: ""},
+	{Name: "rubydo", Editors: Both, Since: ""},
+	{Name: "rubyfile", Editors: Both, Since: ""},
+	{Name: "rundo", Editors: Both, Since: ""},
+	{Name: "runtime", Editors: Both, Since: ""},
+	{Name: "rviminfo", Editors: Both, Since: ""},
+	{Name: "sNext", Editors: Both, Since: ""},
+	{Name: "sall", Editors: Both, Since: ""},
+	{Name: "sandbox", Editors: Both, Since: ""},
+	{Name: "sargument", Editors: Both, Since: ""},
+	{Name: "saveas", Editors: Both, Since: "6.0"},
+	{Name: "sbNext", Editors: Both, Since: ""},
+	{Name: "sball", Editors: Both, Since: ""},
+	{Name: "sbfirst", Editors: Both, Since: ""},
+	{Name: "sblast", Editors: Both, Since: ""},
+	{Name: "sbmodified", Editors: Both, Since: ""},
+	{Name: "sbnext", Editors: Both, Since: ""},
+	{Name: "sbprevious", Editors: Both, Since: ""},
+	{Name: "sbrewind", Editors: Both, Since: ""},
+	{Name: "sbuffer", Editors: Both, Since: ""},
+	{Name: "scriptencoding", Editors: Both, Since: ""},
+	{Name: "scriptnames", Editors: Both, Since: "8.2.4617"},
+	{Name: "scriptversion", Editors: Both, Since: "8.1.1116"},
+	{Name: "scscope", Editors: Both, Since: ""},
+	{Name: "set", Editors: Both, Since: ""},
+	{Name: "setfiletype", Editors: Both, Since: ""},
+	{Name: "setglobal", Editors: Both, Since: ""},
+	{Name: "setlocal", Editors: Both, Since: ""},
+	{Name: "sfind", Editors: Both, Since: ""},
+	{Name: "sfirst", Editors: Both, Since: ""},
+	{Name: "shell", Editors: Vim, Since: ""},
+	{Name: "sign", Editors: Both, Since: "7.2.166"},
+	{Name: "silent", Editors: Both, Since: "6.0"},
+	{Name: "simalt", Editors: Both, Since: "5.2"},
+	{Name: "slast", Editors: Both, Since: ""},
+	{Name: "sleep", Editors: Both, Since: ""},
+	{Name: "smagic", Editors: Both, Since: "5.2"},
+	{Name: "smap", Editors: Both, Since: "7.0"},
+	{Name: "smapclear", Editors: Both, Since: "7.0"},
+	{Name: "smenu", Editors: Both, Since: "7.0"},
+	{Name: "smile", Editors: Both, Since: "8.0"},
+	{Name: "snext", Editors: Both, Since: ""},
+	{Name: "snomagic", Editors: Both, Since: "5.2"},
+	{Name: "snoremap", Editors: Both, Since: "7.0"},
+	{Name: "snoremenu", Editors: Both, Since: "7.0"},
+	{Name: "sort", Editors: Both, Since: "7.0"},
+	{Name: "source", Editors: Both, Since: ""},
+	{Name: "spelldump", Editors: Both, Since: "7.0"},
+	{Name: "spellgood", Editors: Both, Since: "7.0"},
+	{Name: "spellinfo", Editors: Both, Since: "7.0"},
+	{Name: "spellrare", Editors: Both, Since: "8.1.1838"},
+	{Name: "spellrepall", Editors: Both, Since: "7.0"},
+	{Name: "spellundo", Editors: Both, Since: "7.0"},
+	{Name: "spellwrong", Editors: Both, Since: "7.0"},
+	{Name: "split", Editors: Both, Since: ""},
+	{Name: "sprevious", Editors: Both, Since: ""},
+	{Name: "srewind", Editors: Both, Since: ""},
+	{Name: "stag", Editors: Both, Since: ""},
+	{Name: "star", Editors: Both, Since: "5.2"},
+	{Name: "startgreplace", Editors: Both, Since: "7.0"},
+	{Name: "startinsert", Editors: Both, Since: ""},
+	{Name: "startreplace", Editors: Both, Since: "7.0"},
+	{Name: "static", Editors: Vim, Since: "9.0"},
+	{Name: "stjump", Editors: Both, Since: ""},
+	{Name: "stop", Editors: Both, Since: ""},
+	{Name: "stopinsert", Editors: Both, Since: ""},
+	{Name: "stselect", Editors: Both, Since: ""},
+	{Name: "substitute", Editors: Both, Since: ""},
+	{Name: "sunhide", Editors: Both, Since: ""},
+	{Name: "sunmap", Editors: Both, Since: "7.0"},
+	{Name: "sunmenu", Editors: Both, Since: "7.0"},
+	{Name: "suspend", Editors: Both, Since: ""},
+	{Name: "sview", Editors: Both, Since: ""},
+	{Name: "swapname", Editors: Both, Since: ""},
+	{Name: "syncbind", Editors: Both, Since: ""},
+	{Name: "syntax", Editors: Both, Since: ""},
+	{Name: "syntime", Editors: Both, Since: "7.3.1129"},
+	{Name: "t", Editors: Both, Since: ""},
+	{Name: "tNext", Editors: Both, Since: ""},
+	{Name: "tab", Editors: Both, Since: ""},
+	{Name: "tabNext", Editors: Both, Since: "7.0"},
+	{Name: "tabclose", Editors: Both, Since: "7.0"},
+	{Name: "tabdo", Editors: Both, Since: "7.0"},
+	{Name: "tabedit", Editors: Both, Since: "7.0"},
+	{Name: "tabfind", Editors: Both, Since: "7.0"},
+	{Name: "tabfirst", Editors: Both, Since: "7.0"},
+	{Name: "tablast", Editors: Both, Since: "7.0"},
+	{Name: "tabmove", Editors: Both, Since: "7.0"},
+	{Name: "tabnew", Editors: Both, Since: "7.0"},
+	{Name: "tabnext", Editors: Both, Since: "7.0"},
+	{Name: "tabonly", Editors: Both, Since: "7.0"},
+	{Name: "tabprevious", Editors: Both, Since: "7.0"},
+	{Name: "tabrewind", Editors: Both, Since: "7.0"},
+	{Name: "tabs", Editors: Both, Since: "7.0"},
+	{Name: "tag", Editors: Both, Since: ""},
+	{Name: "tags", Editors: Both, Since: ""},
+	{Name: "tcd", Editors: Both, Since: ""},
+	{Name: "tchdir", Editors: Both, Since: ""},
+	{Name: "tcl", Editors: Both, Since: "5.2"},
+	{Name: "tcldo", Editors: Both, Since: "5.2"},
+	{Name: "tclfile", Editors: Both, Since: "5.2"},
+	{Name: "tearoff", Editors: Vim, Since: "5.2"},
+	{Name: "terminal", Editors: Both, Since: ""},
+	{Name: "tfirst", Editors: Both, Since: ""},
+	{Name: "throw", Editors: Both, Since: ""},
+	{Name: "tjump", Editors: Both, Since: ""},
+	{Name: "tlast", Editors: Both, Since: ""},
+	{Name: "tlmenu", Editors: Both, Since: "8.1.0487"},
+	{Name: "tlnoremenu", Editors: Both, Since: "8.2.1768"},
+	{Name: "tlunmenu", Editors: Both, Since: ""},
+	{Name: "tmap", Editors: Both, Since: "8.0.1108"},
+	{Name: "tmapclear", Editors: Both, Since: ""},
+	{Name: "tmenu", Editors: Both, Since: "5.2"},
+	{Name: "tnext", Editors: Both, Since: ""},
+	{Name: "tnoremap", Editors: Both, Since: ""},
+	{Name: "topleft", Editors: Both, Since: ""},
+	{Name: "tprevious", Editors: Both, Since: ""},
+	{Name: "trewind", Editors: Both, Since: ""},
+	{Name: "try", Editors: Both, Since: ""},
+	{Name: "tselect", Editors: Both, Since: ""},
+	{Name: "tunmap", Editors: Both, Since: ""},
+	{Name: "tunmenu", Editors: Both, Since: "5.2"},
+	{Name: "unabbreviate", Editors: Both, Since: ""},
+	{Name: "undo", Editors: Both, Since: ""},
+	{Name: "undojoin", Editors: Both, Since: "7.0"},
+	{Name: "undolist", Editors: Both, Since: "7.0"},
+	{Name: "unhide", Editors: Both, Since: ""},
+	{Name: "unlet", Editors: Both, Since: "8.2.0601"},
+	{Name: "unlockvar", Editors: Both, Since: "7.0"},
+	{Name: "unmap", Editors: Both, Since: ""},
+	{Name: "unmenu", Editors: Both, Since: ""},
+	{Name: "unsilent", Editors: Both, Since: "7.2.223"},
+	{Name: "update", Editors: Both, Since: "5.0"},
+	{Name: "var", Editors: Vim, Since: "9.0"},
+	{Name: "verbose", Editors: Both, Since: "6.0"},
+	{Name: "version", Editors: Both, Since: ""},
+	{Name: "vertical", Editors: Both, Since: ""},
+	{Name: "vglobal", Editors: Both, Since: ""},
+	{Name: "view", Editors: Both, Since: ""},
+	{Name: "vim9cmd", Editors: Vim, Since: ""},
+	{Name: "vim9script", Editors: Vim, Since: "9.0"},
+	{Name: "vimgrep", Editors: Both, Since: "7.0"},
+	{Name: "vimgrepadd", Editors: Both, Since: "7.0"},
+	{Name: "visual", Editors: Both, Since: ""},
+	{Name: "viusage", Editors: Both, Since: "7.0"},
+	{Name: "vmap", Editors: Both, Since: ""},
+	{Name: "vmapclear", Editors: Both, Since: ""},
+	{Name: "vmenu", Editors: Both, Since: ""},
+	{Name: "vnew", Editors: Both, Since: ""},
+	{Name: "vnoremap", Editors: Both, Since: ""},
+	{Name: "vnoremenu", Editors: Both, Since: ""},
+	{Name: "vsplit", Editors: Both, Since: ""},
+	{Name: "vunmap", Editors: Both, Since: ""},
+	{Name: "vunmenu", Editors: Both, Since: ""},
+	{Name: "wNext", Editors: Both, Since: ""},
+	{Name: "wall", Editors: Both, Since: ""},
+	{Name: "while", Editors: Both, Since: "5.0"},
+	{Name: "wincmd", Editors: Both, Since: "6.0"},
+	{Name: "windo", Editors: Both, Since: "6.0"},
+	{Name: "winpos", Editors: Both, Since: ""},
+	{Name: "winsize", Editors: Both, Since: ""},
+	{Name: "wnext", Editors: Both, Since: ""},
+	{Name: "wprevious", Editors: Both, Since: ""},
+	{Name: "wq", Editors: Both, Since: ""},
+	{Name: "wqall", Editors: Both, Since: ""},
+	{Name: "write", Editors: Both, Since: ""},
+	{Name: "wshada", Editors: Neovim, Since: "0.1.0"},
+	{Name: "wundo", Editors: Both, Since: ""},
+	{Name: "wviminfo", Editors: Both, Since: ""},
+	{Name: "xall", Editors: Both, Since: ""},
+	{Name: "xit", Editors: Both, Since: ""},
+	{Name: "xmap", Editors: Both, Since: "7.0"},
+	{Name: "xmapclear", Editors: Both, Since: "7.0"},
+	{Name: "xmenu", Editors: Both, Since: "7.0"},
+	{Name: "xnoremap", Editors: Both, Since: "7.0"},
+	{Name: "xnoremenu", Editors: Both, Since: "7.0"},
+	{Name: "xrestore", Editors: Both, Since: "8.1.1307"},
+	{Name: "xunmap", Editors: Both, Since: "7.0"},
+	{Name: "xunmenu", Editors: Both, Since: "7.0"},
+	{Name: "yank", Editors: Both, Since: ""},
+	{Name: "z", Editors: Both, Since: "6.0"},
+}
@@ -0,0 +1,28 @@
+package builtin
+
+import "testing"
+
+func TestLookupCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		editors Editor
+		since   string
+	}{
+		{"const", Both, "8.1.1539"},
+		{"eval", Both, "8.1.1807"},
+		{"cdo", Both, "7.4.858"},
+		{"saveas", Both, "6.0"},
+		{"shell", Vim, ""},
+		{"tearoff", Vim, "5.2"},
+		{"rshada", Neovim, "0.1.0"},
+	}
+	for _, tt := range tests {
+		c := LookupCommand(tt.name)
+		if c == nil || c.Editors != tt.editors || c.Since != tt.since {
+			t.Errorf("LookupCommand(%q) = %+v, want %v since %q", tt.name, c, tt.editors, tt.since)
+		}
+	}
+	if c := LookupCommand("cons"); c != nil {
+		t.Errorf("LookupCommand(%q) = %+v, want nil", "cons", c)
+	}
+}
@@ -0,0 +1,51 @@
+package builtin
+
+import "strings"
+
+//go:generate go run gen_builtin.go
+
+// Function is a builtin function.
+type Function struct {
+	Name    string // name, e.g. "strlen"
+	MinArgs int    // minimum number of the arguments
+	MaxArgs int    // maximum number of the arguments; or -1 if variadic
+	Result  string // type of the result in Vim9 syntax, e.g. "list<any>"
+	Method  bool   // can be called as a method, e.g. `'abc'->strlen()`
+	Editors Editor // editors which have the function
+
+	// Since is the version which introduced the function, e.g.
+	// "8.2.0878". It's the version of Neovim, e.g. "0.5.0", for functions
+	// of Neovim only. It's empty for functions older than Vim 5.2.
+	Since string
+}
+
+// LookupFunction returns the builtin function by the name. API functions of
+// Neovim, e.g. nvim_buf_get_lines, take any number of arguments. It returns
+// nil if name is not a builtin function.
+func LookupFunction(name string) *Function {
+	if f, ok := functionsByName[name]; ok {
+		return f
+	}
+	if strings.HasPrefix(name, "nvim_") && len(name) > 5 {
+		return &Function{Name: name, MaxArgs: -1, Result: "any", Editors: Neovim}
+	}
+	return nil
+}
+
+// Functions returns builtin functions sorted by the name. API functions of
+// Neovim are not included.
+func Functions() []*Function {
+	list := make([]*Function, len(functions))
+	for i := range functions {
+		list[i] = &functions[i]
+	}
+	return list
+}
+
+var functionsByName = func() map[string]*Function {
+	m := make(map[string]*Function, len(functions))
+	for i := range functions {
+		m[functions[i].Name] = &functions[i]
+	}
+	return m
+}()
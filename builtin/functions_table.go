@@ -0,0 +1,582 @@
+// Code generated by gen_builtin.go; DO NOT EDIT.
+// source: vim90/doc/builtin.txt and version*.txt
+
+package builtin
+
+var functions = []Function{
+	{Name: "abs", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "acos", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "add", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "and", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3.377"},
+	{Name: "api_info", MinArgs: 0, MaxArgs: 0, Result: "dict<any>", Method: false, Editors: Neovim, Since: "0.1.5"},
+	{Name: "append", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "appendbufline", MinArgs: 3, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.1.0037"},
+	{Name: "argc", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "5.2"},
+	{Name: "argidx", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "arglistid", MinArgs: 0, MaxArgs: 2, Result: "any", Method: false, Editors: Both, Since: "7.4.312"},
+	{Name: "argv", MinArgs: 0, MaxArgs: 2, Result: "any", Method: false, Editors: Both, Since: "5.2"},
+	{Name: "asin", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "assert_beeps", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.0.1510"},
+	{Name: "assert_equal", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "assert_equalfile", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.0.1523"},
+	{Name: "assert_exception", MinArgs: 1, MaxArgs: 2, Result: "number", Method: false, Editors: Both, Since: "7.4.1092"},
+	{Name: "assert_fails", MinArgs: 1, MaxArgs: 5, Result: "number", Method: true, Editors: Both, Since: "7.4.1096"},
+	{Name: "assert_false", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "assert_inrange", MinArgs: 3, MaxArgs: 4, Result: "number", Method: false, Editors: Both, Since: "7.4.2095"},
+	{Name: "assert_match", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.4.1663"},
+	{Name: "assert_nobeep", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.2.2694"},
+	{Name: "assert_notequal", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.4.1703"},
+	{Name: "assert_notmatch", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.4.1703"},
+	{Name: "assert_report", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.0.0477"},
+	{Name: "assert_true", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "atan", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "atan2", MinArgs: 2, MaxArgs: 2, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "autocmd_add", MinArgs: 1, MaxArgs: 1, Result: "bool", Method: true, Editors: Vim, Since: "9.0"},
+	{Name: "autocmd_delete", MinArgs: 1, MaxArgs: 1, Result: "bool", Method: true, Editors: Vim, Since: "9.0"},
+	{Name: "autocmd_get", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Vim, Since: "9.0"},
+	{Name: "balloon_gettext", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Vim, Since: "8.1.1303"},
+	{Name: "balloon_show", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.0.0396"},
+	{Name: "balloon_split", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Vim, Since: "8.0.1318"},
+	{Name: "blob2list", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.2.3438"},
+	{Name: "browse", MinArgs: 4, MaxArgs: 4, Result: "string", Method: false, Editors: Both, Since: "5.2"},
+	{Name: "browsedir", MinArgs: 2, MaxArgs: 2, Result: "string", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "bufadd", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.1.1610"},
+	{Name: "bufexists", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "buflisted", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "bufload", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.1.1610"},
+	{Name: "bufloaded", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "bufname", MinArgs: 0, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "bufnr", MinArgs: 0, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "bufwinid", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.4.1893"},
+	{Name: "bufwinnr", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "byte2line", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "byteidx", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "byteidxcomp", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.4.057"},
+	{Name: "call", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "ceil", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "ch_canread", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Vim, Since: "8.0.0105"},
+	{Name: "ch_close", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_close_in", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "7.4.2298"},
+	{Name: "ch_evalexpr", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Vim, Since: "7.4.1435"},
+	{Name: "ch_evalraw", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Vim, Since: "7.4.1435"},
+	{Name: "ch_getbufnr", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "7.4.1438"},
+	{Name: "ch_getjob", MinArgs: 1, MaxArgs: 1, Result: "job", Method: true, Editors: Vim, Since: "7.4.1382"},
+	{Name: "ch_info", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Vim, Since: "7.4.1624"},
+	{Name: "ch_log", MinArgs: 1, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "7.4.1351"},
+	{Name: "ch_logfile", MinArgs: 1, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "7.4.1310"},
+	{Name: "ch_open", MinArgs: 1, MaxArgs: 2, Result: "channel", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_read", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "7.4.1372"},
+	{Name: "ch_readblob", MinArgs: 1, MaxArgs: 2, Result: "blob", Method: true, Editors: Vim, Since: "8.2"},
+	{Name: "ch_readraw", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_sendexpr", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_sendraw", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_setoptions", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "ch_status", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "chanclose", MinArgs: 1, MaxArgs: 2, Result: "number", Method: false, Editors: Neovim, Since: "0.3.0"},
+	{Name: "changenr", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "chansend", MinArgs: 2, MaxArgs: 2, Result: "number", Method: false, Editors: Neovim, Since: "0.3.0"},
+	{Name: "char2nr", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "charclass", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "8.2.1536"},
+	{Name: "charcol", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "charidx", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.2.2233"},
+	{Name: "chdir", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "8.1.1291"},
+	{Name: "cindent", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "clearmatches", MinArgs: 0, MaxArgs: 1, Result: "void", Method: true, Editors: Both, Since: "7.1.040"},
+	{Name: "col", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "complete", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "complete_add", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "complete_check", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "complete_info", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "8.1.1068"},
+	{Name: "confirm", MinArgs: 1, MaxArgs: 4, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "copy", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "cos", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "cosh", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "count", MinArgs: 2, MaxArgs: 4, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "cscope_connection", MinArgs: 0, MaxArgs: 3, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "ctxget", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Neovim, Since: "0.4.0"},
+	{Name: "ctxpop", MinArgs: 0, MaxArgs: 0, Result: "void", Method: false, Editors: Neovim, Since: "0.4.0"},
+	{Name: "ctxpush", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Neovim, Since: "0.4.0"},
+	{Name: "ctxset", MinArgs: 1, MaxArgs: 2, Result: "void", Method: false, Editors: Neovim, Since: "0.4.0"},
+	{Name: "ctxsize", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Neovim, Since: "0.4.0"},
+	{Name: "cursor", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "6.0.234"},
+	{Name: "debugbreak", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.1.0091"},
+	{Name: "deepcopy", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "delete", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "deletebufline", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.1.0039"},
+	{Name: "dictwatcheradd", MinArgs: 3, MaxArgs: 3, Result: "void", Method: false, Editors: Neovim, Since: "0.1.5"},
+	{Name: "dictwatcherdel", MinArgs: 3, MaxArgs: 3, Result: "void", Method: false, Editors: Neovim, Since: "0.1.5"},
+	{Name: "did_filetype", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: ""},
+	{Name: "diff_filler", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "diff_hlID", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "digraph_get", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "digraph_getlist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "digraph_set", MinArgs: 2, MaxArgs: 2, Result: "bool", Method: false, Editors: Both, Since: "9.0"},
+	{Name: "digraph_setlist", MinArgs: 1, MaxArgs: 1, Result: "bool", Method: false, Editors: Both, Since: "9.0"},
+	{Name: "echoraw", MinArgs: 1, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "8.2.0258"},
+	{Name: "empty", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "environ", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Both, Since: "8.1.1305"},
+	{Name: "escape", MinArgs: 2, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "eval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "eventhandler", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "executable", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "execute", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "exepath", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.4.235"},
+	{Name: "exists", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "exists_compiled", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Vim, Since: "8.2.3314"},
+	{Name: "exp", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "expand", MinArgs: 1, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: ""},
+	{Name: "expandcmd", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.1.1510"},
+	{Name: "extend", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "extendnew", MinArgs: 2, MaxArgs: 3, Result: "any", Method: false, Editors: Both, Since: "8.2.2336"},
+	{Name: "feedkeys", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "filereadable", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "filewritable", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "filter", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "finddir", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "findfile", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "flatten", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.2.0935"},
+	{Name: "flattennew", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Both, Since: "8.2.2449"},
+	{Name: "float2nr", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "floor", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "fmod", MinArgs: 2, MaxArgs: 2, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "fnameescape", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.1.299"},
+	{Name: "fnamemodify", MinArgs: 2, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "foldclosed", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "foldclosedend", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "foldlevel", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "foldtext", MinArgs: 0, MaxArgs: 0, Result: "string", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "foldtextresult", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "foreground", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "fullcommand", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.2.2468"},
+	{Name: "funcref", MinArgs: 1, MaxArgs: 3, Result: "func", Method: true, Editors: Both, Since: "7.4.2137"},
+	{Name: "function", MinArgs: 1, MaxArgs: 3, Result: "func", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "garbagecollect", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "get", MinArgs: 2, MaxArgs: 3, Result: "any", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "getbufinfo", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.2204"},
+	{Name: "getbufline", MinArgs: 2, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "getbufoneline", MinArgs: 2, MaxArgs: 2, Result: "string", Method: false, Editors: Both, Since: "9.0.0916"},
+	{Name: "getbufvar", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "getcellwidths", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "9.0.1212"},
+	{Name: "getchangelist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.0.1514"},
+	{Name: "getchar", MinArgs: 0, MaxArgs: 1, Result: "any", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "getcharmod", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "getcharpos", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "getcharsearch", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Both, Since: "7.4.813"},
+	{Name: "getcharstr", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.2.2957"},
+	{Name: "getcmdcompltype", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.2.4903"},
+	{Name: "getcmdline", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "6.2.442"},
+	{Name: "getcmdpos", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "6.2.442"},
+	{Name: "getcmdscreenpos", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "8.2.4903"},
+	{Name: "getcmdtype", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "getcmdwintype", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "7.4.392"},
+	{Name: "getcompletion", MinArgs: 2, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.2011"},
+	{Name: "getcurpos", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.312"},
+	{Name: "getcursorcharpos", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "getcwd", MinArgs: 0, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "getenv", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "8.1.1305"},
+	{Name: "getfontname", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "getfperm", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "getfsize", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "getftime", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "getftype", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "getimstatus", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "8.1.2000"},
+	{Name: "getjumplist", MinArgs: 0, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.0.1497"},
+	{Name: "getline", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: ""},
+	{Name: "getloclist", MinArgs: 1, MaxArgs: 2, Result: "any", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "getmarklist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.2.0861"},
+	{Name: "getmatches", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "7.1.040"},
+	{Name: "getmousepos", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Both, Since: "8.1.2304"},
+	{Name: "getmouseshape", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "9.0.0881"},
+	{Name: "getpid", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "7.1.262"},
+	{Name: "getpos", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "getqflist", MinArgs: 0, MaxArgs: 1, Result: "any", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "getreg", MinArgs: 0, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "getreginfo", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "8.2.0924"},
+	{Name: "getregtype", MinArgs: 0, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.2"},
+	{Name: "getscriptinfo", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "9.0.0244"},
+	{Name: "gettabinfo", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.2204"},
+	{Name: "gettabvar", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "gettabwinvar", MinArgs: 3, MaxArgs: 4, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "gettagstack", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "8.1.0519"},
+	{Name: "gettext", MinArgs: 1, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.2.1544"},
+	{Name: "getwininfo", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.2204"},
+	{Name: "getwinpos", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.0.1563"},
+	{Name: "getwinposx", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "5.4"},
+	{Name: "getwinposy", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "5.4"},
+	{Name: "getwinvar", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "glob", MinArgs: 1, MaxArgs: 4, Result: "any", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "glob2regpat", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.4.668"},
+	{Name: "globpath", MinArgs: 1, MaxArgs: 5, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "has", MinArgs: 1, MaxArgs: 2, Result: "number", Method: false, Editors: Both, Since: ""},
+	{Name: "has_key", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "haslocaldir", MinArgs: 0, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "hasmapto", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "histadd", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "histdel", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "histget", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "histnr", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "hlID", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "5.2"},
+	{Name: "hlexists", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "5.2"},
+	{Name: "hlget", MinArgs: 0, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.2.3578"},
+	{Name: "hlset", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.2.3578"},
+	{Name: "hostname", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: ""},
+	{Name: "iconv", MinArgs: 3, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "id", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Neovim, Since: "0.5.0"},
+	{Name: "indent", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "index", MinArgs: 2, MaxArgs: 4, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "indexof", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "9.0.0196"},
+	{Name: "input", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "inputdialog", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "inputlist", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "inputrestore", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "6.1.276"},
+	{Name: "inputsave", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "6.1.276"},
+	{Name: "inputsecret", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "insert", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "interrupt", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Both, Since: "8.1.2341"},
+	{Name: "invert", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.3.377"},
+	{Name: "isabsolutepath", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.2.4838"},
+	{Name: "isdirectory", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "isinf", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.1.1111"},
+	{Name: "islocked", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "isnan", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.4.1407"},
+	{Name: "items", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "job_getchannel", MinArgs: 1, MaxArgs: 1, Result: "channel", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "job_info", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "job_setoptions", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "7.4.1378"},
+	{Name: "job_start", MinArgs: 1, MaxArgs: 2, Result: "job", Method: true, Editors: Vim, Since: "7.4.1274"},
+	{Name: "job_status", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Vim, Since: "7.4.1274"},
+	{Name: "job_stop", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "7.4.1274"},
+	{Name: "jobpid", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "jobresize", MinArgs: 3, MaxArgs: 3, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "jobstart", MinArgs: 1, MaxArgs: 2, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "jobstop", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "jobwait", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "join", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "js_decode", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "js_encode", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "json_decode", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "json_encode", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "keys", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "keytrans", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "9.0.0449"},
+	{Name: "len", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "libcall", MinArgs: 3, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "libcallnr", MinArgs: 3, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "line", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "line2byte", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.4.35"},
+	{Name: "lispindent", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "list2blob", MinArgs: 1, MaxArgs: 1, Result: "blob", Method: true, Editors: Both, Since: "8.2.3438"},
+	{Name: "list2str", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.1.1122"},
+	{Name: "listener_add", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1320"},
+	{Name: "listener_flush", MinArgs: 0, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.1.1332"},
+	{Name: "listener_remove", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.1.1320"},
+	{Name: "localtime", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "5.4"},
+	{Name: "log", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "log10", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "luaeval", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.3.490"},
+	{Name: "map", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "maparg", MinArgs: 1, MaxArgs: 4, Result: "any", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "mapcheck", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "maplist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "9.0"},
+	{Name: "mapnew", MinArgs: 2, MaxArgs: 2, Result: "any", Method: false, Editors: Both, Since: "8.2.1969"},
+	{Name: "mapset", MinArgs: 1, MaxArgs: 3, Result: "void", Method: false, Editors: Both, Since: "8.2.0807"},
+	{Name: "match", MinArgs: 2, MaxArgs: 4, Result: "number", Method: false, Editors: Both, Since: ""},
+	{Name: "matchadd", MinArgs: 2, MaxArgs: 5, Result: "number", Method: true, Editors: Both, Since: "7.1.040"},
+	{Name: "matchaddpos", MinArgs: 2, MaxArgs: 5, Result: "number", Method: true, Editors: Both, Since: "7.4.330"},
+	{Name: "matcharg", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "matchdelete", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.1.040"},
+	{Name: "matchend", MinArgs: 2, MaxArgs: 4, Result: "number", Method: false, Editors: Both, Since: ""},
+	{Name: "matchfuzzy", MinArgs: 2, MaxArgs: 3, Result: "list<any>", Method: false, Editors: Both, Since: "8.2.1665"},
+	{Name: "matchfuzzypos", MinArgs: 2, MaxArgs: 3, Result: "list<any>", Method: false, Editors: Both, Since: "8.2.1726"},
+	{Name: "matchlist", MinArgs: 2, MaxArgs: 4, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "matchstr", MinArgs: 2, MaxArgs: 4, Result: "string", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "matchstrpos", MinArgs: 2, MaxArgs: 4, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.1685"},
+	{Name: "max", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "menu_get", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Neovim, Since: "0.3.0"},
+	{Name: "menu_info", MinArgs: 1, MaxArgs: 2, Result: "dict<any>", Method: true, Editors: Both, Since: "8.2.0385"},
+	{Name: "min", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "mkdir", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "mode", MinArgs: 0, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "msgpackdump", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "msgpackparse", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "mzeval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Vim, Since: "7.2.336"},
+	{Name: "nextnonblank", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "nr2char", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "or", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3.377"},
+	{Name: "pathshorten", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "perleval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.4.1125"},
+	{Name: "popup_atcursor", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_beval", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1645"},
+	{Name: "popup_clear", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "8.1.1513"},
+	{Name: "popup_close", MinArgs: 1, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_create", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_dialog", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "8.1.1548"},
+	{Name: "popup_filter_menu", MinArgs: 2, MaxArgs: 2, Result: "any", Method: false, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_filter_yesno", MinArgs: 2, MaxArgs: 2, Result: "any", Method: false, Editors: Vim, Since: "8.1.1548"},
+	{Name: "popup_findecho", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_findinfo", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Vim, Since: "8.1.1905"},
+	{Name: "popup_findpreview", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_getoptions", MinArgs: 1, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_getpos", MinArgs: 1, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_hide", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.1.1364"},
+	{Name: "popup_list", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Vim, Since: "8.2.0748"},
+	{Name: "popup_locate", MinArgs: 2, MaxArgs: 2, Result: "number", Method: false, Editors: Vim, Since: "8.1.1673"},
+	{Name: "popup_menu", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_move", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_notification", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1391"},
+	{Name: "popup_setoptions", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.1561"},
+	{Name: "popup_settext", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.1553"},
+	{Name: "popup_show", MinArgs: 1, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "8.1.1364"},
+	{Name: "pow", MinArgs: 2, MaxArgs: 2, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "prevnonblank", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "printf", MinArgs: 1, MaxArgs: -1, Result: "string", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "prompt_getprompt", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "8.2.1588"},
+	{Name: "prompt_setcallback", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "8.1.0027"},
+	{Name: "prompt_setinterrupt", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "8.1.0069"},
+	{Name: "prompt_setprompt", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "8.1.0027"},
+	{Name: "prop_add", MinArgs: 3, MaxArgs: 3, Result: "any", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_add_list", MinArgs: 1, MaxArgs: -1, Result: "void", Method: true, Editors: Vim, Since: "8.2.3356"},
+	{Name: "prop_clear", MinArgs: 1, MaxArgs: 3, Result: "void", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_find", MinArgs: 1, MaxArgs: 2, Result: "dict<any>", Method: false, Editors: Vim, Since: "8.2.0110"},
+	{Name: "prop_list", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_remove", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_type_add", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_type_change", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_type_delete", MinArgs: 1, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_type_get", MinArgs: 1, MaxArgs: 2, Result: "dict<any>", Method: true, Editors: Vim, Since: "8.1.0579"},
+	{Name: "prop_type_list", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Vim, Since: "8.1.0579"},
+	{Name: "pum_getpos", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Both, Since: "8.1.1875"},
+	{Name: "pumvisible", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "py3eval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.3.569"},
+	{Name: "pyeval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "7.3.569"},
+	{Name: "pyxeval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "8.0.0251"},
+	{Name: "rand", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "8.1.2342"},
+	{Name: "range", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "readblob", MinArgs: 1, MaxArgs: 3, Result: "blob", Method: false, Editors: Both, Since: "8.2.2343"},
+	{Name: "readdir", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.1120"},
+	{Name: "readdirex", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Vim, Since: "8.2.0875"},
+	{Name: "readfile", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "reduce", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "reg_executing", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.1.0020"},
+	{Name: "reg_recorded", MinArgs: 0, MaxArgs: 0, Result: "string", Method: false, Editors: Neovim, Since: "0.5.0"},
+	{Name: "reg_recording", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.1.0020"},
+	{Name: "reltime", MinArgs: 0, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "reltimefloat", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.4.1285"},
+	{Name: "reltimestr", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "remote_expr", MinArgs: 2, MaxArgs: 4, Result: "string", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "remote_foreground", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "remote_peek", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "remote_read", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "remote_send", MinArgs: 2, MaxArgs: 3, Result: "string", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "remote_startserver", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.0.0475"},
+	{Name: "remove", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "rename", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.4.49"},
+	{Name: "repeat", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "resolve", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "reverse", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "round", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "rpcnotify", MinArgs: 2, MaxArgs: -1, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "rpcrequest", MinArgs: 2, MaxArgs: -1, Result: "any", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "rubyeval", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Vim, Since: "8.1.1056"},
+	{Name: "screenattr", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3.1164"},
+	{Name: "screenchar", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3.1164"},
+	{Name: "screenchars", MinArgs: 2, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.1071"},
+	{Name: "screencol", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.3.748"},
+	{Name: "screenpos", MinArgs: 3, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "8.1.1645"},
+	{Name: "screenrow", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.3.748"},
+	{Name: "screenstring", MinArgs: 2, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "8.1.1071"},
+	{Name: "search", MinArgs: 1, MaxArgs: 5, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "searchcount", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "8.2.0877"},
+	{Name: "searchdecl", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "searchpair", MinArgs: 3, MaxArgs: -1, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "searchpairpos", MinArgs: 3, MaxArgs: -1, Result: "list<any>", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "searchpos", MinArgs: 1, MaxArgs: 5, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "server2client", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "6.0"},
+	{Name: "serverlist", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "serverstart", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "serverstop", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "setbufline", MinArgs: 3, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.0.1039"},
+	{Name: "setbufvar", MinArgs: 1, MaxArgs: 3, Result: "void", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "setcellwidths", MinArgs: 1, MaxArgs: 1, Result: "void", Method: false, Editors: Both, Since: "8.2.1535"},
+	{Name: "setcharpos", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "setcharsearch", MinArgs: 1, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "7.4.813"},
+	{Name: "setcmdline", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "9.0.0285"},
+	{Name: "setcmdpos", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.2.442"},
+	{Name: "setcursorcharpos", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "9.0"},
+	{Name: "setenv", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Both, Since: "8.1.1305"},
+	{Name: "setfperm", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.4.1516"},
+	{Name: "setline", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "setloclist", MinArgs: 2, MaxArgs: 4, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "setmatches", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.1.040"},
+	{Name: "setpos", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "setqflist", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "setreg", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "6.2"},
+	{Name: "settabvar", MinArgs: 3, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "settabwinvar", MinArgs: 4, MaxArgs: 4, Result: "void", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "settagstack", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.1.0519"},
+	{Name: "setwinvar", MinArgs: 1, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "sha256", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.3.816"},
+	{Name: "shellescape", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "7.0.111"},
+	{Name: "shiftwidth", MinArgs: 0, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.3.694"},
+	{Name: "sign_define", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_getdefined", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_getplaced", MinArgs: 0, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_jump", MinArgs: 3, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.1.0717"},
+	{Name: "sign_place", MinArgs: 4, MaxArgs: 5, Result: "number", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_placelist", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_undefine", MinArgs: 0, MaxArgs: 1, Result: "any", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_unplace", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "sign_unplacelist", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.0614"},
+	{Name: "simplify", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.2.064"},
+	{Name: "sin", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "sinh", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "slice", MinArgs: 2, MaxArgs: 3, Result: "any", Method: true, Editors: Both, Since: "8.2.2344"},
+	{Name: "sockconnect", MinArgs: 2, MaxArgs: 3, Result: "number", Method: false, Editors: Neovim, Since: "0.2.0"},
+	{Name: "sort", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "sound_clear", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "8.1.1502"},
+	{Name: "sound_playevent", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1502"},
+	{Name: "sound_playfile", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.1.1502"},
+	{Name: "sound_stop", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.1.1502"},
+	{Name: "soundfold", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "spellbadword", MinArgs: 0, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "spellsuggest", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "split", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "sqrt", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "srand", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "8.1.2342"},
+	{Name: "state", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "8.1.2047"},
+	{Name: "stdioopen", MinArgs: 1, MaxArgs: 1, Result: "number", Method: false, Editors: Neovim, Since: "0.5.0"},
+	{Name: "stdpath", MinArgs: 1, MaxArgs: 1, Result: "any", Method: false, Editors: Neovim, Since: "0.3.1"},
+	{Name: "str2float", MinArgs: 1, MaxArgs: 2, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "str2list", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.1122"},
+	{Name: "str2nr", MinArgs: 1, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "strcharlen", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.2.2606"},
+	{Name: "strcharpart", MinArgs: 2, MaxArgs: 4, Result: "string", Method: true, Editors: Both, Since: "7.4.1730"},
+	{Name: "strchars", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "strdisplaywidth", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "strftime", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "strgetchar", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.4.1730"},
+	{Name: "stridx", MinArgs: 2, MaxArgs: 3, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "string", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "strlen", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "strpart", MinArgs: 2, MaxArgs: 4, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "strptime", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.1.2326"},
+	{Name: "strridx", MinArgs: 1, MaxArgs: 3, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "strtrans", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "strwidth", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "submatch", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "substitute", MinArgs: 4, MaxArgs: 4, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "swapfilelist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "9.0.1007"},
+	{Name: "swapinfo", MinArgs: 1, MaxArgs: 1, Result: "dict<any>", Method: true, Editors: Both, Since: "8.1.0313"},
+	{Name: "swapname", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "8.1.0401"},
+	{Name: "synID", MinArgs: 3, MaxArgs: 3, Result: "number", Method: false, Editors: Both, Since: ""},
+	{Name: "synIDattr", MinArgs: 2, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: ""},
+	{Name: "synIDtrans", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: ""},
+	{Name: "synconcealed", MinArgs: 2, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Both, Since: "7.3"},
+	{Name: "synstack", MinArgs: 2, MaxArgs: 2, Result: "list<any>", Method: false, Editors: Both, Since: "7.1.215"},
+	{Name: "system", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Both, Since: "5.4"},
+	{Name: "systemlist", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.248"},
+	{Name: "tabpagebuflist", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "tabpagenr", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "tabpagewinnr", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "tagfiles", MinArgs: 0, MaxArgs: 0, Result: "list<any>", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "taglist", MinArgs: 1, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "tan", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "tanh", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "tempname", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: ""},
+	{Name: "term_dumpdiff", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Vim, Since: "8.0.1523"},
+	{Name: "term_dumpload", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_dumpwrite", MinArgs: 2, MaxArgs: 3, Result: "void", Method: true, Editors: Vim, Since: "8.0.1523"},
+	{Name: "term_getaltscreen", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Vim, Since: "8.0.0898"},
+	{Name: "term_getansicolors", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Vim, Since: "8.0.1685"},
+	{Name: "term_getattr", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_getcursor", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Vim, Since: "8.0.0818"},
+	{Name: "term_getjob", MinArgs: 1, MaxArgs: 1, Result: "job", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_getline", MinArgs: 2, MaxArgs: 2, Result: "string", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_getscrolled", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Vim, Since: "8.0.0893"},
+	{Name: "term_getsize", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_getstatus", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Vim, Since: "8.0.0821"},
+	{Name: "term_gettitle", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Vim, Since: "8.0.0821"},
+	{Name: "term_gettty", MinArgs: 1, MaxArgs: 2, Result: "string", Method: true, Editors: Vim, Since: "8.0.0846"},
+	{Name: "term_list", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_scrape", MinArgs: 2, MaxArgs: 2, Result: "list<any>", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_sendkeys", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_setansicolors", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.0.1685"},
+	{Name: "term_setapi", MinArgs: 2, MaxArgs: 2, Result: "void", Method: false, Editors: Vim, Since: "8.1.2080"},
+	{Name: "term_setkill", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_setrestore", MinArgs: 2, MaxArgs: 2, Result: "any", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_setsize", MinArgs: 3, MaxArgs: 3, Result: "void", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_start", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "term_wait", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Vim, Since: "8.0.0693"},
+	{Name: "terminalprops", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Vim, Since: "8.2.0970"},
+	{Name: "termopen", MinArgs: 1, MaxArgs: 2, Result: "number", Method: false, Editors: Neovim, Since: "0.1.0"},
+	{Name: "test_alloc_fail", MinArgs: 3, MaxArgs: 3, Result: "void", Method: true, Editors: Vim, Since: "8.0"},
+	{Name: "test_autochdir", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "7.4.2015"},
+	{Name: "test_feedinput", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.0.1048"},
+	{Name: "test_garbagecollect_now", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Both, Since: "8.0"},
+	{Name: "test_garbagecollect_soon", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "8.1.1579"},
+	{Name: "test_getvalue", MinArgs: 1, MaxArgs: 1, Result: "any", Method: true, Editors: Vim, Since: "8.1.1334"},
+	{Name: "test_gui_event", MinArgs: 2, MaxArgs: 2, Result: "bool", Method: true, Editors: Vim, Since: "9.0"},
+	{Name: "test_ignore_error", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.0.0392"},
+	{Name: "test_mswin_event", MinArgs: 2, MaxArgs: 2, Result: "bool", Method: true, Editors: Vim, Since: "9.0.1084"},
+	{Name: "test_null_blob", MinArgs: 0, MaxArgs: 1, Result: "blob", Method: false, Editors: Vim, Since: "8.1.0736"},
+	{Name: "test_null_channel", MinArgs: 0, MaxArgs: 1, Result: "channel", Method: false, Editors: Vim, Since: "8.0"},
+	{Name: "test_null_dict", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Vim, Since: "7.4.1838"},
+	{Name: "test_null_function", MinArgs: 0, MaxArgs: 1, Result: "func", Method: false, Editors: Vim, Since: "9.0"},
+	{Name: "test_null_job", MinArgs: 0, MaxArgs: 1, Result: "job", Method: false, Editors: Vim, Since: "8.0"},
+	{Name: "test_null_list", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Vim, Since: "7.4.1838"},
+	{Name: "test_null_partial", MinArgs: 0, MaxArgs: 1, Result: "func", Method: false, Editors: Vim, Since: "8.0"},
+	{Name: "test_null_string", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Vim, Since: "8.0"},
+	{Name: "test_option_not_set", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "8.1.0386"},
+	{Name: "test_override", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Vim, Since: "8.0.0440"},
+	{Name: "test_refcount", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Vim, Since: "8.1.1044"},
+	{Name: "test_setmouse", MinArgs: 2, MaxArgs: 2, Result: "void", Method: false, Editors: Vim, Since: "8.1.1262"},
+	{Name: "test_settime", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Vim, Since: "7.4.1903"},
+	{Name: "test_srand_seed", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Vim, Since: "9.0"},
+	{Name: "test_unknown", MinArgs: 0, MaxArgs: 1, Result: "any", Method: false, Editors: Vim, Since: "8.2.0299"},
+	{Name: "test_void", MinArgs: 0, MaxArgs: 1, Result: "any", Method: false, Editors: Vim, Since: "8.2.0299"},
+	{Name: "timer_info", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.2170"},
+	{Name: "timer_pause", MinArgs: 2, MaxArgs: 2, Result: "void", Method: true, Editors: Both, Since: "7.4.2180"},
+	{Name: "timer_start", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "timer_stop", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "timer_stopall", MinArgs: 0, MaxArgs: 1, Result: "void", Method: false, Editors: Both, Since: "7.4.2180"},
+	{Name: "tolower", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "toupper", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "tr", MinArgs: 3, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "trim", MinArgs: 1, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "8.0.1630"},
+	{Name: "trunc", MinArgs: 1, MaxArgs: 1, Result: "float", Method: true, Editors: Both, Since: "7.2"},
+	{Name: "type", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "typename", MinArgs: 1, MaxArgs: 1, Result: "string", Method: false, Editors: Vim, Since: "8.2.2339"},
+	{Name: "undofile", MinArgs: 1, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "7.3"},
+	{Name: "undotree", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: false, Editors: Both, Since: "7.3"},
+	{Name: "uniq", MinArgs: 1, MaxArgs: 3, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "values", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "virtcol", MinArgs: 1, MaxArgs: 2, Result: "any", Method: true, Editors: Both, Since: ""},
+	{Name: "virtcol2col", MinArgs: 3, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.2.5034"},
+	{Name: "visualmode", MinArgs: 0, MaxArgs: 1, Result: "string", Method: false, Editors: Both, Since: "5.4"},
+	{Name: "wait", MinArgs: 2, MaxArgs: 3, Result: "number", Method: false, Editors: Neovim, Since: "0.5.0"},
+	{Name: "wildmenumode", MinArgs: 0, MaxArgs: 1, Result: "number", Method: false, Editors: Both, Since: "7.3.828"},
+	{Name: "win_execute", MinArgs: 2, MaxArgs: 3, Result: "string", Method: true, Editors: Both, Since: "8.1.1418"},
+	{Name: "win_findbuf", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "7.4.1558"},
+	{Name: "win_getid", MinArgs: 0, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "win_gettype", MinArgs: 0, MaxArgs: 1, Result: "string", Method: true, Editors: Both, Since: "8.2.0257"},
+	{Name: "win_gotoid", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "win_id2tabwin", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "win_id2win", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "8.0"},
+	{Name: "win_move_separator", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.2.4052"},
+	{Name: "win_move_statusline", MinArgs: 1, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "8.2.4052"},
+	{Name: "win_screenpos", MinArgs: 1, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.0.1364"},
+	{Name: "win_splitmove", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "8.1.2020"},
+	{Name: "winbufnr", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "wincol", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "windowsversion", MinArgs: 0, MaxArgs: 0, Result: "string", Method: false, Editors: Both, Since: "8.2.0047"},
+	{Name: "winheight", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.1"},
+	{Name: "winlayout", MinArgs: 0, MaxArgs: 1, Result: "list<any>", Method: true, Editors: Both, Since: "8.1.0307"},
+	{Name: "winline", MinArgs: 0, MaxArgs: 0, Result: "number", Method: false, Editors: Both, Since: "6.0"},
+	{Name: "winnr", MinArgs: 0, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "5.2"},
+	{Name: "winrestcmd", MinArgs: 0, MaxArgs: 0, Result: "string", Method: false, Editors: Both, Since: "6.2.185"},
+	{Name: "winrestview", MinArgs: 1, MaxArgs: 1, Result: "void", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "winsaveview", MinArgs: 0, MaxArgs: 0, Result: "dict<any>", Method: false, Editors: Both, Since: "7.0"},
+	{Name: "winwidth", MinArgs: 1, MaxArgs: 1, Result: "number", Method: true, Editors: Both, Since: "6.0"},
+	{Name: "wordcount", MinArgs: 0, MaxArgs: 1, Result: "dict<any>", Method: false, Editors: Both, Since: "7.4.1042"},
+	{Name: "writefile", MinArgs: 2, MaxArgs: 3, Result: "number", Method: true, Editors: Both, Since: "7.0"},
+	{Name: "xor", MinArgs: 2, MaxArgs: 2, Result: "number", Method: true, Editors: Both, Since: "7.3.377"},
+}
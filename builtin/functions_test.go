@@ -0,0 +1,89 @@
+package builtin
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestFunctions(t *testing.T) {
+	names := map[string]bool{}
+	for _, f := range functions {
+		if names[f.Name] {
+			t.Errorf("duplicated name %q", f.Name)
+		}
+		names[f.Name] = true
+		if f.MinArgs < 0 || f.MaxArgs >= 0 && f.MaxArgs < f.MinArgs {
+			t.Errorf("%s: invalid arguments %d..%d", f.Name, f.MinArgs, f.MaxArgs)
+		}
+		if f.Editors == 0 {
+			t.Errorf("%s: no editors", f.Name)
+		}
+	}
+	if !sort.SliceIsSorted(functions, func(i, j int) bool { return functions[i].Name < functions[j].Name }) {
+		t.Error("functions are not sorted")
+	}
+}
+
+func TestLookupFunction(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     int
+		max     int
+		editors Editor
+	}{
+		{"strlen", 1, 1, Both},
+		{"printf", 1, -1, Both},
+		{"execute", 1, 2, Both},
+		{"get", 2, 3, Both},
+		{"popup_create", 2, 2, Vim},
+		{"synID", 3, 3, Both},
+		{"synIDattr", 2, 3, Both},
+		{"synIDtrans", 1, 1, Both},
+		{"hlID", 1, 1, Both},
+		{"diff_hlID", 2, 2, Both},
+		{"jobstart", 1, 2, Neovim},
+		{"nvim_buf_get_lines", 0, -1, Neovim},
+	}
+	for _, tt := range tests {
+		f := LookupFunction(tt.name)
+		if f == nil {
+			t.Errorf("LookupFunction(%q) = nil", tt.name)
+			continue
+		}
+		if f.MinArgs != tt.min || f.MaxArgs != tt.max || f.Editors != tt.editors {
+			t.Errorf("LookupFunction(%q) = %d..%d %v, want %d..%d %v",
+				tt.name, f.MinArgs, f.MaxArgs, f.Editors, tt.min, tt.max, tt.editors)
+		}
+	}
+	for _, name := range []string{"strlenn", "nvim_", "Strlen"} {
+		if f := LookupFunction(name); f != nil {
+			t.Errorf("LookupFunction(%q) = %+v, want nil", name, f)
+		}
+	}
+}
+
+func TestFunctionSince(t *testing.T) {
+	tests := []struct {
+		name  string
+		since string
+	}{
+		{"strlen", ""},
+		{"bufexists", "5.2"},
+		{"confirm", "5.2"},
+		{"abs", "7.2"},
+		{"str2float", "7.2"},
+		{"acos", "7.3"},
+		{"strchars", "7.3"},
+		{"gettabvar", "7.3"},
+		{"undotree", "7.3"},
+		{"shellescape", "7.0.111"},
+		{"pyxeval", "8.0.0251"},
+		{"ch_readblob", "8.2"},
+		{"popup_create", "8.1.1391"},
+	}
+	for _, tt := range tests {
+		if f := LookupFunction(tt.name); f == nil || f.Since != tt.since {
+			t.Errorf("LookupFunction(%q).Since = %+v, want %q", tt.name, f, tt.since)
+		}
+	}
+}
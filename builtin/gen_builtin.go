@@ -0,0 +1,751 @@
+//go:build ignore
+// +build ignore
+
+// gen_builtin.go generates functions_table.go and commands_table.go from the
+// help files of Vim.
+//
+// Usage:
+//
+//	go run gen_builtin.go [-vimruntime dir]
+//
+// The arguments and the results of functions are from the table in
+// builtin.txt and the descriptions of the functions in all help files. The
+// method calls are from "Can also be used as a |method|". The commands are
+// from the index of Ex commands in index.txt and the tables of the commands
+// of Neovim in the parser. The versions are from the lists of new functions
+// and commands and the patches in version*.txt. Neovim doesn't have the
+// table, so the functions of Neovim are listed in this file.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	internal "github.com/vim-jp/go-vimlparser/go"
+)
+
+var vimruntime = flag.String("vimruntime", os.Getenv("VIMRUNTIME"), "runtime directory of Vim; ask vim if empty")
+
+type function struct {
+	name    string
+	min     int
+	max     int // -1 for variadic
+	result  string
+	method  bool
+	editors string
+	since   string
+}
+
+type command struct {
+	name    string
+	editors string
+	since   string
+}
+
+// neovimFunctions are the functions of Neovim 0.10 which Vim doesn't have.
+// nvim_* functions are not listed.
+var neovimFunctions = []*function{
+	{name: "api_info", min: 0, max: 0, result: "dict<any>", since: "0.1.5"},
+	{name: "chanclose", min: 1, max: 2, result: "number", since: "0.3.0"},
+	{name: "chansend", min: 2, max: 2, result: "number", since: "0.3.0"},
+	{name: "ctxget", min: 0, max: 1, result: "dict<any>", since: "0.4.0"},
+	{name: "ctxpop", min: 0, max: 0, result: "void", since: "0.4.0"},
+	{name: "ctxpush", min: 0, max: 1, result: "void", since: "0.4.0"},
+	{name: "ctxset", min: 1, max: 2, result: "void", since: "0.4.0"},
+	{name: "ctxsize", min: 0, max: 0, result: "number", since: "0.4.0"},
+	{name: "dictwatcheradd", min: 3, max: 3, result: "void", since: "0.1.5"},
+	{name: "dictwatcherdel", min: 3, max: 3, result: "void", since: "0.1.5"},
+	{name: "id", min: 1, max: 1, result: "string", method: true, since: "0.5.0"},
+	{name: "jobpid", min: 1, max: 1, result: "number", since: "0.1.0"},
+	{name: "jobresize", min: 3, max: 3, result: "number", since: "0.1.0"},
+	{name: "jobstart", min: 1, max: 2, result: "number", since: "0.1.0"},
+	{name: "jobstop", min: 1, max: 1, result: "number", since: "0.1.0"},
+	{name: "jobwait", min: 1, max: 2, result: "list<any>", since: "0.1.0"},
+	{name: "menu_get", min: 1, max: 2, result: "list<any>", since: "0.3.0"},
+	{name: "msgpackdump", min: 1, max: 2, result: "list<any>", since: "0.1.0"},
+	{name: "msgpackparse", min: 1, max: 1, result: "list<any>", since: "0.1.0"},
+	{name: "reg_recorded", min: 0, max: 0, result: "string", since: "0.5.0"},
+	{name: "rpcnotify", min: 2, max: -1, result: "number", since: "0.1.0"},
+	{name: "rpcrequest", min: 2, max: -1, result: "any", since: "0.1.0"},
+	{name: "serverstart", min: 0, max: 1, result: "string", since: "0.1.0"},
+	{name: "serverstop", min: 1, max: 1, result: "number", since: "0.1.0"},
+	{name: "sockconnect", min: 2, max: 3, result: "number", since: "0.2.0"},
+	{name: "stdioopen", min: 1, max: 1, result: "number", since: "0.5.0"},
+	{name: "stdpath", min: 1, max: 1, result: "any", since: "0.3.1"},
+	{name: "termopen", min: 1, max: 2, result: "number", since: "0.1.0"},
+	{name: "wait", min: 2, max: 3, result: "number", since: "0.5.0"},
+}
+
+// neovimCommandSince is the versions of Neovim which introduced the commands
+// of Neovim in the parser.
+var neovimCommandSince = map[string]string{
+	"rshada": "0.1.0",
+	"wshada": "0.1.0",
+}
+
+// vimOnlyCommands are the commands of Vim which Neovim doesn't have in
+// addition to the commands removed in the parser: Vim9 script, MzScheme,
+// encryption and the dialogs of the GUI.
+var vimOnlyCommands = []string{
+	"X", "class", "def", "defcompile", "disassemble", "endclass", "enddef",
+	"export", "final", "import", "legacy", "mzfile", "mzscheme",
+	"promptfind", "promptrepl", "public", "static", "var", "vim9cmd",
+	"vim9script",
+}
+
+// vimOnlyPrefixes and vimOnlyFunctions are the functions of Vim which
+// Neovim doesn't have.
+var vimOnlyPrefixes = []string{
+	"balloon_", "ch_", "job_", "js_", "listener_", "popup_", "prop_",
+	"remote_", "sound_", "term_", "test_",
+}
+
+var vimOnlyFunctions = map[string]bool{
+	"autocmd_add":     true,
+	"autocmd_delete":  true,
+	"autocmd_get":     true,
+	"bindtextdomain":  true,
+	"echoraw":         true,
+	"err_teapot":      true,
+	"exists_compiled": true,
+	"mzeval":          true,
+	"readdirex":       true,
+	"rubyeval":        true,
+	"server2client":   true,
+	"terminalprops":   true,
+	"typename":        true,
+
+	// test functions which Neovim has.
+	"test_garbagecollect_now": false,
+	"test_write_list_log":     false,
+}
+
+// featureSince is the versions of functions which version*.txt doesn't
+// list by the name. A key ending with "_" is the prefix of the functions of a
+// feature, whose version is the patch which introduced the feature; some of
+// the functions were added by later patches. version9.txt of Vim 9.0 doesn't
+// have the patches of 9.0, so the functions added by them are from the list
+// of the patches.
+var featureSince = map[string]string{
+	"popup_":      "8.1.1391",
+	"prompt_":     "8.1.0027",
+	"prop_":       "8.1.0579",
+	"screenpos":   "8.1.1645",
+	"sign_":       "8.1.0614",
+	"sound_":      "8.1.1502",
+	"term_":       "8.0.0693",
+	"win_execute": "8.1.1418",
+
+	// The floating point functions of Vim 7.2.
+	"abs":       "7.2",
+	"atan":      "7.2",
+	"ceil":      "7.2",
+	"cos":       "7.2",
+	"float2nr":  "7.2",
+	"floor":     "7.2",
+	"log10":     "7.2",
+	"pow":       "7.2",
+	"round":     "7.2",
+	"sin":       "7.2",
+	"sqrt":      "7.2",
+	"str2float": "7.2",
+	"trunc":     "7.2",
+
+	"byte2line":     "6.0",
+	"confirm":       "5.2",
+	"fnameescape":   "7.1.299",
+	"foldtext":      "6.0",
+	"getregtype":    "6.2",
+	"line2byte":     "5.4.35",
+	"pyxeval":       "8.0.0251",
+	"remote_":       "6.0",
+	"server2client": "6.0",
+	"serverlist":    "6.0",
+	"setreg":        "6.2",
+	"submatch":      "6.0",
+
+	"getbufoneline":    "9.0.0916",
+	"getcellwidths":    "9.0.1212",
+	"getmouseshape":    "9.0.0881",
+	"getscriptinfo":    "9.0.0244",
+	"indexof":          "9.0.0196",
+	"keytrans":         "9.0.0449",
+	"setcmdline":       "9.0.0285",
+	"swapfilelist":     "9.0.1007",
+	"test_mswin_event": "9.0.1084",
+}
+
+// oldFunctions is the functions older than Vim 5.2, whose versions are
+// unknown.
+var oldFunctions = map[string]bool{
+	"char2nr":      true,
+	"col":          true,
+	"delete":       true,
+	"did_filetype": true,
+	"exists":       true,
+	"expand":       true,
+	"getcwd":       true,
+	"getline":      true,
+	"has":          true,
+	"hostname":     true,
+	"isdirectory":  true,
+	"line":         true,
+	"match":        true,
+	"matchend":     true,
+	"nr2char":      true,
+	"strftime":     true,
+	"strlen":       true,
+	"strpart":      true,
+	"substitute":   true,
+	"synID":        true,
+	"synIDattr":    true,
+	"synIDtrans":   true,
+	"tempname":     true,
+	"virtcol":      true,
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen_builtin: ")
+	flag.Parse()
+	if *vimruntime == "" {
+		out, err := exec.Command("vim", "-u", "NONE", "-N", "-es",
+			"--cmd", `call writefile([$VIMRUNTIME], "/dev/stdout")`, "--cmd", "qall!").Output()
+		if err != nil {
+			log.Fatalf("vim: %v", err)
+		}
+		*vimruntime = strings.TrimSpace(string(out))
+	}
+	doc := filepath.Join(*vimruntime, "doc")
+
+	funcs, err := parseUsage(filepath.Join(doc, "builtin.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := parseDetails(doc, funcs); err != nil {
+		log.Fatal(err)
+	}
+	cmds, err := parseIndex(filepath.Join(doc, "index.txt"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := parseVersions(doc, &versions{funcs, cmds}); err != nil {
+		log.Fatal(err)
+	}
+	for _, f := range funcs {
+		// The lists of new functions of Vim 8.1 and 8.2 have the versions of
+		// the releases, which are later than the patches.
+		release := strings.Count(f.since, ".") == 1
+		if v := featureVersion(f.name); v != "" && (f.since == "" || release && versionLess(v, f.since)) {
+			f.since = v
+		}
+		if f.since == "" && !oldFunctions[f.name] {
+			log.Fatalf("unknown version of %s()", f.name)
+		}
+		f.editors = "Both"
+		if isVimOnly(f.name) {
+			f.editors = "Vim"
+		}
+	}
+	for _, f := range neovimFunctions {
+		if funcs[f.name] != nil {
+			log.Fatalf("%s is a function of Vim", f.name)
+		}
+		f.editors = "Neovim"
+		funcs[f.name] = f
+	}
+
+	for _, c := range cmds {
+		c.editors = "Both"
+	}
+	added, removed := internal.NeovimCommands()
+	for _, c := range added {
+		since, ok := neovimCommandSince[c.Name]
+		if !ok {
+			log.Fatalf("unknown version of :%s of Neovim", c.Name)
+		}
+		cmds[c.Name] = &command{name: c.Name, editors: "Neovim", since: since}
+	}
+	for _, c := range removed {
+		vimOnlyCommands = append(vimOnlyCommands, c.Name)
+	}
+	for _, name := range vimOnlyCommands {
+		if cmds[name] == nil {
+			log.Fatalf(":%s is not a command of Vim", name)
+		}
+		cmds[name].editors = "Vim"
+	}
+
+	var flist []*function
+	for _, f := range funcs {
+		flist = append(flist, f)
+	}
+	sort.Slice(flist, func(i, j int) bool { return flist[i].name < flist[j].name })
+	var buf bytes.Buffer
+	header(&buf, "builtin.txt")
+	fmt.Fprintf(&buf, "var functions = []Function{\n")
+	for _, f := range flist {
+		fmt.Fprintf(&buf, "{Name: %q, MinArgs: %d, MaxArgs: %d, Result: %q, Method: %v, Editors: %s, Since: %q},\n",
+			f.name, f.min, f.max, f.result, f.method, f.editors, f.since)
+	}
+	fmt.Fprintf(&buf, "}\n")
+	write("functions_table.go", buf.Bytes())
+
+	var clist []*command
+	for _, c := range cmds {
+		clist = append(clist, c)
+	}
+	sort.Slice(clist, func(i, j int) bool { return clist[i].name < clist[j].name })
+	buf.Reset()
+	header(&buf, "index.txt")
+	fmt.Fprintf(&buf, "var commands = []Command{\n")
+	for _, c := range clist {
+		fmt.Fprintf(&buf, "{Name: %q, Editors: %s, Since: %q},\n", c.name, c.editors, c.since)
+	}
+	fmt.Fprintf(&buf, "}\n")
+	write("commands_table.go", buf.Bytes())
+}
+
+// featureVersion returns the version in featureSince for the function name;
+// or "" if unknown.
+func featureVersion(name string) string {
+	for k, v := range featureSince {
+		if name == k || strings.HasSuffix(k, "_") && strings.HasPrefix(name, k) {
+			return v
+		}
+	}
+	return ""
+}
+
+func header(buf *bytes.Buffer, source string) {
+	fmt.Fprintf(buf, "// Code generated by gen_builtin.go; DO NOT EDIT.\n")
+	fmt.Fprintf(buf, "// source: %s/doc/%s and version*.txt\n\n", filepath.Base(*vimruntime), source)
+	fmt.Fprintf(buf, "package builtin\n\n")
+}
+
+func write(path string, src []byte) {
+	src, err := format.Source(src)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// widen widens the range of the number of the arguments by another
+// signature of the function.
+func (f *function) widen(min, max int) {
+	if min < f.min {
+		f.min = min
+	}
+	if f.max >= 0 && (max < 0 || max > f.max) {
+		f.max = max
+	}
+}
+
+func isVimOnly(name string) bool {
+	if only, ok := vimOnlyFunctions[name]; ok {
+		return only
+	}
+	for _, p := range vimOnlyPrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func readLines(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	s := bufio.NewScanner(bytes.NewReader(b))
+	s.Buffer(nil, 1<<20)
+	for s.Scan() {
+		lines = append(lines, s.Text())
+	}
+	return lines, s.Err()
+}
+
+var usageRe = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_]*)\((.*)\)(.*)$`)
+
+// parseUsage parses the table of functions in builtin.txt, e.g.
+//
+//	abs({expr})			Float or Number  absolute value of {expr}
+//	argv({nr} [, {winid}])		String	{nr} entry of the argument list
+//
+// The result is in the next line if the arguments are long.
+func parseUsage(path string) (map[string]*function, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	funcs := make(map[string]*function)
+	in := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "USAGE") {
+			in = true
+			continue
+		}
+		if !in {
+			continue
+		}
+		if strings.HasPrefix(line, "====") {
+			break
+		}
+		// the arguments end at the last ")" before the result.
+		sig := line
+		if j := strings.IndexByte(sig, '\t'); j >= 0 {
+			sig = sig[:j]
+		}
+		m := usageRe.FindStringSubmatch(sig)
+		if m == nil {
+			continue
+		}
+		rest := strings.TrimSpace(line[len(sig):])
+		if rest == "" && i+1 < len(lines) {
+			rest = strings.TrimSpace(lines[i+1])
+		}
+		min, max := countArgs(m[2])
+		result := resultType(rest)
+		if f := funcs[m[1]]; f != nil {
+			// e.g. get() of List, Dict and Funcref.
+			f.widen(min, max)
+			if f.result != result {
+				f.result = "any"
+			}
+			continue
+		}
+		funcs[m[1]] = &function{name: m[1], min: min, max: max, result: result}
+	}
+	if len(funcs) == 0 {
+		return nil, fmt.Errorf("%s: no functions", path)
+	}
+	return funcs, nil
+}
+
+// countArgs returns the minimum and maximum numbers of the arguments, e.g.
+// 1 and 3 for "{name} [, {arglist}] [, {dict}]". The maximum is -1 if the
+// arguments end with "...".
+func countArgs(s string) (min, max int) {
+	depth := 0
+	for _, arg := range strings.Split(s, ",") {
+		optional := false
+		name := ""
+		for _, c := range arg {
+			switch {
+			case c == '[':
+				depth++
+			case c == ']':
+				depth--
+			case c != ' ' && name == "":
+				optional = depth > 0
+				name = string(c)
+			}
+		}
+		arg = strings.Trim(arg, "[] ")
+		if strings.HasSuffix(arg, "...") {
+			max = -1
+			arg = strings.TrimSpace(strings.TrimSuffix(arg, "..."))
+			optional = true
+		}
+		if arg == "" {
+			continue
+		}
+		if !optional {
+			min++
+		}
+		if max >= 0 {
+			max++
+		}
+	}
+	return min, max
+}
+
+// resultTypes maps the results in the table to the types in Vim9 script.
+// The other results, e.g. "Float or Number", are "any".
+var resultTypes = map[string]string{
+	"Number":  "number",
+	"Float":   "float",
+	"String":  "string",
+	"List":    "list<any>",
+	"Dict":    "dict<any>",
+	"Funcref": "func",
+	"Blob":    "blob",
+	"Job":     "job",
+	"Channel": "channel",
+	"Bool":    "bool",
+	"bool":    "bool",
+	"Boolean": "bool",
+	"any":     "any",
+	"none":    "void",
+}
+
+func resultType(s string) string {
+	// the description follows the result after tabs or spaces.
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	if i := strings.Index(s, "  "); i >= 0 {
+		s = s[:i]
+	}
+	if t, ok := resultTypes[s]; ok {
+		return t
+	}
+	return "any"
+}
+
+var indexRe = regexp.MustCompile(`^\|:([A-Za-z][A-Za-z0-9]*)\|`)
+
+// parseIndex parses the index of Ex commands in index.txt, e.g.
+//
+//	|:cdo|		:cdo		execute command in each valid error list entry
+func parseIndex(path string) (map[string]*command, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+	cmds := make(map[string]*command)
+	for _, line := range lines {
+		if m := indexRe.FindStringSubmatch(line); m != nil {
+			cmds[m[1]] = &command{name: m[1]}
+		}
+	}
+	if len(cmds) == 0 {
+		return nil, fmt.Errorf("%s: no commands", path)
+	}
+	return cmds, nil
+}
+
+var (
+	tagRe       = regexp.MustCompile(`\*([a-zA-Z][a-zA-Z0-9_]*)\(\)\*`)
+	signatureRe = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_]*)\((.*)\)\s*(\*.*)?$`)
+	methodRe    = regexp.MustCompile(`Can also be used as a \|method\|`)
+)
+
+// parseDetails parses the descriptions of the functions in the help files.
+// The signatures in the descriptions widen the arguments, because the table
+// omits some optional arguments, e.g.
+//
+//	execute({command} [, {silent}])					*execute()*
+//
+// It also marks the functions which can be used as methods.
+func parseDetails(doc string, funcs map[string]*function) error {
+	paths, err := filepath.Glob(filepath.Join(doc, "*.txt"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		lines, err := readLines(path)
+		if err != nil {
+			return err
+		}
+		var cur *function
+		for _, line := range lines {
+			if m := tagRe.FindAllStringSubmatch(line, -1); m != nil {
+				cur = funcs[m[len(m)-1][1]]
+			}
+			if m := signatureRe.FindStringSubmatch(line); m != nil && funcs[m[1]] != nil {
+				funcs[m[1]].widen(countArgs(m[2]))
+			}
+			if cur != nil && methodRe.MatchString(line) {
+				cur.method = true
+			}
+		}
+	}
+	return nil
+}
+
+var (
+	versionRe  = regexp.MustCompile(`^VERSION (\d+\.\d+)\s+\*version-`)
+	patchRe    = regexp.MustCompile(`^Patch (\d+\.\d+\.\d+)$`)
+	newFuncsRe = regexp.MustCompile(`^(New (and extended )?functions|(Added f|F)unctions:$|Renamed functions:$)`)
+	newCmdsRe  = regexp.MustCompile(`^(New (Ex )?commands|Ex commands: ~)`)
+	callRe     = regexp.MustCompile(`\b([a-zA-Z][a-zA-Z0-9_]*)\(\)`)
+	cmdRe      = regexp.MustCompile(`:([A-Za-z][A-Za-z0-9]*(?:\[[a-z]*\])?)`)
+	addFuncRe  = regexp.MustCompile(`\b(?:[Aa]dd(?:ed)?|[Ii]mplement|More [a-z ]*functions:)(?: and use)?(?: the)?(?: functions?)? ((?:[a-zA-Z][a-zA-Z0-9_]*\(\)(?:,? and |, | or )?)+)`)
+	addCmdRe   = regexp.MustCompile(`\b(?:[Aa]dd|[Ii]mplement)(?: the)?(?: commands?)? ((?:"?:[A-Za-z]+"?(?:,? and |, | or )?)+)`)
+	extendedRe = regexp.MustCompile(`^\s*(extra argument|takes an|also|with second|without an)`)
+	funcItemRe = regexp.MustCompile(`^\t\|([a-zA-Z][a-zA-Z0-9_]*)\(\)\|$`)
+	headingRe  = regexp.MustCompile(`^[A-Z][a-z ]*:$`)
+	renameRe   = regexp.MustCompile(`-> ([a-zA-Z][a-zA-Z0-9_]*)\(\)`)
+)
+
+// versions is the versions which introduced functions and commands.
+type versions struct {
+	funcs map[string]*function
+	cmds  map[string]*command
+}
+
+func (v *versions) setFunc(name, version string) {
+	if f := v.funcs[name]; f != nil && (f.since == "" || versionLess(version, f.since)) {
+		f.since = version
+	}
+}
+
+func (v *versions) setCmd(name, version string) {
+	// e.g. ":sav[eas]"
+	name = strings.NewReplacer("[", "", "]", "").Replace(name)
+	if c := v.cmds[name]; c != nil && (c.since == "" || versionLess(version, c.since)) {
+		c.since = version
+	}
+}
+
+// parseVersions sets the earliest version which mentions each function and
+// command in the lists of new ones or in the solutions of patches which add
+// or implement it. The version is unknown if it's older than Vim 5.0 or the
+// notes don't mention it.
+func parseVersions(doc string, v *versions) error {
+	for n := 5; n <= 9; n++ {
+		lines, err := readLines(filepath.Join(doc, fmt.Sprintf("version%d.txt", n)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		version := fmt.Sprintf("%d.0", n)
+		patch, solution, para := "", "", ""
+		var list func(line string) // item of the list of new ones
+		for _, line := range lines {
+			if patch == "" {
+				// the paragraphs of the notes before the patches.
+				if line == "" {
+					v.addFuncs(para, version)
+					para = ""
+				} else {
+					para += strings.TrimSpace(line) + " "
+				}
+			}
+			switch {
+			case versionRe.MatchString(line):
+				version = versionRe.FindStringSubmatch(line)[1]
+				patch, list = "", nil
+				continue
+			case patchRe.MatchString(line):
+				patch, list = patchRe.FindStringSubmatch(line)[1], nil
+				continue
+			case newFuncsRe.MatchString(line):
+				list = func(line string) { v.newFunc(line, version) }
+				continue
+			case newCmdsRe.MatchString(line):
+				list = func(line string) { v.newCmd(line, version) }
+				continue
+			}
+			if list != nil {
+				if strings.HasSuffix(line, "~") || strings.HasPrefix(line, "New ") || strings.HasPrefix(line, "====") || headingRe.MatchString(line) {
+					list = nil
+					continue
+				}
+				list(line)
+				continue
+			}
+			// the solution continues in the indented lines.
+			switch {
+			case patch != "" && strings.HasPrefix(line, "Solution:"):
+				solution = line
+			case solution != "" && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")):
+				solution += " " + strings.TrimSpace(line)
+			case solution != "":
+				// e.g. "Add \":const\"."
+				v.addFuncs(solution, patch)
+				for _, m := range addCmdRe.FindAllStringSubmatch(solution, -1) {
+					for _, n := range cmdRe.FindAllStringSubmatch(m[1], -1) {
+						v.setCmd(n[1], patch)
+					}
+				}
+				solution = ""
+			}
+		}
+	}
+	return nil
+}
+
+// newFunc parses an item of the list of new functions. The names are at the
+// start of the items, e.g. "|add()|\t..." and "- |histnr()|, |histadd()|:",
+// or the items are indented names, e.g. "\t|pyxeval()|".
+func (v *versions) newFunc(line, version string) {
+	if m := funcItemRe.FindStringSubmatch(line); m != nil {
+		v.setFunc(m[1], version)
+		return
+	}
+	if m := renameRe.FindStringSubmatch(line); m != nil {
+		// e.g. "\t\tbuffer_exists()\t   -> bufexists()"
+		v.setFunc(m[1], version)
+		return
+	}
+	if line == "" || line[0] == ' ' || line[0] == '\t' {
+		return
+	}
+	names, desc := line, ""
+	if i := strings.IndexAny(line, "\t:"); i >= 0 {
+		names, desc = line[:i], line[i+1:]
+	}
+	if extendedRe.MatchString(desc) {
+		// e.g. "|stridx()|\textra argument: start position"
+		return
+	}
+	for _, m := range callRe.FindAllStringSubmatch(names, -1) {
+		v.setFunc(m[1], version)
+	}
+}
+
+// addFuncs parses a solution of a patch or a paragraph of the notes which
+// adds functions, e.g. "Add the uniq() function.", "Add systemlist().",
+// "Added the |gettabvar()| and |settabvar()| functions." and "More floating
+// point functions: |acos()|, |asin()|, ...".
+func (v *versions) addFuncs(text, version string) {
+	text = strings.ReplaceAll(text, "|", "")
+	for _, m := range addFuncRe.FindAllStringSubmatch(text, -1) {
+		for _, n := range callRe.FindAllStringSubmatch(m[1], -1) {
+			v.setFunc(n[1], version)
+		}
+	}
+}
+
+// newCmd parses an item of the list of new commands. The name is at the
+// start of the item, e.g. "|:cdo|\t...", ":sav[eas][!] {file}" and
+// "\t:changes\t...".
+func (v *versions) newCmd(line, version string) {
+	line = strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(line, "|:") && !strings.HasPrefix(line, ":") {
+		return
+	}
+	if m := cmdRe.FindStringSubmatch(line); m != nil {
+		v.setCmd(m[1], version)
+	}
+}
+
+// versionLess reports whether the version v is older than w, e.g. "7.4"
+// and "7.4.1304".
+func versionLess(v, w string) bool {
+	vs, ws := strings.Split(v, "."), strings.Split(w, ".")
+	for i := 0; i < len(vs) || i < len(ws); i++ {
+		var a, b int
+		if i < len(vs) {
+			a, _ = strconv.Atoi(vs[i])
+		}
+		if i < len(ws) {
+			b, _ = strconv.Atoi(ws[i])
+		}
+		if a != b {
+			return a < b
+		}
+	}
+	return false
+}
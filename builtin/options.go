@@ -0,0 +1,520 @@
+// Package builtin provides tables of builtin things of Vim and Neovim, e.g.
+// options and functions.
+package builtin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Editor is a set of editors.
+type Editor int
+
+const (
+	Vim Editor = 1 << iota
+	Neovim
+
+	Both = Vim | Neovim
+)
+
+func (e Editor) String() string {
+	switch e {
+	case Vim:
+		return "Vim"
+	case Neovim:
+		return "Neovim"
+	case Both:
+		return "Vim and Neovim"
+	}
+	return fmt.Sprintf("Editor(%d)", int(e))
+}
+
+// OptionType is the type of the value of an option.
+type OptionType int
+
+const (
+	Bool OptionType = iota + 1
+	Number
+	String
+)
+
+// Option is a builtin option.
+type Option struct {
+	Name    string     // full name, e.g. "tabstop"
+	Short   string     // short name, e.g. "ts"; or empty
+	Type    OptionType // type of the value
+	Editors Editor     // editors which have the option
+
+	// Deprecated is the option to use instead of the deprecated option; or
+	// empty.
+	Deprecated string
+}
+
+// LookupOption returns the option by the full or short name. Terminal
+// options, e.g. t_Co and <t_F1>, and key codes, e.g. <F13>, are String
+// options of Vim. It returns nil if name is not an option.
+func LookupOption(name string) *Option {
+	if o, ok := optionsByName[name]; ok {
+		return o
+	}
+	if strings.HasPrefix(name, "t_") && len(name) == 4 ||
+		strings.HasPrefix(name, "<") && strings.HasSuffix(name, ">") && len(name) > 2 {
+		return &Option{Name: name, Type: String, Editors: Vim}
+	}
+	return nil
+}
+
+// Options returns builtin options sorted by the full name. Terminal options
+// are not included.
+func Options() []*Option {
+	list := make([]*Option, len(options))
+	for i := range options {
+		list[i] = &options[i]
+	}
+	return list
+}
+
+var optionsByName = func() map[string]*Option {
+	m := make(map[string]*Option, len(options)*2)
+	for i := range options {
+		o := &options[i]
+		m[o.Name] = o
+		if o.Short != "" {
+			m[o.Short] = o
+		}
+	}
+	return m
+}()
+
+// options is from options.txt of Vim 9.1 and Neovim 0.10.
+var options = []Option{
+	{Name: "aleph", Short: "al", Type: Number, Editors: Both},
+	{Name: "allowrevins", Short: "ari", Type: Bool, Editors: Both},
+	{Name: "ambiwidth", Short: "ambw", Type: String, Editors: Both},
+	{Name: "antialias", Short: "anti", Type: Bool, Editors: Vim},
+	{Name: "autochdir", Short: "acd", Type: Bool, Editors: Both},
+	{Name: "autoindent", Short: "ai", Type: Bool, Editors: Both},
+	{Name: "autoread", Short: "ar", Type: Bool, Editors: Both},
+	{Name: "autoshelldir", Short: "asd", Type: Bool, Editors: Vim},
+	{Name: "autowrite", Short: "aw", Type: Bool, Editors: Both},
+	{Name: "autowriteall", Short: "awa", Type: Bool, Editors: Both},
+	{Name: "background", Short: "bg", Type: String, Editors: Both},
+	{Name: "backspace", Short: "bs", Type: String, Editors: Both},
+	{Name: "backup", Short: "bk", Type: Bool, Editors: Both},
+	{Name: "backupcopy", Short: "bkc", Type: String, Editors: Both},
+	{Name: "backupdir", Short: "bdir", Type: String, Editors: Both},
+	{Name: "backupext", Short: "bex", Type: String, Editors: Both},
+	{Name: "backupskip", Short: "bsk", Type: String, Editors: Both},
+	{Name: "balloondelay", Short: "bdlay", Type: Number, Editors: Vim},
+	{Name: "ballooneval", Short: "beval", Type: Bool, Editors: Vim},
+	{Name: "balloonevalterm", Short: "bevalterm", Type: Bool, Editors: Vim},
+	{Name: "balloonexpr", Short: "bexpr", Type: String, Editors: Vim},
+	{Name: "belloff", Short: "bo", Type: String, Editors: Both},
+	{Name: "binary", Short: "bin", Type: Bool, Editors: Both},
+	{Name: "bomb", Type: Bool, Editors: Both},
+	{Name: "breakat", Short: "brk", Type: String, Editors: Both},
+	{Name: "breakindent", Short: "bri", Type: Bool, Editors: Both},
+	{Name: "breakindentopt", Short: "briopt", Type: String, Editors: Both},
+	{Name: "browsedir", Short: "bsdir", Type: String, Editors: Vim},
+	{Name: "bufhidden", Short: "bh", Type: String, Editors: Both},
+	{Name: "buflisted", Short: "bl", Type: Bool, Editors: Both},
+	{Name: "buftype", Short: "bt", Type: String, Editors: Both},
+	{Name: "casemap", Short: "cmp", Type: String, Editors: Both},
+	{Name: "cdhome", Short: "cdh", Type: Bool, Editors: Vim},
+	{Name: "cdpath", Short: "cd", Type: String, Editors: Both},
+	{Name: "cedit", Type: String, Editors: Both},
+	{Name: "channel", Type: Number, Editors: Neovim},
+	{Name: "charconvert", Short: "ccv", Type: String, Editors: Both},
+	{Name: "cindent", Short: "cin", Type: Bool, Editors: Both},
+	{Name: "cinkeys", Short: "cink", Type: String, Editors: Both},
+	{Name: "cinoptions", Short: "cino", Type: String, Editors: Both},
+	{Name: "cinscopedecls", Short: "cinsd", Type: String, Editors: Both},
+	{Name: "cinwords", Short: "cinw", Type: String, Editors: Both},
+	{Name: "clipboard", Short: "cb", Type: String, Editors: Both},
+	{Name: "cmdheight", Short: "ch", Type: Number, Editors: Both},
+	{Name: "cmdwinheight", Short: "cwh", Type: Number, Editors: Both},
+	{Name: "colorcolumn", Short: "cc", Type: String, Editors: Both},
+	{Name: "columns", Short: "co", Type: Number, Editors: Both},
+	{Name: "comments", Short: "com", Type: String, Editors: Both},
+	{Name: "commentstring", Short: "cms", Type: String, Editors: Both},
+	{Name: "compatible", Short: "cp", Type: Bool, Editors: Vim},
+	{Name: "complete", Short: "cpt", Type: String, Editors: Both},
+	{Name: "completefunc", Short: "cfu", Type: String, Editors: Both},
+	{Name: "completeopt", Short: "cot", Type: String, Editors: Both},
+	{Name: "completepopup", Short: "cpp", Type: String, Editors: Vim},
+	{Name: "completeslash", Short: "csl", Type: String, Editors: Both},
+	{Name: "concealcursor", Short: "cocu", Type: String, Editors: Both},
+	{Name: "conceallevel", Short: "cole", Type: Number, Editors: Both},
+	{Name: "confirm", Short: "cf", Type: Bool, Editors: Both},
+	{Name: "copyindent", Short: "ci", Type: Bool, Editors: Both},
+	{Name: "cpoptions", Short: "cpo", Type: String, Editors: Both},
+	{Name: "cryptmethod", Short: "cm", Type: String, Editors: Vim},
+	{Name: "cscopepathcomp", Short: "cspc", Type: Number, Editors: Vim},
+	{Name: "cscopeprg", Short: "csprg", Type: String, Editors: Vim},
+	{Name: "cscopequickfix", Short: "csqf", Type: String, Editors: Vim},
+	{Name: "cscoperelative", Short: "csre", Type: Bool, Editors: Vim},
+	{Name: "cscopetag", Short: "cst", Type: Bool, Editors: Vim},
+	{Name: "cscopetagorder", Short: "csto", Type: Number, Editors: Vim},
+	{Name: "cscopeverbose", Short: "csverb", Type: Bool, Editors: Vim},
+	{Name: "cursorbind", Short: "crb", Type: Bool, Editors: Both},
+	{Name: "cursorcolumn", Short: "cuc", Type: Bool, Editors: Both},
+	{Name: "cursorline", Short: "cul", Type: Bool, Editors: Both},
+	{Name: "cursorlineopt", Short: "culopt", Type: String, Editors: Both},
+	{Name: "debug", Type: String, Editors: Both},
+	{Name: "define", Short: "def", Type: String, Editors: Both},
+	{Name: "delcombine", Short: "deco", Type: Bool, Editors: Both},
+	{Name: "dictionary", Short: "dict", Type: String, Editors: Both},
+	{Name: "diff", Type: Bool, Editors: Both},
+	{Name: "diffexpr", Short: "dex", Type: String, Editors: Both},
+	{Name: "diffopt", Short: "dip", Type: String, Editors: Both},
+	{Name: "digraph", Short: "dg", Type: Bool, Editors: Both},
+	{Name: "directory", Short: "dir", Type: String, Editors: Both},
+	{Name: "display", Short: "dy", Type: String, Editors: Both},
+	{Name: "eadirection", Short: "ead", Type: String, Editors: Both},
+	{Name: "edcompatible", Short: "ed", Type: Bool, Editors: Vim},
+	{Name: "emoji", Short: "emo", Type: Bool, Editors: Both},
+	{Name: "encoding", Short: "enc", Type: String, Editors: Both},
+	{Name: "endoffile", Short: "eof", Type: Bool, Editors: Both},
+	{Name: "endofline", Short: "eol", Type: Bool, Editors: Both},
+	{Name: "equalalways", Short: "ea", Type: Bool, Editors: Both},
+	{Name: "equalprg", Short: "ep", Type: String, Editors: Both},
+	{Name: "errorbells", Short: "eb", Type: Bool, Editors: Both},
+	{Name: "errorfile", Short: "ef", Type: String, Editors: Both},
+	{Name: "errorformat", Short: "efm", Type: String, Editors: Both},
+	{Name: "esckeys", Short: "ek", Type: Bool, Editors: Vim},
+	{Name: "eventignore", Short: "ei", Type: String, Editors: Both},
+	{Name: "expandtab", Short: "et", Type: Bool, Editors: Both},
+	{Name: "exrc", Short: "ex", Type: Bool, Editors: Both},
+	{Name: "fileencoding", Short: "fenc", Type: String, Editors: Both},
+	{Name: "fileencodings", Short: "fencs", Type: String, Editors: Both},
+	{Name: "fileformat", Short: "ff", Type: String, Editors: Both},
+	{Name: "fileformats", Short: "ffs", Type: String, Editors: Both},
+	{Name: "fileignorecase", Short: "fic", Type: Bool, Editors: Both},
+	{Name: "filetype", Short: "ft", Type: String, Editors: Both},
+	{Name: "fillchars", Short: "fcs", Type: String, Editors: Both},
+	{Name: "fixendofline", Short: "fixeol", Type: Bool, Editors: Both},
+	{Name: "foldclose", Short: "fcl", Type: String, Editors: Both},
+	{Name: "foldcolumn", Short: "fdc", Type: Number, Editors: Both},
+	{Name: "foldenable", Short: "fen", Type: Bool, Editors: Both},
+	{Name: "foldexpr", Short: "fde", Type: String, Editors: Both},
+	{Name: "foldignore", Short: "fdi", Type: String, Editors: Both},
+	{Name: "foldlevel", Short: "fdl", Type: Number, Editors: Both},
+	{Name: "foldlevelstart", Short: "fdls", Type: Number, Editors: Both},
+	{Name: "foldmarker", Short: "fmr", Type: String, Editors: Both},
+	{Name: "foldmethod", Short: "fdm", Type: String, Editors: Both},
+	{Name: "foldminlines", Short: "fml", Type: Number, Editors: Both},
+	{Name: "foldnestmax", Short: "fdn", Type: Number, Editors: Both},
+	{Name: "foldopen", Short: "fdo", Type: String, Editors: Both},
+	{Name: "foldtext", Short: "fdt", Type: String, Editors: Both},
+	{Name: "formatexpr", Short: "fex", Type: String, Editors: Both},
+	{Name: "formatlistpat", Short: "flp", Type: String, Editors: Both},
+	{Name: "formatoptions", Short: "fo", Type: String, Editors: Both},
+	{Name: "formatprg", Short: "fp", Type: String, Editors: Both},
+	{Name: "fsync", Short: "fs", Type: Bool, Editors: Both},
+	{Name: "gdefault", Short: "gd", Type: Bool, Editors: Both},
+	{Name: "grepformat", Short: "gfm", Type: String, Editors: Both},
+	{Name: "grepprg", Short: "gp", Type: String, Editors: Both},
+	{Name: "guicursor", Short: "gcr", Type: String, Editors: Both},
+	{Name: "guifont", Short: "gfn", Type: String, Editors: Both},
+	{Name: "guifontset", Short: "gfs", Type: String, Editors: Vim},
+	{Name: "guifontwide", Short: "gfw", Type: String, Editors: Both},
+	{Name: "guiheadroom", Short: "ghr", Type: Number, Editors: Vim},
+	{Name: "guiligatures", Short: "gli", Type: String, Editors: Vim},
+	{Name: "guioptions", Short: "go", Type: String, Editors: Vim},
+	{Name: "guipty", Type: Bool, Editors: Vim},
+	{Name: "guitablabel", Short: "gtl", Type: String, Editors: Vim},
+	{Name: "guitabtooltip", Short: "gtt", Type: String, Editors: Vim},
+	{Name: "helpfile", Short: "hf", Type: String, Editors: Both},
+	{Name: "helpheight", Short: "hh", Type: Number, Editors: Both},
+	{Name: "helplang", Short: "hlg", Type: String, Editors: Both},
+	{Name: "hidden", Short: "hid", Type: Bool, Editors: Both},
+	{Name: "highlight", Short: "hl", Type: String, Editors: Vim},
+	{Name: "history", Short: "hi", Type: Number, Editors: Both},
+	{Name: "hkmap", Short: "hk", Type: Bool, Editors: Vim},
+	{Name: "hkmapp", Short: "hkp", Type: Bool, Editors: Vim},
+	{Name: "hlsearch", Short: "hls", Type: Bool, Editors: Both},
+	{Name: "icon", Type: Bool, Editors: Both},
+	{Name: "iconstring", Type: String, Editors: Both},
+	{Name: "ignorecase", Short: "ic", Type: Bool, Editors: Both},
+	{Name: "imactivatefunc", Short: "imaf", Type: String, Editors: Vim},
+	{Name: "imactivatekey", Short: "imak", Type: String, Editors: Vim},
+	{Name: "imcmdline", Short: "imc", Type: Bool, Editors: Both},
+	{Name: "imdisable", Short: "imd", Type: Bool, Editors: Vim},
+	{Name: "iminsert", Short: "imi", Type: Number, Editors: Both},
+	{Name: "imsearch", Short: "ims", Type: Number, Editors: Both},
+	{Name: "imstatusfunc", Short: "imsf", Type: String, Editors: Vim},
+	{Name: "imstyle", Short: "imst", Type: Number, Editors: Vim},
+	{Name: "inccommand", Short: "icm", Type: String, Editors: Neovim},
+	{Name: "include", Short: "inc", Type: String, Editors: Both},
+	{Name: "includeexpr", Short: "inex", Type: String, Editors: Both},
+	{Name: "incsearch", Short: "is", Type: Bool, Editors: Both},
+	{Name: "indentexpr", Short: "inde", Type: String, Editors: Both},
+	{Name: "indentkeys", Short: "indk", Type: String, Editors: Both},
+	{Name: "infercase", Short: "inf", Type: Bool, Editors: Both},
+	{Name: "insertmode", Short: "im", Type: Bool, Editors: Vim},
+	{Name: "isfname", Short: "isf", Type: String, Editors: Both},
+	{Name: "isident", Short: "isi", Type: String, Editors: Both},
+	{Name: "iskeyword", Short: "isk", Type: String, Editors: Both},
+	{Name: "isprint", Short: "isp", Type: String, Editors: Both},
+	{Name: "joinspaces", Short: "js", Type: Bool, Editors: Both},
+	{Name: "jumpoptions", Short: "jop", Type: String, Editors: Both},
+	{Name: "key", Type: String, Editors: Vim},
+	{Name: "keymap", Short: "kmp", Type: String, Editors: Both},
+	{Name: "keymodel", Short: "km", Type: String, Editors: Both},
+	{Name: "keyprotocol", Short: "kpc", Type: String, Editors: Vim},
+	{Name: "keywordprg", Short: "kp", Type: String, Editors: Both},
+	{Name: "langmap", Short: "lmap", Type: String, Editors: Both},
+	{Name: "langmenu", Short: "lm", Type: String, Editors: Both},
+	{Name: "langnoremap", Short: "lnr", Type: Bool, Editors: Both},
+	{Name: "langremap", Short: "lrm", Type: Bool, Editors: Both},
+	{Name: "laststatus", Short: "ls", Type: Number, Editors: Both},
+	{Name: "lazyredraw", Short: "lz", Type: Bool, Editors: Both},
+	{Name: "linebreak", Short: "lbr", Type: Bool, Editors: Both},
+	{Name: "lines", Type: Number, Editors: Both},
+	{Name: "linespace", Short: "lsp", Type: Number, Editors: Both},
+	{Name: "lisp", Type: Bool, Editors: Both},
+	{Name: "lispoptions", Short: "lop", Type: String, Editors: Both},
+	{Name: "lispwords", Short: "lw", Type: String, Editors: Both},
+	{Name: "list", Type: Bool, Editors: Both},
+	{Name: "listchars", Short: "lcs", Type: String, Editors: Both},
+	{Name: "loadplugins", Short: "lpl", Type: Bool, Editors: Both},
+	{Name: "luadll", Type: String, Editors: Vim},
+	{Name: "macatsui", Type: Bool, Editors: Vim},
+	{Name: "magic", Type: Bool, Editors: Both},
+	{Name: "makeef", Short: "mef", Type: String, Editors: Both},
+	{Name: "makeencoding", Short: "menc", Type: String, Editors: Both},
+	{Name: "makeprg", Short: "mp", Type: String, Editors: Both},
+	{Name: "matchpairs", Short: "mps", Type: String, Editors: Both},
+	{Name: "matchtime", Short: "mat", Type: Number, Editors: Both},
+	{Name: "maxcombine", Short: "mco", Type: Number, Editors: Vim},
+	{Name: "maxfuncdepth", Short: "mfd", Type: Number, Editors: Both},
+	{Name: "maxmapdepth", Short: "mmd", Type: Number, Editors: Both},
+	{Name: "maxmem", Short: "mm", Type: Number, Editors: Vim},
+	{Name: "maxmempattern", Short: "mmp", Type: Number, Editors: Both},
+	{Name: "maxmemtot", Short: "mmt", Type: Number, Editors: Vim},
+	{Name: "menuitems", Short: "mis", Type: Number, Editors: Both},
+	{Name: "mkspellmem", Short: "msm", Type: String, Editors: Both},
+	{Name: "modeline", Short: "ml", Type: Bool, Editors: Both},
+	{Name: "modelineexpr", Short: "mle", Type: Bool, Editors: Both},
+	{Name: "modelines", Short: "mls", Type: Number, Editors: Both},
+	{Name: "modifiable", Short: "ma", Type: Bool, Editors: Both},
+	{Name: "modified", Short: "mod", Type: Bool, Editors: Both},
+	{Name: "more", Type: Bool, Editors: Both},
+	{Name: "mouse", Type: String, Editors: Both},
+	{Name: "mousefocus", Short: "mousef", Type: Bool, Editors: Both},
+	{Name: "mousehide", Short: "mh", Type: Bool, Editors: Vim},
+	{Name: "mousemodel", Short: "mousem", Type: String, Editors: Both},
+	{Name: "mousemoveevent", Short: "mousemev", Type: Bool, Editors: Both},
+	{Name: "mousescroll", Type: String, Editors: Neovim},
+	{Name: "mouseshape", Short: "mouses", Type: String, Editors: Vim},
+	{Name: "mousetime", Short: "mouset", Type: Number, Editors: Both},
+	{Name: "mzquantum", Short: "mzq", Type: Number, Editors: Vim},
+	{Name: "mzschemedll", Type: String, Editors: Vim},
+	{Name: "mzschemegcdll", Type: String, Editors: Vim},
+	{Name: "nrformats", Short: "nf", Type: String, Editors: Both},
+	{Name: "number", Short: "nu", Type: Bool, Editors: Both},
+	{Name: "numberwidth", Short: "nuw", Type: Number, Editors: Both},
+	{Name: "omnifunc", Short: "ofu", Type: String, Editors: Both},
+	{Name: "opendevice", Short: "odev", Type: Bool, Editors: Vim},
+	{Name: "operatorfunc", Short: "opfunc", Type: String, Editors: Both},
+	{Name: "packpath", Short: "pp", Type: String, Editors: Both},
+	{Name: "paragraphs", Short: "para", Type: String, Editors: Both},
+	{Name: "paste", Type: Bool, Editors: Both},
+	{Name: "pastetoggle", Short: "pt", Type: String, Editors: Vim},
+	{Name: "patchexpr", Short: "pex", Type: String, Editors: Both},
+	{Name: "patchmode", Short: "pm", Type: String, Editors: Both},
+	{Name: "path", Short: "pa", Type: String, Editors: Both},
+	{Name: "perldll", Type: String, Editors: Vim},
+	{Name: "preserveindent", Short: "pi", Type: Bool, Editors: Both},
+	{Name: "previewheight", Short: "pvh", Type: Number, Editors: Both},
+	{Name: "previewpopup", Short: "pvp", Type: String, Editors: Vim},
+	{Name: "previewwindow", Short: "pvw", Type: Bool, Editors: Both},
+	{Name: "printdevice", Short: "pdev", Type: String, Editors: Vim},
+	{Name: "printencoding", Short: "penc", Type: String, Editors: Vim},
+	{Name: "printexpr", Short: "pexpr", Type: String, Editors: Vim},
+	{Name: "printfont", Short: "pfn", Type: String, Editors: Vim},
+	{Name: "printheader", Short: "pheader", Type: String, Editors: Vim},
+	{Name: "printmbcharset", Short: "pmbcs", Type: String, Editors: Vim},
+	{Name: "printmbfont", Short: "pmbfn", Type: String, Editors: Vim},
+	{Name: "printoptions", Short: "popt", Type: String, Editors: Vim},
+	{Name: "prompt", Type: Bool, Editors: Vim},
+	{Name: "pumblend", Short: "pb", Type: Number, Editors: Neovim},
+	{Name: "pumheight", Short: "ph", Type: Number, Editors: Both},
+	{Name: "pumwidth", Short: "pw", Type: Number, Editors: Both},
+	{Name: "pythondll", Type: String, Editors: Vim},
+	{Name: "pythonhome", Type: String, Editors: Vim},
+	{Name: "pythonthreedll", Type: String, Editors: Vim},
+	{Name: "pythonthreehome", Type: String, Editors: Vim},
+	{Name: "pyxversion", Short: "pyx", Type: Number, Editors: Both},
+	{Name: "quickfixtextfunc", Short: "qftf", Type: String, Editors: Both},
+	{Name: "quoteescape", Short: "qe", Type: String, Editors: Both},
+	{Name: "readonly", Short: "ro", Type: Bool, Editors: Both},
+	{Name: "redrawdebug", Short: "rdb", Type: String, Editors: Neovim},
+	{Name: "redrawtime", Short: "rdt", Type: Number, Editors: Both},
+	{Name: "regexpengine", Short: "re", Type: Number, Editors: Both},
+	{Name: "relativenumber", Short: "rnu", Type: Bool, Editors: Both},
+	{Name: "remap", Type: Bool, Editors: Vim},
+	{Name: "renderoptions", Short: "rop", Type: String, Editors: Vim},
+	{Name: "report", Type: Number, Editors: Both},
+	{Name: "restorescreen", Short: "rs", Type: Bool, Editors: Vim},
+	{Name: "revins", Short: "ri", Type: Bool, Editors: Both},
+	{Name: "rightleft", Short: "rl", Type: Bool, Editors: Both},
+	{Name: "rightleftcmd", Short: "rlc", Type: String, Editors: Both},
+	{Name: "rubydll", Type: String, Editors: Vim},
+	{Name: "ruler", Short: "ru", Type: Bool, Editors: Both},
+	{Name: "rulerformat", Short: "ruf", Type: String, Editors: Both},
+	{Name: "runtimepath", Short: "rtp", Type: String, Editors: Both},
+	{Name: "scroll", Short: "scr", Type: Number, Editors: Both},
+	{Name: "scrollback", Short: "scbk", Type: Number, Editors: Neovim},
+	{Name: "scrollbind", Short: "scb", Type: Bool, Editors: Both},
+	{Name: "scrollfocus", Short: "scf", Type: Bool, Editors: Vim},
+	{Name: "scrolljump", Short: "sj", Type: Number, Editors: Both},
+	{Name: "scrolloff", Short: "so", Type: Number, Editors: Both},
+	{Name: "scrollopt", Short: "sbo", Type: String, Editors: Both},
+	{Name: "sections", Short: "sect", Type: String, Editors: Both},
+	{Name: "secure", Type: Bool, Editors: Both},
+	{Name: "selection", Short: "sel", Type: String, Editors: Both},
+	{Name: "selectmode", Short: "slm", Type: String, Editors: Both},
+	{Name: "sessionoptions", Short: "ssop", Type: String, Editors: Both},
+	{Name: "shada", Short: "sd", Type: String, Editors: Neovim},
+	{Name: "shadafile", Short: "sdf", Type: String, Editors: Neovim},
+	{Name: "shell", Short: "sh", Type: String, Editors: Both},
+	{Name: "shellcmdflag", Short: "shcf", Type: String, Editors: Both},
+	{Name: "shellpipe", Short: "sp", Type: String, Editors: Both},
+	{Name: "shellquote", Short: "shq", Type: String, Editors: Both},
+	{Name: "shellredir", Short: "srr", Type: String, Editors: Both},
+	{Name: "shellslash", Short: "ssl", Type: Bool, Editors: Both},
+	{Name: "shelltemp", Short: "stmp", Type: Bool, Editors: Both},
+	{Name: "shelltype", Short: "st", Type: Number, Editors: Vim},
+	{Name: "shellxescape", Short: "sxe", Type: String, Editors: Both},
+	{Name: "shellxquote", Short: "sxq", Type: String, Editors: Both},
+	{Name: "shiftround", Short: "sr", Type: Bool, Editors: Both},
+	{Name: "shiftwidth", Short: "sw", Type: Number, Editors: Both},
+	{Name: "shortmess", Short: "shm", Type: String, Editors: Both},
+	{Name: "shortname", Short: "sn", Type: Bool, Editors: Vim},
+	{Name: "showbreak", Short: "sbr", Type: String, Editors: Both},
+	{Name: "showcmd", Short: "sc", Type: Bool, Editors: Both},
+	{Name: "showcmdloc", Short: "sloc", Type: String, Editors: Both},
+	{Name: "showfulltag", Short: "sft", Type: Bool, Editors: Both},
+	{Name: "showmatch", Short: "sm", Type: Bool, Editors: Both},
+	{Name: "showmode", Short: "smd", Type: Bool, Editors: Both},
+	{Name: "showtabline", Short: "stal", Type: Number, Editors: Both},
+	{Name: "sidescroll", Short: "ss", Type: Number, Editors: Both},
+	{Name: "sidescrolloff", Short: "siso", Type: Number, Editors: Both},
+	{Name: "signcolumn", Short: "scl", Type: String, Editors: Both},
+	{Name: "smartcase", Short: "scs", Type: Bool, Editors: Both},
+	{Name: "smartindent", Short: "si", Type: Bool, Editors: Both},
+	{Name: "smarttab", Short: "sta", Type: Bool, Editors: Both},
+	{Name: "smoothscroll", Short: "sms", Type: Bool, Editors: Both},
+	{Name: "softtabstop", Short: "sts", Type: Number, Editors: Both},
+	{Name: "spell", Type: Bool, Editors: Both},
+	{Name: "spellcapcheck", Short: "spc", Type: String, Editors: Both},
+	{Name: "spellfile", Short: "spf", Type: String, Editors: Both},
+	{Name: "spelllang", Short: "spl", Type: String, Editors: Both},
+	{Name: "spelloptions", Short: "spo", Type: String, Editors: Both},
+	{Name: "spellsuggest", Short: "sps", Type: String, Editors: Both},
+	{Name: "splitbelow", Short: "sb", Type: Bool, Editors: Both},
+	{Name: "splitkeep", Short: "spk", Type: String, Editors: Both},
+	{Name: "splitright", Short: "spr", Type: Bool, Editors: Both},
+	{Name: "startofline", Short: "sol", Type: Bool, Editors: Both},
+	{Name: "statuscolumn", Short: "stc", Type: String, Editors: Neovim},
+	{Name: "statusline", Short: "stl", Type: String, Editors: Both},
+	{Name: "suffixes", Short: "su", Type: String, Editors: Both},
+	{Name: "suffixesadd", Short: "sua", Type: String, Editors: Both},
+	{Name: "swapfile", Short: "swf", Type: Bool, Editors: Both},
+	{Name: "swapsync", Short: "sws", Type: String, Editors: Vim},
+	{Name: "switchbuf", Short: "swb", Type: String, Editors: Both},
+	{Name: "synmaxcol", Short: "smc", Type: Number, Editors: Both},
+	{Name: "syntax", Short: "syn", Type: String, Editors: Both},
+	{Name: "tabline", Short: "tal", Type: String, Editors: Both},
+	{Name: "tabpagemax", Short: "tpm", Type: Number, Editors: Both},
+	{Name: "tabstop", Short: "ts", Type: Number, Editors: Both},
+	{Name: "tagbsearch", Short: "tbs", Type: Bool, Editors: Both},
+	{Name: "tagcase", Short: "tc", Type: String, Editors: Both},
+	{Name: "tagfunc", Short: "tfu", Type: String, Editors: Both},
+	{Name: "taglength", Short: "tl", Type: Number, Editors: Both},
+	{Name: "tagrelative", Short: "tr", Type: Bool, Editors: Both},
+	{Name: "tags", Short: "tag", Type: String, Editors: Both},
+	{Name: "tagstack", Short: "tgst", Type: Bool, Editors: Both},
+	{Name: "tcldll", Type: String, Editors: Vim},
+	{Name: "term", Type: String, Editors: Vim},
+	{Name: "termbidi", Short: "tbidi", Type: Bool, Editors: Both},
+	{Name: "termencoding", Short: "tenc", Type: String, Editors: Vim},
+	{Name: "termguicolors", Short: "tgc", Type: Bool, Editors: Both},
+	{Name: "termpastefilter", Short: "tpf", Type: String, Editors: Neovim},
+	{Name: "termsync", Type: Bool, Editors: Neovim},
+	{Name: "termwinkey", Short: "twk", Type: String, Editors: Vim},
+	{Name: "termwinscroll", Short: "twsl", Type: Number, Editors: Vim},
+	{Name: "termwinsize", Short: "tws", Type: String, Editors: Vim},
+	{Name: "termwintype", Short: "twt", Type: String, Editors: Vim},
+	{Name: "terse", Type: Bool, Editors: Vim},
+	{Name: "textauto", Short: "ta", Type: Bool, Editors: Vim, Deprecated: "fileformats"},
+	{Name: "textmode", Short: "tx", Type: Bool, Editors: Vim, Deprecated: "fileformat"},
+	{Name: "textwidth", Short: "tw", Type: Number, Editors: Both},
+	{Name: "thesaurus", Short: "tsr", Type: String, Editors: Both},
+	{Name: "thesaurusfunc", Short: "tsrfu", Type: String, Editors: Both},
+	{Name: "tildeop", Short: "top", Type: Bool, Editors: Both},
+	{Name: "timeout", Short: "to", Type: Bool, Editors: Both},
+	{Name: "timeoutlen", Short: "tm", Type: Number, Editors: Both},
+	{Name: "title", Type: Bool, Editors: Both},
+	{Name: "titlelen", Type: Number, Editors: Both},
+	{Name: "titleold", Type: String, Editors: Both},
+	{Name: "titlestring", Type: String, Editors: Both},
+	{Name: "toolbar", Short: "tb", Type: String, Editors: Vim},
+	{Name: "toolbariconsize", Short: "tbis", Type: String, Editors: Vim},
+	{Name: "ttimeout", Type: Bool, Editors: Both},
+	{Name: "ttimeoutlen", Short: "ttm", Type: Number, Editors: Both},
+	{Name: "ttybuiltin", Short: "tbi", Type: Bool, Editors: Vim},
+	{Name: "ttyfast", Short: "tf", Type: Bool, Editors: Vim},
+	{Name: "ttymouse", Short: "ttym", Type: String, Editors: Vim},
+	{Name: "ttyscroll", Short: "tsl", Type: Number, Editors: Vim},
+	{Name: "ttytype", Short: "tty", Type: String, Editors: Vim},
+	{Name: "undodir", Short: "udir", Type: String, Editors: Both},
+	{Name: "undofile", Short: "udf", Type: Bool, Editors: Both},
+	{Name: "undolevels", Short: "ul", Type: Number, Editors: Both},
+	{Name: "undoreload", Short: "ur", Type: Number, Editors: Both},
+	{Name: "updatecount", Short: "uc", Type: Number, Editors: Both},
+	{Name: "updatetime", Short: "ut", Type: Number, Editors: Both},
+	{Name: "varsofttabstop", Short: "vsts", Type: String, Editors: Both},
+	{Name: "vartabstop", Short: "vts", Type: String, Editors: Both},
+	{Name: "verbose", Short: "vbs", Type: Number, Editors: Both},
+	{Name: "verbosefile", Short: "vfile", Type: String, Editors: Both},
+	{Name: "viewdir", Short: "vdir", Type: String, Editors: Both},
+	{Name: "viewoptions", Short: "vop", Type: String, Editors: Both},
+	{Name: "viminfo", Short: "vi", Type: String, Editors: Vim},
+	{Name: "viminfofile", Short: "vif", Type: String, Editors: Vim},
+	{Name: "virtualedit", Short: "ve", Type: String, Editors: Both},
+	{Name: "visualbell", Short: "vb", Type: Bool, Editors: Both},
+	{Name: "warn", Type: Bool, Editors: Both},
+	{Name: "weirdinvert", Short: "wiv", Type: Bool, Editors: Vim},
+	{Name: "whichwrap", Short: "ww", Type: String, Editors: Both},
+	{Name: "wildchar", Short: "wc", Type: Number, Editors: Both},
+	{Name: "wildcharm", Short: "wcm", Type: Number, Editors: Both},
+	{Name: "wildignore", Short: "wig", Type: String, Editors: Both},
+	{Name: "wildignorecase", Short: "wic", Type: Bool, Editors: Both},
+	{Name: "wildmenu", Short: "wmnu", Type: Bool, Editors: Both},
+	{Name: "wildmode", Short: "wim", Type: String, Editors: Both},
+	{Name: "wildoptions", Short: "wop", Type: String, Editors: Both},
+	{Name: "winaltkeys", Short: "wak", Type: String, Editors: Both},
+	{Name: "winbar", Short: "wbr", Type: String, Editors: Neovim},
+	{Name: "winblend", Short: "winbl", Type: Number, Editors: Neovim},
+	{Name: "wincolor", Short: "wcr", Type: String, Editors: Vim},
+	{Name: "window", Short: "wi", Type: Number, Editors: Both},
+	{Name: "winfixbuf", Short: "wfb", Type: Bool, Editors: Both},
+	{Name: "winfixheight", Short: "wfh", Type: Bool, Editors: Both},
+	{Name: "winfixwidth", Short: "wfw", Type: Bool, Editors: Both},
+	{Name: "winheight", Short: "wh", Type: Number, Editors: Both},
+	{Name: "winhighlight", Short: "winhl", Type: String, Editors: Neovim},
+	{Name: "winminheight", Short: "wmh", Type: Number, Editors: Both},
+	{Name: "winminwidth", Short: "wmw", Type: Number, Editors: Both},
+	{Name: "winptydll", Type: String, Editors: Vim},
+	{Name: "winwidth", Short: "wiw", Type: Number, Editors: Both},
+	{Name: "wrap", Type: Bool, Editors: Both},
+	{Name: "wrapmargin", Short: "wm", Type: Number, Editors: Both},
+	{Name: "wrapscan", Short: "ws", Type: Bool, Editors: Both},
+	{Name: "write", Type: Bool, Editors: Both},
+	{Name: "writeany", Short: "wa", Type: Bool, Editors: Both},
+	{Name: "writebackup", Short: "wb", Type: Bool, Editors: Both},
+	{Name: "writedelay", Short: "wd", Type: Number, Editors: Both},
+	{Name: "xtermcodes", Type: Bool, Editors: Vim},
+}
@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	names := map[string]bool{}
+	for _, o := range options {
+		for _, name := range []string{o.Name, o.Short} {
+			if names[name] {
+				t.Errorf("duplicated name %q", name)
+			}
+			if name != "" {
+				names[name] = true
+			}
+		}
+		if o.Deprecated != "" && LookupOption(o.Deprecated) == nil {
+			t.Errorf("%s is deprecated for unknown option %q", o.Name, o.Deprecated)
+		}
+	}
+	if !sort.SliceIsSorted(options, func(i, j int) bool { return options[i].Name < options[j].Name }) {
+		t.Error("options are not sorted")
+	}
+}
+
+func TestLookupOption(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"tabstop", "tabstop"},
+		{"ts", "tabstop"},
+		{"t_Co", "t_Co"},
+		{"<t_F1>", "<t_F1>"},
+		{"tabstp", ""},
+		{"t_", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		if o := LookupOption(tt.name); o != nil {
+			got = o.Name
+		}
+		if got != tt.want {
+			t.Errorf("LookupOption(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,440 @@
+// Package callgraph builds call graphs of Vim script files.
+//
+// A node of the graph is a user-defined function or the top level of a file,
+// which runs when the file is sourced. An edge is a reference from the
+// caller to the callee: a call, a method call, a funcref by function() or
+// funcref(), or a reference in a mapping, a user command or an autocmd
+// defined by the caller. Calls of builtin functions are not in the graph.
+//
+// The graph is built statically. Functions called through variables and
+// names built at runtime, e.g. by :execute and curly braces names with
+// expressions, are not resolved.
+package callgraph
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/scope"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// Kind represents the kind of nodes.
+type Kind int
+
+const (
+	Script   Kind = iota // top level of a file
+	Function             // function defined in the files
+	External             // function which is not defined in the files
+)
+
+var kindNames = [...]string{
+	Script:   "script",
+	Function: "function",
+	External: "external",
+}
+
+func (k Kind) String() string {
+	if 0 <= k && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// EdgeKind represents how the caller refers to the callee.
+type EdgeKind int
+
+const (
+	Call    EdgeKind = iota // F() and :call F()
+	Method                  // x->F()
+	Funcref                 // function('F') and funcref('F')
+	Mapping                 // reference in a mapping
+	Command                 // reference in a user command
+	Autocmd                 // reference in an autocmd
+)
+
+var edgeKindNames = [...]string{
+	Call:    "call",
+	Method:  "method",
+	Funcref: "funcref",
+	Mapping: "mapping",
+	Command: "command",
+	Autocmd: "autocmd",
+}
+
+func (k EdgeKind) String() string {
+	if 0 <= k && int(k) < len(edgeKindNames) {
+		return edgeKindNames[k]
+	}
+	return fmt.Sprintf("EdgeKind(%d)", int(k))
+}
+
+// Deferred reports whether the callee runs later than the caller, i.e. when
+// the mapping, the user command or the autocmd is used.
+func (k EdgeKind) Deferred() bool {
+	return k == Mapping || k == Command || k == Autocmd
+}
+
+// Node is a function or the top level of a file.
+type Node struct {
+	// ID is the unique name of the node: the filename for Script, the
+	// filename and the name for script-local functions, e.g.
+	// "plugin/foo.vim:s:F", and the name for the other functions.
+	ID   string
+	Kind Kind
+
+	// Name is the normalized function name, e.g. "s:F" for `<SID>F` and
+	// "F" for `g:F`, or the filename for Script.
+	Name string
+	File string   // file which defines the function; or empty for External
+	Def  ast.Node // *ast.Function, *ast.Def or *ast.File; or nil for External
+
+	In  []*Edge // edges from callers in the order of files
+	Out []*Edge // edges to callees in the order of references
+}
+
+// Pos returns the position of the definition; or zero Pos for External.
+func (n *Node) Pos() ast.Pos {
+	if n.Def == nil {
+		return ast.Pos{}
+	}
+	switch d := n.Def.(type) {
+	case *ast.Function:
+		return d.Name.Pos()
+	case *ast.Def:
+		return d.Name.Pos()
+	}
+	return n.Def.Pos()
+}
+
+// Edge is a reference from Caller to Callee.
+type Edge struct {
+	Caller *Node
+	Callee *Node
+	Pos    ast.Pos // position of the reference
+	Kind   EdgeKind
+}
+
+// Graph is a call graph.
+type Graph struct {
+	Nodes []*Node // Script and Function nodes in the order of files, then External nodes
+
+	ids map[string]*Node
+}
+
+// Lookup returns the node of the ID; or nil.
+func (g *Graph) Lookup(id string) *Node {
+	return g.ids[id]
+}
+
+// Build builds the call graph of the files. A function defined more than
+// once, e.g. in both branches of :if, is a node whose Def is the first
+// definition.
+func Build(files []*ast.File) *Graph {
+	g := &Graph{ids: make(map[string]*Node)}
+	var externals []*Node
+	scripts := make([]*script, len(files))
+	for i, f := range files {
+		s := &script{graph: g, file: f, name: f.Start.Filename, funcs: make(map[string]*Node)}
+		s.node = g.add(&Node{ID: s.name, Kind: Script, Name: s.name, File: s.name, Def: f})
+		s.define()
+		scripts[i] = s
+	}
+	for _, s := range scripts {
+		s.external = func(name string) *Node {
+			n := &Node{ID: name, Kind: External, Name: name}
+			g.ids[name] = n
+			externals = append(externals, n)
+			return n
+		}
+		if !s.file.Vim9 {
+			s.info = scope.Resolve(s.file)
+		}
+		ast.Walk(&visitor{s: s, caller: s.node}, s.file)
+	}
+	g.Nodes = append(g.Nodes, externals...)
+	return g
+}
+
+// add adds n unless a node of the same ID exists. It returns the node of
+// the ID.
+func (g *Graph) add(n *Node) *Node {
+	if m := g.ids[n.ID]; m != nil {
+		return m
+	}
+	g.ids[n.ID] = n
+	g.Nodes = append(g.Nodes, n)
+	return n
+}
+
+// Unreachable returns functions which are not reachable from the top level
+// of any file in the order of Nodes. Autoload functions which are called
+// only from other plugins are unreachable.
+func (g *Graph) Unreachable() []*Node {
+	reached := make(map[*Node]bool)
+	var visit func(n *Node)
+	visit = func(n *Node) {
+		if reached[n] {
+			return
+		}
+		reached[n] = true
+		for _, e := range n.Out {
+			visit(e.Callee)
+		}
+	}
+	for _, n := range g.Nodes {
+		if n.Kind == Script {
+			visit(n)
+		}
+	}
+	var nodes []*Node
+	for _, n := range g.Nodes {
+		if n.Kind == Function && !reached[n] {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// script is the state of building the graph for a file.
+type script struct {
+	graph    *Graph
+	file     *ast.File
+	name     string
+	node     *Node
+	funcs    map[string]*Node // script-local functions by name
+	info     *scope.Info      // or nil for Vim9 script
+	external func(name string) *Node
+}
+
+// define adds the functions defined in the file to the graph.
+func (s *script) define() {
+	ast.Inspect(s.file, func(n ast.Node) bool {
+		var x ast.Expr
+		switch n := n.(type) {
+		case *ast.Function:
+			x = n.Name
+		case *ast.Def:
+			x = n.Name
+		case *ast.Class:
+			return false // methods are called via objects
+		default:
+			return true
+		}
+		name, ok := funcName(x)
+		if !ok {
+			return true
+		}
+		name = s.normalize(name)
+		if s.vim9Local(name) {
+			name = "s:" + name
+		}
+		node := &Node{ID: name, Kind: Function, Name: name, File: s.name, Def: n}
+		if strings.HasPrefix(name, "s:") {
+			node.ID = s.name + ":" + name
+			if s.funcs[name] == nil {
+				s.funcs[name] = node
+			}
+		}
+		s.graph.add(node)
+		return true
+	})
+}
+
+// vim9Local reports whether the normalized name without scope defines a
+// script-local function in Vim9 script.
+func (s *script) vim9Local(name string) bool {
+	return s.file.Vim9 && !strings.ContainsAny(name, ":#")
+}
+
+// normalize returns the function name as Node.Name.
+func (s *script) normalize(name string) string {
+	if len(name) > 5 && strings.EqualFold(name[:5], "<SID>") {
+		return "s:" + name[5:]
+	}
+	return strings.TrimPrefix(name, "g:")
+}
+
+// lookup returns the node of the called function name; or nil for builtin
+// functions. If defined is true, it returns nil for functions which are not
+// defined in the files.
+func (s *script) lookup(name string, defined bool) *Node {
+	name = s.normalize(name)
+	if name == "" || isBuiltinFunc(name) {
+		return nil
+	}
+	if strings.HasPrefix(name, "s:") {
+		return s.funcs[name]
+	}
+	if s.vim9Local(name) {
+		if n := s.funcs["s:"+name]; n != nil {
+			return n
+		}
+	}
+	if strings.ContainsAny(name, ":.") {
+		return nil // dictionary functions and funcref variables
+	}
+	if n := s.graph.ids[name]; n != nil || defined {
+		return n
+	}
+	return s.external(name)
+}
+
+type visitor struct {
+	s      *script
+	caller *Node
+	kind   EdgeKind // kind of edges if Deferred; otherwise the default Call
+}
+
+func (v *visitor) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.Function, *ast.Def:
+		var x ast.Expr
+		if f, ok := n.(*ast.Function); ok {
+			x = f.Name
+		} else {
+			x = n.(*ast.Def).Name
+		}
+		if name, ok := funcName(x); ok {
+			name = v.s.normalize(name)
+			if v.s.vim9Local(name) {
+				name = "s:" + name
+			}
+			id := name
+			if strings.HasPrefix(name, "s:") {
+				id = v.s.name + ":" + name
+			}
+			if node := v.s.graph.ids[id]; node != nil && node.Kind == Function {
+				return &visitor{s: v.s, caller: node}
+			}
+		}
+		// dictionary functions are in their callers.
+		return v
+
+	case *ast.Map:
+		w := &visitor{s: v.s, caller: v.caller, kind: Mapping}
+		if n.RhsExpr == nil && n.RhsCmds == nil {
+			w.text(n.Pos(), n.Text)
+		}
+		return w
+
+	case *ast.UserCommand:
+		w := &visitor{s: v.s, caller: v.caller, kind: Command}
+		if n.Body == nil {
+			w.text(n.Pos(), n.Text)
+		}
+		if fn := n.Attr.CompleteFunc; fn != "" {
+			w.ref(n.Pos(), fn, Command)
+		}
+		return w
+
+	case *ast.Autocmd:
+		return &visitor{s: v.s, caller: v.caller, kind: Autocmd}
+
+	case *ast.Excmd:
+		v.text(n.Pos(), n.Command)
+
+	case *ast.CallExpr:
+		if id, ok := n.Fun.(*ast.Ident); ok && (id.Name == "function" || id.Name == "funcref") && len(n.Args) > 0 {
+			if lit, ok := n.Args[0].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				v.ref(lit.Pos(), unquote(lit.Value), Funcref)
+			}
+		}
+		v.call(n.Fun, Call)
+
+	case *ast.MethodExpr:
+		v.call(n.Method, Method)
+	}
+	return v
+}
+
+// call adds the edge to the function x called by kind.
+func (v *visitor) call(x ast.Expr, kind EdgeKind) {
+	name, ok := funcName(x)
+	if !ok {
+		return
+	}
+	if v.s.info != nil {
+		if sym := v.s.info.Uses[x]; sym != nil && !sym.Func {
+			return // funcref variable
+		}
+	}
+	if callee := v.s.lookup(name, false); callee != nil {
+		v.edge(x.Pos(), callee, kind)
+	}
+}
+
+// ref adds the edge to the function name referred by kind if it's defined.
+func (v *visitor) ref(pos ast.Pos, name string, kind EdgeKind) {
+	if callee := v.s.lookup(name, true); callee != nil {
+		v.edge(pos, callee, kind)
+	}
+}
+
+func (v *visitor) edge(pos ast.Pos, callee *Node, kind EdgeKind) {
+	if v.kind.Deferred() {
+		kind = v.kind
+	}
+	e := &Edge{Caller: v.caller, Callee: callee, Pos: pos, Kind: kind}
+	v.caller.Out = append(v.caller.Out, e)
+	callee.In = append(callee.In, e)
+}
+
+// funcCall matches a function call in Ex command text, e.g. "<SID>F(" and
+// "foo#bar(".
+var funcCall = regexp.MustCompile(`(?:<[Ss][Ii][Dd]>|\b[gs]:)?\b[A-Za-z_][A-Za-z0-9_#]*\(`)
+
+// text adds edges to functions defined in the files called in the Ex
+// command text at pos, e.g. the rhs of a mapping which isn't parsed.
+func (v *visitor) text(pos ast.Pos, text string) {
+	for _, m := range funcCall.FindAllStringIndex(text, -1) {
+		p := pos
+		if !strings.Contains(text[:m[0]], "\n") {
+			p.Offset += m[0]
+			p.Column += m[0]
+		}
+		v.ref(p, text[m[0]:m[1]-1], Call)
+	}
+}
+
+// funcName returns the name of the function if x is an identifier or a curly
+// braces name without expressions such as `<SID>F`.
+func funcName(x ast.Expr) (string, bool) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name, true
+	case *ast.CurlyName:
+		var name string
+		for _, p := range x.Parts {
+			lit, ok := p.(*ast.CurlyNameLit)
+			if !ok {
+				return "", false
+			}
+			name += lit.Value
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// isBuiltinFunc reports whether the function name is a builtin function,
+// i.e. it starts with lower case letter without scope and "#".
+func isBuiltinFunc(name string) bool {
+	return name != "" && 'a' <= name[0] && name[0] <= 'z' &&
+		!strings.ContainsAny(name, ":#")
+}
+
+// unquote returns the value of the string literal s. Escape sequences of
+// double-quoted strings are not interpreted because function names don't
+// have them.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	if s[0] == '\'' {
+		return strings.Replace(s[1:len(s)-1], "''", "'", -1)
+	}
+	return s[1 : len(s)-1]
+}
@@ -0,0 +1,190 @@
+package callgraph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+func parse(t *testing.T, files ...string) []*ast.File {
+	t.Helper()
+	var fs []*ast.File
+	for i := 0; i < len(files); i += 2 {
+		f, err := vimlparser.ParseFile(strings.NewReader(files[i+1]), files[i], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs = append(fs, f)
+	}
+	return fs
+}
+
+func TestBuild(t *testing.T) {
+	g := Build(parse(t,
+		"plugin/foo.vim", `call foo#start()
+nnoremap <silent> <Plug>(foo) :<C-u>call <SID>Map()<CR>
+nnoremap <expr> <Plug>(bar) <SID>Expr()
+command! -nargs=* -complete=customlist,s:Complete Foo call s:Command(<f-args>)
+autocmd BufRead * call s:Auto()
+let s:F = function('s:Ref')
+function! s:Map() abort
+  let l:Fn = function('len')
+  return Fn([]) + Other()
+endfunction
+function! s:Expr() abort
+endfunction
+function! s:Complete(...) abort
+endfunction
+function! s:Command(...) abort
+endfunction
+function! s:Auto() abort
+endfunction
+function! s:Ref() abort
+endfunction
+function! s:Dead() abort
+  call s:Dead2()
+endfunction
+function! s:Dead2() abort
+endfunction
+`,
+		"autoload/foo.vim", `function! foo#start() abort
+  return [1]->foo#method()
+endfunction
+function! foo#method(x) abort
+  return a:x
+endfunction
+`,
+		"autoload/bar.vim", `vim9script
+export def Baz()
+  Local()
+enddef
+def Local()
+enddef
+`,
+	))
+
+	var nodes []string
+	for _, n := range g.Nodes {
+		nodes = append(nodes, fmt.Sprintf("%s %s %v", n.Kind, n.ID, n.Pos()))
+	}
+	wantNodes := []string{
+		"script plugin/foo.vim plugin/foo.vim:1:1",
+		"function plugin/foo.vim:s:Map plugin/foo.vim:7:11",
+		"function plugin/foo.vim:s:Expr plugin/foo.vim:11:11",
+		"function plugin/foo.vim:s:Complete plugin/foo.vim:13:11",
+		"function plugin/foo.vim:s:Command plugin/foo.vim:15:11",
+		"function plugin/foo.vim:s:Auto plugin/foo.vim:17:11",
+		"function plugin/foo.vim:s:Ref plugin/foo.vim:19:11",
+		"function plugin/foo.vim:s:Dead plugin/foo.vim:21:11",
+		"function plugin/foo.vim:s:Dead2 plugin/foo.vim:24:11",
+		"script autoload/foo.vim autoload/foo.vim:1:1",
+		"function foo#start autoload/foo.vim:1:11",
+		"function foo#method autoload/foo.vim:4:11",
+		"script autoload/bar.vim autoload/bar.vim:1:1",
+		"function autoload/bar.vim:s:Baz autoload/bar.vim:2:12",
+		"function autoload/bar.vim:s:Local autoload/bar.vim:5:5",
+		"external Other 0:0",
+	}
+	if !reflect.DeepEqual(nodes, wantNodes) {
+		t.Errorf("nodes:\ngot  %q\nwant %q", nodes, wantNodes)
+	}
+
+	var edges []string
+	for _, n := range g.Nodes {
+		for _, e := range n.Out {
+			edges = append(edges, fmt.Sprintf("%s -> %s %s %d:%d", e.Caller.Name, e.Callee.Name, e.Kind, e.Pos.Line, e.Pos.Column))
+		}
+	}
+	wantEdges := []string{
+		"plugin/foo.vim -> foo#start call 1:6",
+		"plugin/foo.vim -> s:Map mapping 2:42",
+		"plugin/foo.vim -> s:Expr mapping 3:29",
+		"plugin/foo.vim -> s:Complete command 4:1",
+		"plugin/foo.vim -> s:Command command 4:60",
+		"plugin/foo.vim -> s:Auto autocmd 5:24",
+		"plugin/foo.vim -> s:Ref funcref 6:20",
+		"s:Map -> Other call 9:19",
+		"s:Dead -> s:Dead2 call 22:8",
+		"foo#start -> foo#method method 2:15",
+		"s:Baz -> s:Local call 3:3",
+	}
+	if !reflect.DeepEqual(edges, wantEdges) {
+		t.Errorf("edges:\ngot  %q\nwant %q", edges, wantEdges)
+	}
+
+	var dead []string
+	for _, n := range g.Unreachable() {
+		dead = append(dead, n.Name)
+	}
+	if want := []string{"s:Dead", "s:Dead2", "s:Baz", "s:Local"}; !reflect.DeepEqual(dead, want) {
+		t.Errorf("Unreachable() = %q, want %q", dead, want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	g := Build(parse(t, "a.vim", `nnoremap x :call <SID>F()<CR>
+call s:F()
+call s:F()
+function! s:F() abort
+  call G()
+endfunction
+`))
+	var b bytes.Buffer
+	if err := g.WriteDOT(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph callgraph {
+	"a.vim" [shape=box];
+	"a.vim:s:F" [label="s:F"];
+	"G" [style=dashed];
+	"a.vim" -> "a.vim:s:F" [label=mapping, style=dashed];
+	"a.vim" -> "a.vim:s:F" [label=call];
+	"a.vim:s:F" -> "G" [label=call];
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteDOT:\ngot\n%s\nwant\n%s", got, want)
+	}
+
+	b.Reset()
+	if err := g.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	var out jsonGraph
+	if err := json.Unmarshal(b.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Nodes) != 3 || len(out.Edges) != 4 {
+		t.Fatalf("WriteJSON: %s", b.String())
+	}
+	if e := out.Edges[0]; e != (jsonEdge{Caller: "a.vim", Callee: "a.vim:s:F", Kind: "mapping", File: "a.vim", Line: 1, Column: 18}) {
+		t.Errorf("WriteJSON: edge = %+v", e)
+	}
+	if n := out.Nodes[2]; n != (jsonNode{ID: "G", Kind: "external", Name: "G"}) {
+		t.Errorf("WriteJSON: node = %+v", n)
+	}
+
+	// The file of the edge is where the reference is, which is not the
+	// first definition of the caller.
+	g = Build(parse(t,
+		"a.vim", "function! F() abort\nendfunction\n",
+		"b.vim", "function! F() abort\n  call G()\nendfunction\n",
+	))
+	b.Reset()
+	if err := g.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	out = jsonGraph{}
+	if err := json.Unmarshal(b.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if len(out.Edges) != 1 || out.Edges[0].File != "b.vim" {
+		t.Errorf("WriteJSON: edges = %+v", out.Edges)
+	}
+}
@@ -0,0 +1,101 @@
+package callgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the graph in the DOT language of Graphviz. Script nodes
+// are boxes and External nodes are dashed. References of the same kind from
+// a caller to a callee are an edge, and deferred edges are dashed.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph callgraph {")
+	for _, n := range g.Nodes {
+		switch n.Kind {
+		case Script:
+			fmt.Fprintf(b, "\t%s [shape=box];\n", strconv.Quote(n.ID))
+		case Function:
+			fmt.Fprintf(b, "\t%s [label=%s];\n", strconv.Quote(n.ID), strconv.Quote(n.Name))
+		case External:
+			fmt.Fprintf(b, "\t%s [style=dashed];\n", strconv.Quote(n.ID))
+		}
+	}
+	for _, n := range g.Nodes {
+		type key struct {
+			callee *Node
+			kind   EdgeKind
+		}
+		seen := make(map[key]bool)
+		for _, e := range n.Out {
+			if k := (key{e.Callee, e.Kind}); !seen[k] {
+				seen[k] = true
+				style := ""
+				if e.Kind.Deferred() {
+					style = ", style=dashed"
+				}
+				fmt.Fprintf(b, "\t%s -> %s [label=%s%s];\n", strconv.Quote(n.ID), strconv.Quote(e.Callee.ID), e.Kind, style)
+			}
+		}
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+type jsonGraph struct {
+	Nodes []jsonNode `json:"nodes"`
+	Edges []jsonEdge `json:"edges"`
+}
+
+type jsonNode struct {
+	ID     string `json:"id"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+type jsonEdge struct {
+	Caller string `json:"caller"`
+	Callee string `json:"callee"`
+	Kind   string `json:"kind"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// WriteJSON writes the graph in JSON: an object with "nodes" and "edges".
+// A node has "id", "kind", "name", "file", "line" and "column" of the
+// definition. An edge is a reference, which has "caller" and "callee" IDs,
+// "kind" and the position of the reference.
+func (g *Graph) WriteJSON(w io.Writer) error {
+	out := jsonGraph{Nodes: []jsonNode{}, Edges: []jsonEdge{}}
+	for _, n := range g.Nodes {
+		pos := n.Pos()
+		out.Nodes = append(out.Nodes, jsonNode{
+			ID:     n.ID,
+			Kind:   n.Kind.String(),
+			Name:   n.Name,
+			File:   n.File,
+			Line:   pos.Line,
+			Column: pos.Column,
+		})
+		for _, e := range n.Out {
+			out.Edges = append(out.Edges, jsonEdge{
+				Caller: n.ID,
+				Callee: e.Callee.ID,
+				Kind:   e.Kind.String(),
+				File:   e.Pos.Filename,
+				Line:   e.Pos.Line,
+				Column: e.Pos.Column,
+			})
+		}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
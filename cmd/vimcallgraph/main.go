@@ -0,0 +1,104 @@
+// Command vimcallgraph prints the call graph of Vim script files with the
+// callgraph package.
+//
+// Usage:
+//
+//	vimcallgraph [flags] [path ...]
+//
+// Given a directory, it reads all .vim files in that directory,
+// recursively. It prints the graph in the DOT language by default.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/callgraph"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+var (
+	usejson = flag.Bool("json", false, "output json")
+	dead    = flag.Bool("dead", false, "print functions unreachable from the top level of the files")
+	neovim  = flag.Bool("neovim", false, "use neovim parser")
+)
+
+var exitCode = 0
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 1
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vimcallgraph [flags] [path ...]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var paths []string
+	for _, path := range flag.Args() {
+		switch dir, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case dir.IsDir():
+			paths = append(paths, walkDir(path)...)
+		default:
+			paths = append(paths, path)
+		}
+	}
+	opt := &vimlparser.ParseOption{Neovim: *neovim, Recover: true}
+	prog := loader.LoadFiles(paths, &loader.Config{ParseOption: opt})
+	var files []*ast.File
+	for _, f := range prog.Files {
+		if f.Err != nil {
+			report(f.Err)
+		}
+		if f.AST != nil {
+			files = append(files, f.AST)
+		}
+	}
+
+	g := callgraph.Build(files)
+	var err error
+	switch {
+	case *dead:
+		for _, n := range g.Unreachable() {
+			fmt.Printf("%v: %s\n", n.Pos(), n.Name)
+		}
+	case *usejson:
+		err = g.WriteJSON(os.Stdout)
+	default:
+		err = g.WriteDOT(os.Stdout)
+	}
+	if err != nil {
+		report(err)
+	}
+	os.Exit(exitCode)
+}
+
+// walkDir returns .vim files in the directory.
+func walkDir(path string) []string {
+	var paths []string
+	filepath.Walk(path, func(path string, f os.FileInfo, err error) error {
+		if err == nil && loader.IsVimFile(f) {
+			paths = append(paths, path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			report(err)
+		}
+		return nil
+	})
+	return paths
+}
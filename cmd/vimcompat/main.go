@@ -0,0 +1,142 @@
+// Command vimcompat prints the versions of Vim and Neovim which Vim script
+// files require and the portability problems with the compat package.
+//
+// Usage:
+//
+//	vimcompat [flags] [path ...]
+//
+// Given a directory, it reads all .vim files in that directory,
+// recursively. It prints the newest version of each editor which each file
+// requires and the use which requires it. Given -vim or -nvim, it prints
+// all the uses which require newer versions than the given one instead and
+// exits with status 1 if any. Given -portability, it prints the uses of
+// commands, functions and options which either editor doesn't have and exits
+// with status 1 if any.
+//
+// The files are parsed with the commands of both editors.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/compat"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+var (
+	vim         = flag.String("vim", "", "supported version of Vim, e.g. 8.0 and 8.2.1234")
+	nvim        = flag.String("nvim", "", "supported version of Neovim, e.g. 0.4.0")
+	portability = flag.Bool("portability", false, "print features which Vim or Neovim doesn't have")
+)
+
+var exitCode = 0
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 2
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vimcompat [flags] [path ...]\n")
+	flag.PrintDefaults()
+}
+
+// target is a supported version of an editor.
+type target struct {
+	editor  builtin.Editor
+	version compat.Version
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var targets []target
+	for _, t := range []struct {
+		editor builtin.Editor
+		flag   string
+	}{{builtin.Vim, *vim}, {builtin.Neovim, *nvim}} {
+		if t.flag == "" {
+			continue
+		}
+		v, err := compat.ParseVersion(t.flag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(2)
+		}
+		targets = append(targets, target{t.editor, v})
+	}
+
+	var paths []string
+	for _, path := range flag.Args() {
+		switch dir, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case dir.IsDir():
+			paths = append(paths, walkDir(path)...)
+		default:
+			paths = append(paths, path)
+		}
+	}
+	opt := &vimlparser.ParseOption{AllCommands: true, Recover: true}
+	prog := loader.LoadFiles(paths, &loader.Config{ParseOption: opt})
+	for _, f := range prog.Files {
+		if f.Err != nil {
+			report(f.Err)
+		}
+		if f.AST == nil {
+			continue
+		}
+		if *portability {
+			for _, p := range compat.Portability(f.AST) {
+				fmt.Println(p)
+				if exitCode == 0 {
+					exitCode = 1
+				}
+			}
+			continue
+		}
+		r := compat.MinVersion(f.AST)
+		if len(targets) == 0 {
+			for _, req := range []*compat.Requirement{r.Vim, r.Neovim} {
+				if req != nil {
+					fmt.Printf("%s: %s %v (%v: %s)\n", f.Path, req.Editor, req.Version, req.Pos, req.Feature)
+				}
+			}
+			continue
+		}
+		for _, t := range targets {
+			for _, req := range r.Since(t.editor, t.version) {
+				fmt.Printf("%v: %s requires %s %v\n", req.Pos, req.Feature, req.Editor, req.Version)
+				if exitCode == 0 {
+					exitCode = 1
+				}
+			}
+		}
+	}
+	os.Exit(exitCode)
+}
+
+// walkDir returns .vim files in the directory.
+func walkDir(path string) []string {
+	var paths []string
+	filepath.Walk(path, func(path string, f os.FileInfo, err error) error {
+		if err == nil && loader.IsVimFile(f) {
+			paths = append(paths, path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			report(err)
+		}
+		return nil
+	})
+	return paths
+}
@@ -0,0 +1,246 @@
+// Command vimfmt formats Vim script.
+//
+// Usage:
+//
+//	vimfmt [flags] [path ...]
+//
+// Without an explicit path, it processes the standard input. Given a file,
+// it operates on that file; given a directory, it operates on all .vim files
+// in that directory, recursively.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/printer"
+)
+
+var (
+	list       = flag.Bool("l", false, "list files whose formatting differs from vimfmt's")
+	write      = flag.Bool("w", false, "write result to (source) file instead of stdout")
+	doDiff     = flag.Bool("d", false, "display diffs instead of rewriting files")
+	neovim     = flag.Bool("neovim", false, "use neovim parser")
+	indent     = flag.Int("indent", 2, "indent width")
+	tabs       = flag.Bool("tabs", false, "indent with tabs")
+	compact    = flag.Bool("compact", false, "print binary operators without surrounding spaces")
+	width      = flag.Int("width", 0, "maximum line width; long lists, dictionaries and arguments are split into continuation lines (0 means no limit)")
+	contIndent = flag.Int("contindent", 0, "indent width before \\ of continuation lines; 0 means 3 times -indent")
+)
+
+var exitCode = 0
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 2
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vimfmt [flags] [path ...]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	cfg := config()
+	opt := &vimlparser.ParseOption{Neovim: *neovim}
+
+	if flag.NArg() == 0 {
+		if *write {
+			fmt.Fprintln(os.Stderr, "error: cannot use -w with standard input")
+			os.Exit(2)
+		}
+		if err := processFile("<standard input>", os.Stdin, os.Stdout, cfg, opt); err != nil {
+			report(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	for _, path := range flag.Args() {
+		switch dir, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case dir.IsDir():
+			walkDir(path, cfg, opt)
+		default:
+			if err := processFile(path, nil, os.Stdout, cfg, opt); err != nil {
+				report(err)
+			}
+		}
+	}
+	os.Exit(exitCode)
+}
+
+func config() *printer.Config {
+	unit := strings.Repeat(" ", *indent)
+	if *tabs {
+		unit = "\t"
+	}
+	return &printer.Config{
+		Indent:             unit,
+		CompactOperators:   *compact,
+		LineWidth:          *width,
+		ContinuationIndent: strings.Repeat(" ", *contIndent),
+	}
+}
+
+func walkDir(path string, cfg *printer.Config, opt *vimlparser.ParseOption) {
+	filepath.Walk(path, func(path string, f os.FileInfo, err error) error {
+		if err == nil && isVimFile(f) {
+			err = processFile(path, nil, os.Stdout, cfg, opt)
+		}
+		// Don't complain if a file was deleted in the meantime (i.e.
+		// the directory changed concurrently while running vimfmt).
+		if err != nil && !os.IsNotExist(err) {
+			report(err)
+		}
+		return nil
+	})
+}
+
+func isVimFile(f os.FileInfo) bool {
+	name := f.Name()
+	return !f.IsDir() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".vim")
+}
+
+// If in == nil, the source is the contents of the file with the given filename.
+func processFile(filename string, in io.Reader, out io.Writer, cfg *printer.Config, opt *vimlparser.ParseOption) error {
+	if in == nil {
+		f, err := os.Open(filename)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	src, err := ioutil.ReadAll(in)
+	if err != nil {
+		return err
+	}
+
+	res, err := format(filename, src, cfg, opt)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(src, res) {
+		// formatting has changed
+		if *list {
+			fmt.Fprintln(out, filename)
+		}
+		if *write {
+			info, err := os.Stat(filename)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filename, res, info.Mode().Perm()); err != nil {
+				return err
+			}
+		}
+		if *doDiff {
+			data, err := diff(src, res, filename)
+			if err != nil {
+				return fmt.Errorf("computing diff: %s", err)
+			}
+			fmt.Fprintf(out, "diff -u %s %s\n", filepath.ToSlash(filename+".orig"), filepath.ToSlash(filename))
+			out.Write(data)
+		}
+	}
+
+	if !*list && !*write && !*doDiff {
+		_, err = out.Write(res)
+	}
+
+	return err
+}
+
+func format(filename string, src []byte, cfg *printer.Config, opt *vimlparser.ParseOption) ([]byte, error) {
+	node, err := vimlparser.ParseFile(bytes.NewReader(src), filename, opt)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, node, cfg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTempFile(dir, prefix string, data []byte) (string, error) {
+	file, err := ioutil.TempFile(dir, prefix)
+	if err != nil {
+		return "", err
+	}
+	_, err = file.Write(data)
+	if err1 := file.Close(); err == nil {
+		err = err1
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return file.Name(), nil
+}
+
+// diff runs "diff -u" for b1 and b2.
+func diff(b1, b2 []byte, filename string) (data []byte, err error) {
+	f1, err := writeTempFile("", "vimfmt", b1)
+	if err != nil {
+		return
+	}
+	defer os.Remove(f1)
+
+	f2, err := writeTempFile("", "vimfmt", b2)
+	if err != nil {
+		return
+	}
+	defer os.Remove(f2)
+
+	data, err = exec.Command("diff", "-u", f1, f2).CombinedOutput()
+	if len(data) > 0 {
+		// diff exits with a non-zero status when the files don't match.
+		// Ignore that failure as long as we get output.
+		return replaceTempFilename(data, filename)
+	}
+	return
+}
+
+// replaceTempFilename replaces temporary filenames in diff with actual one.
+//
+//	--- /tmp/vimfmt316145376	2017-02-03 19:13:00.280468375 -0500
+//	+++ /tmp/vimfmt617882815	2017-02-03 19:13:00.280468375 -0500
+//	...
+//	->
+//	--- path/to/file.vim.orig	2017-02-03 19:13:00.280468375 -0500
+//	+++ path/to/file.vim	2017-02-03 19:13:00.280468375 -0500
+//	...
+func replaceTempFilename(diff []byte, filename string) ([]byte, error) {
+	bs := bytes.SplitN(diff, []byte{'\n'}, 3)
+	if len(bs) < 3 {
+		return nil, fmt.Errorf("got unexpected diff for %s", filename)
+	}
+	// Preserve timestamps.
+	var t0, t1 []byte
+	if i := bytes.LastIndexByte(bs[0], '\t'); i != -1 {
+		t0 = bs[0][i:]
+	}
+	if i := bytes.LastIndexByte(bs[1], '\t'); i != -1 {
+		t1 = bs[1][i:]
+	}
+	// Always print filepath with slash separator.
+	f := filepath.ToSlash(filename)
+	bs[0] = []byte(fmt.Sprintf("--- %s%s", f+".orig", t0))
+	bs[1] = []byte(fmt.Sprintf("+++ %s%s", f, t1))
+	return bytes.Join(bs, []byte{'\n'}), nil
+}
@@ -0,0 +1,162 @@
+// Command vimlint checks Vim script with the rules of the lint package.
+//
+// Usage:
+//
+//	vimlint [flags] [path ...]
+//
+// Without an explicit path, it checks the standard input. Given a directory,
+// it checks all .vim files in that directory, recursively. It prints parse
+// errors and diagnostics and exits with status 1 if any.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/lint"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+var (
+	enable  = flag.String("enable", "", "comma separated rules to run; all rules if empty")
+	disable = flag.String("disable", "", "comma separated rules not to run")
+	neovim  = flag.Bool("neovim", false, "use neovim parser")
+	editor  = flag.String("editor", "", "editor which runs the scripts: vim or neovim; both if empty")
+	list    = flag.Bool("list", false, "list rules and exit")
+)
+
+var editors = map[string]builtin.Editor{
+	"":       builtin.Both,
+	"vim":    builtin.Vim,
+	"neovim": builtin.Neovim,
+}
+
+var exitCode = 0
+
+func report(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	exitCode = 2
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vimlint [flags] [path ...]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *list {
+		for _, r := range lint.Rules() {
+			fmt.Printf("%-26s %-8s %s\n", r.ID, r.Severity, r.Doc)
+		}
+		return
+	}
+
+	cfg := &lint.Config{Enable: splitList(*enable), Disable: splitList(*disable)}
+	for _, id := range append(cfg.Enable, cfg.Disable...) {
+		if lint.Lookup(id) == nil {
+			fmt.Fprintf(os.Stderr, "error: unknown rule %q\n", id)
+			os.Exit(2)
+		}
+	}
+	e, ok := editors[*editor]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: unknown editor %q\n", *editor)
+		os.Exit(2)
+	}
+	cfg.Editors = e
+	opt := &vimlparser.ParseOption{Neovim: *neovim, Recover: true}
+
+	if flag.NArg() == 0 {
+		node, err := vimlparser.ParseFile(os.Stdin, "", opt)
+		if err := lintFile(nil, &loader.File{AST: node, Err: err}, cfg); err != nil {
+			report(err)
+		}
+		os.Exit(exitCode)
+	}
+
+	var paths []string
+	for _, path := range flag.Args() {
+		switch dir, err := os.Stat(path); {
+		case err != nil:
+			report(err)
+		case dir.IsDir():
+			paths = append(paths, walkDir(path)...)
+		default:
+			paths = append(paths, path)
+		}
+	}
+	// Files are parsed in parallel and checked in order. Calls of autoload
+	// functions are resolved in the files.
+	prog := loader.LoadFiles(paths, &loader.Config{ParseOption: opt})
+	for _, f := range prog.Files {
+		if err := lintFile(prog, f, cfg); err != nil {
+			report(err)
+		}
+	}
+	os.Exit(exitCode)
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, x := range strings.Split(s, ",") {
+		if x = strings.TrimSpace(x); x != "" {
+			list = append(list, x)
+		}
+	}
+	return list
+}
+
+// walkDir returns .vim files in the directory.
+func walkDir(path string) []string {
+	var paths []string
+	filepath.Walk(path, func(path string, f os.FileInfo, err error) error {
+		if err == nil && loader.IsVimFile(f) {
+			paths = append(paths, path)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			report(err)
+		}
+		return nil
+	})
+	return paths
+}
+
+// lintFile prints parse errors and diagnostics of the file of prog, which is
+// nil for the standard input. The partial AST is checked even if the file
+// has parse errors.
+func lintFile(prog *loader.Program, f *loader.File, cfg *lint.Config) error {
+	if errs, ok := f.Err.(vimlparser.ErrorList); ok {
+		for _, e := range errs {
+			fmt.Println(e)
+		}
+		exitCode = max(exitCode, 1)
+	} else if f.Err != nil {
+		return f.Err
+	}
+	diags, err := lint.RunProgram(prog, f.AST, cfg)
+	if err != nil {
+		return err
+	}
+	for _, d := range diags {
+		fmt.Println(d)
+	}
+	if len(diags) > 0 {
+		exitCode = max(exitCode, 1)
+	}
+	return nil
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+// definition returns locations of the function definition whose name is at p.
+// Script-local functions are searched in the document, global functions in
+// the open documents and autoload functions in the open documents and the
+// autoload directories.
+func (s *server) definition(d *document, p position) []location {
+	locs := []location{}
+	var name string
+	ast.Inspect(d.file, func(n ast.Node) bool {
+		if n == nil || name != "" {
+			return false
+		}
+		switch n.(type) {
+		case *ast.Ident, *ast.CurlyName:
+			if d.contains(n, p) {
+				name, _ = funcName(n.(ast.Expr))
+			}
+			return false
+		}
+		return true
+	})
+	name = normalizeFuncName(name)
+	if name == "" {
+		return locs
+	}
+
+	locs = append(locs, findFunction(d, name)...)
+	if strings.HasPrefix(name, "s:") {
+		return locs
+	}
+	for uri, doc := range s.docs {
+		if uri != d.uri {
+			locs = append(locs, findFunction(doc, name)...)
+		}
+	}
+	if len(locs) > 0 || !strings.Contains(name, "#") {
+		return locs
+	}
+	for _, dir := range s.runtimeDirs(d) {
+		path := autoloadPath(dir, name)
+		if path == "" || s.docs[pathToURI(path)] != nil {
+			continue
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		doc := newDocument(pathToURI(path), string(b), s.neovim)
+		locs = append(locs, findFunction(doc, name)...)
+	}
+	return locs
+}
+
+// findFunction returns locations of the name of functions named name in d.
+func findFunction(d *document, name string) []location {
+	var locs []location
+	ast.Inspect(d.file, func(n ast.Node) bool {
+		if f, ok := n.(*ast.Function); ok {
+			if fname, ok := funcName(f.Name); ok && normalizeFuncName(fname) == name {
+				locs = append(locs, location{URI: d.uri, Range: d.rangeOf(f.Name)})
+			}
+		}
+		return true
+	})
+	return locs
+}
+
+// funcName returns the name of function if x is an identifier or a curly
+// braces name without expressions such as `<SID>Func`.
+func funcName(x ast.Expr) (string, bool) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name, true
+	case *ast.CurlyName:
+		var name string
+		for _, p := range x.Parts {
+			lit, ok := p.(*ast.CurlyNameLit)
+			if !ok {
+				return "", false
+			}
+			name += lit.Value
+		}
+		return name, true
+	}
+	return "", false
+}
+
+// normalizeFuncName returns the name used to find the definition of the
+// function. It returns empty string if name is not a name of script-local,
+// global or autoload function.
+func normalizeFuncName(name string) string {
+	if len(name) > 5 && strings.EqualFold(name[:5], "<SID>") {
+		return "s:" + name[5:]
+	}
+	name = strings.TrimPrefix(name, "g:")
+	switch {
+	case strings.HasPrefix(name, "s:") && len(name) > 2:
+		return name
+	case strings.Contains(name, "#"):
+		return name
+	case name != "" && 'A' <= name[0] && name[0] <= 'Z':
+		return name
+	}
+	return ""
+}
+
+// runtimeDirs returns directories which may have autoload directory: the
+// root directory of the workspace and the ancestors of the document which
+// have autoload directory.
+func (s *server) runtimeDirs(d *document) []string {
+	var dirs []string
+	if s.root != "" {
+		dirs = append(dirs, s.root)
+	}
+	if d.path == "" {
+		return dirs
+	}
+	for dir := filepath.Dir(d.path); ; {
+		if dir != s.root {
+			if fi, err := os.Stat(filepath.Join(dir, "autoload")); err == nil && fi.IsDir() {
+				dirs = append(dirs, dir)
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return dirs
+}
+
+// autoloadPath returns the path of the script which defines the autoload
+// function name in the runtime directory dir. e.g. dir/autoload/foo/bar.vim
+// for foo#bar#baz.
+func autoloadPath(dir, name string) string {
+	path := loader.AutoloadPath(name)
+	if path == "" {
+		return ""
+	}
+	return filepath.Join(dir, filepath.FromSlash(path))
+}
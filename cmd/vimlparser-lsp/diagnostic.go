@@ -0,0 +1,27 @@
+package main
+
+import "github.com/vim-jp/go-vimlparser/ast"
+
+// diagnostics returns parse errors of the document. The range of an error is
+// from the error position to the end of the line.
+func diagnostics(d *document) []diagnostic {
+	diags := []diagnostic{}
+	for _, e := range d.errs {
+		start := d.position(e.Pos())
+		end := start
+		if start.Line < len(d.lines) {
+			end = d.position(ast.Pos{Line: start.Line + 1, Column: len(d.lines[start.Line]) + 1})
+		}
+		if end.Character <= start.Character {
+			// at the end of line
+			end = position{Line: start.Line + 1}
+		}
+		diags = append(diags, diagnostic{
+			Range:    lspRange{Start: start, End: end},
+			Severity: severityError,
+			Source:   "vimlparser",
+			Message:  e.Msg,
+		})
+	}
+	return diags
+}
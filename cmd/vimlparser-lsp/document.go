@@ -0,0 +1,102 @@
+package main
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// document is a parsed Vim script file.
+type document struct {
+	uri   string
+	path  string   // filename; or empty if uri is not file URI
+	lines []string // lines without newline characters
+	smap  *vimlparser.SourceMap
+	file  *ast.File
+	errs  vimlparser.ErrorList
+}
+
+// newDocument parses text in the error-recovering mode, so that the partial
+// AST is available even if it has errors.
+func newDocument(uri, text string, neovim bool) *document {
+	d := &document{uri: uri, path: uriToPath(uri)}
+	opt := &vimlparser.ParseOption{Neovim: neovim, Recover: true}
+	f, err := vimlparser.ParseFile(strings.NewReader(text), d.path, opt)
+	switch err := err.(type) {
+	case nil:
+	case vimlparser.ErrorList:
+		d.errs = err
+	case *vimlparser.ErrVimlParser:
+		d.errs = vimlparser.ErrorList{err}
+	default:
+		d.errs = vimlparser.ErrorList{{Filename: d.path, Line: 1, Column: 1, Msg: err.Error()}}
+	}
+	if f == nil {
+		f = &ast.File{Start: ast.Pos{Line: 1, Column: 1}}
+	}
+	d.file = f
+	d.smap = vimlparser.NewSourceMap([]byte(text))
+	// split lines in the same way as ParseFile.
+	d.lines = strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	for i, l := range d.lines {
+		d.lines[i] = strings.TrimSuffix(l, "\r")
+	}
+	return d
+}
+
+// position converts pos to the LSP position.
+func (d *document) position(pos ast.Pos) position {
+	p := d.smap.Position(pos)
+	return position{Line: p.Line - 1, Character: p.UTF16Column - 1}
+}
+
+// rangeOf returns the range of the node.
+func (d *document) rangeOf(n ast.Node) lspRange {
+	return lspRange{Start: d.position(n.Pos()), End: d.position(n.End())}
+}
+
+// column returns the byte column (1-based) of the LSP position.
+func (d *document) column(p position) int {
+	if p.Line < 0 || p.Line >= len(d.lines) {
+		return 1
+	}
+	return d.smap.UTF16Pos(p.Line+1, p.Character+1).Column
+}
+
+// contains reports whether the node contains the LSP position.
+func (d *document) contains(n ast.Node, p position) bool {
+	line, col := p.Line+1, d.column(p)
+	start, end := n.Pos(), n.End()
+	if line < start.Line || line == start.Line && col < start.Column {
+		return false
+	}
+	return line < end.Line || line == end.Line && col < end.Column
+}
+
+// uriToPath returns the filename of file URI.
+func uriToPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	path := u.Path
+	// file:///C:/path on Windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// pathToURI returns file URI of the filename.
+func pathToURI(path string) string {
+	path, _ = filepath.Abs(path)
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	u := url.URL{Scheme: "file", Path: path}
+	return u.String()
+}
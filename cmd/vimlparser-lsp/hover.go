@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// flagDescriptions describes flags of builtin commands which are interesting
+// for users.
+var flagDescriptions = []struct {
+	flag, desc string
+}{
+	{"RANGE", "accepts a range"},
+	{"COUNT", "accepts a count"},
+	{"BANG", "accepts !"},
+	{"REGSTR", "accepts a register name"},
+	{"EXTRA", "accepts arguments"},
+	{"NEEDARG", "requires an argument"},
+	{"FILE1", "accepts a file name"},
+	{"FILES", "accepts file names"},
+	{"TRLBAR", "can be followed by | and another command"},
+	{"NOTRLCOM", "doesn't accept a trailing comment"},
+	{"MODIFY", "modifies the buffer"},
+	{"CMDWIN", "allowed in the command-line window"},
+	{"SBOXOK", "allowed in the sandbox"},
+}
+
+// hoverAt returns the description of the builtin command whose name is at p,
+// or nil. The command comes from ExArg.Cmd, which is the entry of the
+// builtin_commands table of the parser.
+func hoverAt(d *document, p position) *hover {
+	var h *hover
+	ast.Inspect(d.file, func(n ast.Node) bool {
+		if n == nil || h != nil {
+			return false
+		}
+		if _, ok := n.(ast.Statement); !ok {
+			return true
+		}
+		ea := exArg(n)
+		if ea == nil || ea.Cmd == nil || ea.Cmdpos == nil || ea.Cmd.Flags == "USERCMD" {
+			return true
+		}
+		r, ok := cmdNameRange(d, ea)
+		if !ok || !inRange(r, p) {
+			return true
+		}
+		h = &hover{
+			Contents: markupContent{Kind: "markdown", Value: describeCmd(ea.Cmd)},
+			Range:    &r,
+		}
+		return false
+	})
+	return h
+}
+
+// exArg returns ExArg field of the statement n or nil.
+func exArg(n ast.Node) *ast.ExArg {
+	v := reflect.ValueOf(n)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	f := v.Elem().FieldByName("ExArg")
+	if !f.IsValid() {
+		return nil
+	}
+	ea, ok := f.Addr().Interface().(*ast.ExArg)
+	if !ok {
+		return nil
+	}
+	return ea
+}
+
+// cmdNameRange returns the range of the command name including "!".
+func cmdNameRange(d *document, ea *ast.ExArg) (lspRange, bool) {
+	p := ea.Cmdpos
+	if p.Line < 1 || p.Line > len(d.lines) || p.Column < 1 || p.Column > len(d.lines[p.Line-1]) {
+		return lspRange{}, false
+	}
+	line := d.lines[p.Line-1]
+	end := p.Column - 1
+	for end < len(line) && isAlpha(line[end]) {
+		end++
+	}
+	if ea.Forceit && end < len(line) && line[end] == '!' {
+		end++
+	}
+	start := d.position(*p)
+	return lspRange{
+		Start: start,
+		End:   d.position(ast.Pos{Line: p.Line, Column: end + 1}),
+	}, true
+}
+
+func isAlpha(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// inRange reports whether p is in r. The end of r is exclusive.
+func inRange(r lspRange, p position) bool {
+	if p.Line < r.Start.Line || p.Line == r.Start.Line && p.Character < r.Start.Character {
+		return false
+	}
+	return p.Line < r.End.Line || p.Line == r.End.Line && p.Character < r.End.Character
+}
+
+// describeCmd returns the description of the command in markdown, e.g.
+//
+//	```vim
+//	:ec[ho]
+//	```
+//	- accepts arguments
+//	...
+func describeCmd(cmd *ast.Cmd) string {
+	name := cmd.Name
+	if 0 < cmd.Minlen && cmd.Minlen < len(name) {
+		name = fmt.Sprintf("%s[%s]", name[:cmd.Minlen], name[cmd.Minlen:])
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "```vim\n:%s\n```\n", name)
+	flags := strings.Split(cmd.Flags, "|")
+	for _, f := range flagDescriptions {
+		for _, flag := range flags {
+			if flag == f.flag {
+				fmt.Fprintf(&b, "- %s\n", f.desc)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nSee `:help :%s`.", cmd.Name)
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/textproto"
+	"strconv"
+	"sync"
+)
+
+// JSON-RPC error codes.
+const (
+	codeParseError     = -32700
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeInternalError  = -32603
+)
+
+// message is a JSON-RPC request, notification or response. Notifications
+// don't have ID.
+type message struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method,omitempty"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+	Result  interface{}      `json:"result,omitempty"`
+	Error   *rpcError        `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// conn reads and writes JSON-RPC messages with the base protocol of LSP, that
+// is, the content is preceded by headers such as "Content-Length: 123".
+type conn struct {
+	r *textproto.Reader
+
+	mu sync.Mutex // guards w
+	w  io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{r: textproto.NewReader(bufio.NewReader(r)), w: w}
+}
+
+// read reads the next message. It returns io.EOF when the input is closed.
+func (c *conn) read() (*message, error) {
+	header, err := c.r.ReadMIMEHeader()
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(header.Get("Content-Length"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Content-Length: %q", header.Get("Content-Length"))
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(c.r.R, body); err != nil {
+		return nil, err
+	}
+	var m message
+	if err := json.Unmarshal(body, &m); err != nil {
+		return nil, &rpcError{Code: codeParseError, Message: err.Error()}
+	}
+	return &m, nil
+}
+
+// write writes m with jsonrpc version.
+func (c *conn) write(m *message) error {
+	m.JSONRPC = "2.0"
+	body, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(body)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(body)
+	return err
+}
+
+// reply sends the response of the request id. Result is null if both result
+// and err are nil.
+func (c *conn) reply(id *json.RawMessage, result interface{}, err error) error {
+	m := &message{ID: id}
+	switch err := err.(type) {
+	case nil:
+		if result == nil {
+			result = json.RawMessage("null")
+		}
+		m.Result = result
+	case *rpcError:
+		m.Error = err
+	default:
+		m.Error = &rpcError{Code: codeInternalError, Message: err.Error()}
+	}
+	return c.write(m)
+}
+
+// notify sends the notification.
+func (c *conn) notify(method string, params interface{}) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	return c.write(&message{Method: method, Params: b})
+}
@@ -0,0 +1,54 @@
+// Command vimlparser-lsp is a Language Server Protocol server for Vim script.
+//
+// Usage:
+//
+//	vimlparser-lsp [flags]
+//
+// It communicates with the client over the standard input and output and
+// supports the following features.
+//
+//   - diagnostics of parse errors
+//   - document symbols: functions, global variables, augroups and commands
+//   - go to definition of script-local, global and autoload functions
+//   - hover for builtin Ex commands
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+var (
+	neovim  = flag.Bool("neovim", false, "use neovim parser")
+	logfile = flag.String("log", "", "write log to the file")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: vimlparser-lsp [flags]\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	log.SetOutput(ioutil.Discard)
+	if *logfile != "" {
+		f, err := os.OpenFile(*logfile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		defer f.Close()
+		log.SetOutput(f)
+	}
+
+	s := newServer(newConn(os.Stdin, os.Stdout), *neovim)
+	if err := s.run(); err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+// Types of Language Server Protocol used by the server.
+// ref: https://microsoft.github.io/language-server-protocol/specification
+
+type initializeParams struct {
+	RootURI string `json:"rootUri"`
+}
+
+type initializeResult struct {
+	Capabilities serverCapabilities `json:"capabilities"`
+}
+
+type serverCapabilities struct {
+	TextDocumentSync       int  `json:"textDocumentSync"`
+	DocumentSymbolProvider bool `json:"documentSymbolProvider"`
+	DefinitionProvider     bool `json:"definitionProvider"`
+	HoverProvider          bool `json:"hoverProvider"`
+}
+
+// TextDocumentSyncKind
+const syncFull = 1
+
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type textDocumentItem struct {
+	URI  string `json:"uri"`
+	Text string `json:"text"`
+}
+
+type didOpenTextDocumentParams struct {
+	TextDocument textDocumentItem `json:"textDocument"`
+}
+
+type didChangeTextDocumentParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type didCloseTextDocumentParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type documentSymbolParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+type textDocumentPositionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+// position is zero-based line and character offset in UTF-16 code units.
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type lspRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+type location struct {
+	URI   string   `json:"uri"`
+	Range lspRange `json:"range"`
+}
+
+// DiagnosticSeverity
+const severityError = 1
+
+type diagnostic struct {
+	Range    lspRange `json:"range"`
+	Severity int      `json:"severity"`
+	Source   string   `json:"source"`
+	Message  string   `json:"message"`
+}
+
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+// SymbolKind
+const (
+	symbolNamespace = 3
+	symbolFunction  = 12
+	symbolVariable  = 13
+)
+
+type documentSymbol struct {
+	Name           string           `json:"name"`
+	Detail         string           `json:"detail,omitempty"`
+	Kind           int              `json:"kind"`
+	Range          lspRange         `json:"range"`
+	SelectionRange lspRange         `json:"selectionRange"`
+	Children       []documentSymbol `json:"children,omitempty"`
+}
+
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    *lspRange     `json:"range,omitempty"`
+}
+
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+)
+
+var errExitWithoutShutdown = errors.New("exit notification without shutdown request")
+
+// server is a language server. It handles messages one by one, so documents
+// don't need locks.
+type server struct {
+	conn   *conn
+	neovim bool
+
+	root     string               // root directory of the workspace; or empty
+	docs     map[string]*document // open documents by URI
+	shutdown bool
+}
+
+func newServer(c *conn, neovim bool) *server {
+	return &server{conn: c, neovim: neovim, docs: make(map[string]*document)}
+}
+
+// run handles messages until the exit notification or the end of input.
+func (s *server) run() error {
+	for {
+		m, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if e, ok := err.(*rpcError); ok {
+			// malformed JSON
+			s.conn.reply(nil, nil, e)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if m.Method == "exit" {
+			if !s.shutdown {
+				return errExitWithoutShutdown
+			}
+			return nil
+		}
+		result, err := s.handle(m)
+		if m.ID == nil {
+			if err != nil {
+				log.Printf("%s: %v", m.Method, err)
+			}
+			continue
+		}
+		if err := s.conn.reply(m.ID, result, err); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles the request or notification m.
+func (s *server) handle(m *message) (interface{}, error) {
+	log.Printf("<- %s", m.Method)
+	switch m.Method {
+	case "initialize":
+		var params initializeParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		s.root = uriToPath(params.RootURI)
+		return &initializeResult{Capabilities: serverCapabilities{
+			TextDocumentSync:       syncFull,
+			DocumentSymbolProvider: true,
+			DefinitionProvider:     true,
+			HoverProvider:          true,
+		}}, nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenTextDocumentParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.update(params.TextDocument.URI, params.TextDocument.Text)
+
+	case "textDocument/didChange":
+		var params didChangeTextDocumentParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		if n := len(params.ContentChanges); n > 0 {
+			// full sync; the last change has the whole text.
+			return nil, s.update(params.TextDocument.URI, params.ContentChanges[n-1].Text)
+		}
+		return nil, nil
+
+	case "textDocument/didClose":
+		var params didCloseTextDocumentParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, params.TextDocument.URI)
+		return nil, s.conn.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+			URI:         params.TextDocument.URI,
+			Diagnostics: []diagnostic{},
+		})
+
+	case "textDocument/documentSymbol":
+		var params documentSymbolParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return []documentSymbol{}, nil
+		}
+		return documentSymbols(d), nil
+
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return []location{}, nil
+		}
+		return s.definition(d, params.Position), nil
+
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := unmarshalParams(m, &params); err != nil {
+			return nil, err
+		}
+		d, ok := s.docs[params.TextDocument.URI]
+		if !ok {
+			return nil, nil
+		}
+		if h := hoverAt(d, params.Position); h != nil {
+			return h, nil
+		}
+		return nil, nil
+	}
+	if m.ID == nil {
+		// ignore unknown notifications such as $/cancelRequest.
+		return nil, nil
+	}
+	return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + m.Method}
+}
+
+// update parses the document and publishes its diagnostics.
+func (s *server) update(uri, text string) error {
+	d := newDocument(uri, text, s.neovim)
+	s.docs[uri] = d
+	return s.conn.notify("textDocument/publishDiagnostics", &publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: diagnostics(d),
+	})
+}
+
+func unmarshalParams(m *message, v interface{}) error {
+	if err := json.Unmarshal(m.Params, v); err != nil {
+		return &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
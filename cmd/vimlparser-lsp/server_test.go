@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func init() {
+	log.SetOutput(ioutil.Discard)
+}
+
+const testSrc = `let g:loaded = 1
+let s:count = 0
+
+function! s:inc() abort
+  let s:count += 1
+endfunction
+
+function! Hello() abort
+  call s:inc()
+  call foo#bar#baz()
+  echo 'ｘ' "こんにちは"
+endfunction
+
+augroup my_group
+  autocmd!
+  autocmd BufRead * call Hello()
+augroup END
+
+command! -nargs=0 Hello call Hello()
+call Hello()
+`
+
+// request writes JSON-RPC request (notification if id is 0) to buf.
+func request(buf *bytes.Buffer, id int, method string, params interface{}) {
+	m := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+	if id != 0 {
+		m["id"] = id
+	}
+	b, _ := json.Marshal(m)
+	fmt.Fprintf(buf, "Content-Length: %d\r\n\r\n%s", len(b), b)
+}
+
+// runServer runs the server with requests in input and returns the messages
+// sent by the server.
+func runServer(t *testing.T, root string, input *bytes.Buffer) []map[string]json.RawMessage {
+	t.Helper()
+	var out bytes.Buffer
+	s := newServer(newConn(input, &out), false)
+	s.root = root
+	if err := s.run(); err != nil {
+		t.Fatal(err)
+	}
+	var msgs []map[string]json.RawMessage
+	c := newConn(&out, nil)
+	for {
+		m, err := c.read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := json.Marshal(m)
+		var raw map[string]json.RawMessage
+		json.Unmarshal(b, &raw)
+		msgs = append(msgs, raw)
+	}
+	return msgs
+}
+
+func textDocumentPosition(uri string, line, char int) interface{} {
+	return map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+		"position":     position{Line: line, Character: char},
+	}
+}
+
+func TestServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vimlparser-lsp")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := os.MkdirAll(filepath.Join(dir, "autoload", "foo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	autoload := filepath.Join(dir, "autoload", "foo", "bar.vim")
+	if err := ioutil.WriteFile(autoload, []byte("function foo#bar#baz() abort\nendfunction\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	uri := pathToURI(filepath.Join(dir, "plugin", "test.vim"))
+
+	var in bytes.Buffer
+	request(&in, 1, "initialize", map[string]string{"rootUri": pathToURI(dir)})
+	request(&in, 0, "initialized", struct{}{})
+	request(&in, 0, "textDocument/didOpen", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri, "languageId": "vim", "text": testSrc},
+	})
+	request(&in, 2, "textDocument/documentSymbol", map[string]interface{}{
+		"textDocument": map[string]string{"uri": uri},
+	})
+	request(&in, 3, "textDocument/definition", textDocumentPosition(uri, 8, 9))  // s:inc
+	request(&in, 4, "textDocument/definition", textDocumentPosition(uri, 9, 12)) // foo#bar#baz
+	request(&in, 5, "textDocument/definition", textDocumentPosition(uri, 19, 6)) // Hello
+	request(&in, 6, "textDocument/hover", textDocumentPosition(uri, 10, 3))      // echo
+	request(&in, 7, "textDocument/didChange", map[string]interface{}{
+		"textDocument":   map[string]interface{}{"uri": uri, "version": 2},
+		"contentChanges": []map[string]string{{"text": "echo 'ｘ' |\nlet x = [\n"}},
+	})
+	request(&in, 8, "unknown", struct{}{})
+	request(&in, 9, "shutdown", nil)
+	request(&in, 0, "exit", nil)
+
+	msgs := runServer(t, dir, &in)
+	byID := make(map[string]json.RawMessage)
+	var diags []publishDiagnosticsParams
+	for _, m := range msgs {
+		if id, ok := m["id"]; ok {
+			if e, ok := m["error"]; ok {
+				byID[string(id)] = e
+			} else {
+				byID[string(id)] = m["result"]
+			}
+			continue
+		}
+		var p publishDiagnosticsParams
+		if err := json.Unmarshal(m["params"], &p); err != nil {
+			t.Fatal(err)
+		}
+		diags = append(diags, p)
+	}
+
+	var init initializeResult
+	json.Unmarshal(byID["1"], &init)
+	if !init.Capabilities.HoverProvider || init.Capabilities.TextDocumentSync != syncFull {
+		t.Errorf("initialize = %s", byID["1"])
+	}
+
+	var syms []documentSymbol
+	json.Unmarshal(byID["2"], &syms)
+	var names []string
+	for _, s := range syms {
+		names = append(names, s.Name)
+		for _, c := range s.Children {
+			names = append(names, s.Name+"/"+c.Name)
+		}
+	}
+	if want := []string{"g:loaded", "s:inc", "Hello", "my_group", "Hello"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("symbols = %q, want %q", names, want)
+	}
+	if len(syms) == 5 {
+		if r := syms[3].Range; r.Start.Line != 13 || r.End.Line != 16 {
+			t.Errorf("augroup range = %+v, want lines 13-16", r)
+		}
+		if r := syms[4].SelectionRange; r != (lspRange{position{18, 18}, position{18, 23}}) {
+			t.Errorf("command selection range = %+v", r)
+		}
+	}
+
+	for _, tt := range []struct {
+		id   string
+		want location
+	}{
+		{"3", location{URI: uri, Range: lspRange{position{3, 10}, position{3, 15}}}},
+		{"4", location{URI: pathToURI(autoload), Range: lspRange{position{0, 9}, position{0, 20}}}},
+		{"5", location{URI: uri, Range: lspRange{position{7, 10}, position{7, 15}}}},
+	} {
+		var locs []location
+		json.Unmarshal(byID[tt.id], &locs)
+		if want := []location{tt.want}; !reflect.DeepEqual(locs, want) {
+			t.Errorf("definition %s = %+v, want %+v", tt.id, locs, want)
+		}
+	}
+
+	var h hover
+	json.Unmarshal(byID["6"], &h)
+	if h.Range == nil || *h.Range != (lspRange{position{10, 2}, position{10, 6}}) || !bytes.Contains([]byte(h.Contents.Value), []byte(":ec[ho]")) {
+		t.Errorf("hover = %s", byID["6"])
+	}
+
+	if len(diags) != 2 || len(diags[0].Diagnostics) != 0 || len(diags[1].Diagnostics) == 0 {
+		t.Fatalf("diagnostics = %+v", diags)
+	}
+	if d := diags[1].Diagnostics[0]; d.Range.Start.Line != 1 || d.Severity != severityError {
+		t.Errorf("diagnostic = %+v", d)
+	}
+
+	var rerr rpcError
+	json.Unmarshal(byID["8"], &rerr)
+	if rerr.Code != codeMethodNotFound {
+		t.Errorf("unknown method = %s", byID["8"])
+	}
+	// null result is dropped in runServer.
+	if b, ok := byID["9"]; !ok || b != nil {
+		t.Errorf("shutdown = %s", byID["9"])
+	}
+}
+
+func TestPositionUTF16(t *testing.T) {
+	d := newDocument("", "echo '𝐀' 'x'\n", false)
+	// 𝐀 is 4 bytes in UTF-8 and 2 code units in UTF-16.
+	if got := d.column(position{Line: 0, Character: 9}); got != 12 {
+		t.Errorf("column = %d, want 12", got)
+	}
+	if len(d.file.Body) != 1 {
+		t.Fatal("unexpected parse result")
+	}
+	if got := d.rangeOf(d.file.Body[0]); got != (lspRange{position{0, 0}, position{0, 13}}) {
+		t.Errorf("range = %+v", got)
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/printer"
+)
+
+// documentSymbols returns functions, global variables, augroups and user
+// commands defined in the document. Functions have symbols in their bodies as
+// children.
+func documentSymbols(d *document) []documentSymbol {
+	c := &symbolCollector{d: d, vars: make(map[string]bool)}
+	return c.collect(d.file.Body, false)
+}
+
+type symbolCollector struct {
+	d    *document
+	vars map[string]bool // global variables already collected
+}
+
+func (c *symbolCollector) collect(body []ast.Statement, inFunc bool) []documentSymbol {
+	syms := []documentSymbol{}
+	augroup := -1 // index of the augroup symbol waiting for `augroup END`
+	for _, s := range body {
+		switch s := s.(type) {
+		case *ast.Function:
+			syms = append(syms, documentSymbol{
+				Name:           exprString(s.Name),
+				Detail:         "function",
+				Kind:           symbolFunction,
+				Range:          c.d.rangeOf(s),
+				SelectionRange: c.d.rangeOf(s.Name),
+				Children:       c.collect(s.Body, true),
+			})
+
+		case *ast.Let:
+			targets := append([]ast.Expr{s.Left, s.Rest}, s.List...)
+			for _, t := range targets {
+				id, ok := t.(*ast.Ident)
+				if !ok || c.vars[id.Name] || !isGlobalVar(id.Name, inFunc) {
+					continue
+				}
+				c.vars[id.Name] = true
+				syms = append(syms, documentSymbol{
+					Name:           id.Name,
+					Detail:         "let",
+					Kind:           symbolVariable,
+					Range:          c.d.rangeOf(s),
+					SelectionRange: c.d.rangeOf(id),
+				})
+			}
+
+		case *ast.Augroup:
+			if s.ExArg.Forceit || s.Name == "" {
+				// :augroup! deletes the group.
+				continue
+			}
+			if s.IsEnd {
+				if augroup >= 0 {
+					syms[augroup].Range.End = c.d.position(s.End())
+					augroup = -1
+				}
+				continue
+			}
+			syms = append(syms, c.excmdSymbol(s, s.ExArg, s.Name, "augroup", symbolNamespace))
+			augroup = len(syms) - 1
+
+		case *ast.UserCommand:
+			if s.Name != "" {
+				syms = append(syms, c.excmdSymbol(s, s.ExArg, s.Name, "command", symbolFunction))
+			}
+
+		case *ast.If:
+			syms = append(syms, c.collect(s.Body, inFunc)...)
+			for _, e := range s.ElseIf {
+				syms = append(syms, c.collect(e.Body, inFunc)...)
+			}
+			if s.Else != nil {
+				syms = append(syms, c.collect(s.Else.Body, inFunc)...)
+			}
+
+		case *ast.While:
+			syms = append(syms, c.collect(s.Body, inFunc)...)
+
+		case *ast.For:
+			syms = append(syms, c.collect(s.Body, inFunc)...)
+
+		case *ast.Try:
+			syms = append(syms, c.collect(s.Body, inFunc)...)
+			for _, e := range s.Catch {
+				syms = append(syms, c.collect(e.Body, inFunc)...)
+			}
+			if s.Finally != nil {
+				syms = append(syms, c.collect(s.Finally.Body, inFunc)...)
+			}
+		}
+	}
+	return syms
+}
+
+// excmdSymbol returns the symbol of the name defined by the Ex command s.
+func (c *symbolCollector) excmdSymbol(s ast.Node, ea ast.ExArg, name, detail string, kind int) documentSymbol {
+	r := c.d.rangeOf(s)
+	sel := r
+	if p := ea.Cmdpos; p != nil && p.Line-1 < len(c.d.lines) && p.Column-1 <= len(c.d.lines[p.Line-1]) {
+		line := c.d.lines[p.Line-1]
+		if i := strings.Index(line[p.Column-1:], name); i >= 0 {
+			start := ast.Pos{Line: p.Line, Column: p.Column + i}
+			end := ast.Pos{Line: p.Line, Column: start.Column + len(name)}
+			sel = lspRange{Start: c.d.position(start), End: c.d.position(end)}
+		}
+	}
+	return documentSymbol{
+		Name:           name,
+		Detail:         detail,
+		Kind:           kind,
+		Range:          r,
+		SelectionRange: sel,
+	}
+}
+
+// isGlobalVar reports whether the variable name is global. Variables without
+// scope are global outside functions.
+func isGlobalVar(name string, inFunc bool) bool {
+	if strings.HasPrefix(name, "g:") {
+		return len(name) > 2
+	}
+	return !inFunc && !strings.Contains(name, ":")
+}
+
+// exprString returns Vim script representation of the expression.
+func exprString(x ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, x, nil); err != nil {
+		return ""
+	}
+	return buf.String()
+}
@@ -8,16 +8,19 @@ import (
 	"os"
 
 	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
 	"github.com/vim-jp/go-vimlparser/compiler"
+	"github.com/vim-jp/go-vimlparser/loader"
 )
 
 var neovim = flag.Bool("neovim", false, "use neovim parser")
 var usejson = flag.Bool("json", false, "output json")
+var recoverErr = flag.Bool("recover", false, "continue parsing after errors and report all of them")
 
 func main() {
 	flag.Parse()
 
-	opt := &vimlparser.ParseOption{Neovim: *neovim}
+	opt := &vimlparser.ParseOption{Neovim: *neovim, Recover: *recoverErr}
 
 	if len(flag.Args()) == 0 {
 		if err := parseFile("", os.Stdin, os.Stdout, opt, *usejson); err != nil {
@@ -29,17 +32,18 @@ func main() {
 
 	exitCode := 0
 
-	for i, file := range flag.Args() {
-		f, err := os.Open(file)
-		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+	// Files are parsed in parallel and printed in order.
+	prog := loader.LoadFiles(flag.Args(), &loader.Config{ParseOption: opt})
+	for i, f := range prog.Files {
+		if _, ok := f.Err.(*os.PathError); ok {
+			fmt.Fprintln(os.Stderr, f.Err)
 			if i == 0 {
 				flag.Usage()
 			}
 			exitCode = 1
 			continue
 		}
-		if err := parseFile(f.Name(), f, os.Stdout, opt, *usejson); err != nil {
+		if err := printFile(f.AST, f.Err, os.Stdout, *usejson); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			exitCode = 1
 		}
@@ -52,6 +56,17 @@ func main() {
 func parseFile(filename string, r io.ReadCloser, w io.Writer, opt *vimlparser.ParseOption, usejson bool) error {
 	defer r.Close()
 	node, err := vimlparser.ParseFile(r, filename, opt)
+	return printFile(node, err, w, usejson)
+}
+
+// printFile prints node or err, which are the results of ParseFile.
+func printFile(node *ast.File, err error, w io.Writer, usejson bool) error {
+	if errs, ok := err.(vimlparser.ErrorList); ok {
+		for _, e := range errs[:len(errs)-1] {
+			fmt.Fprintln(os.Stderr, e)
+		}
+		return errs[len(errs)-1]
+	}
 	if err != nil {
 		return err
 	}
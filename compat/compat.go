@@ -0,0 +1,195 @@
+// Package compat reports the versions of Vim and Neovim which Vim script
+// files require and the uses of features which either editor doesn't have.
+//
+// A file requires the version of an editor which introduced the syntax, the
+// commands and the builtin functions it uses. The versions of commands and
+// functions are from the builtin package. The versions of Neovim are known
+// only for some syntax and for functions of Neovim only; Neovim has all the
+// features of Vim 7.4.
+package compat
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/scope"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// Requirement is a use of a feature which requires a version of an editor.
+type Requirement struct {
+	Pos     ast.Pos        // position of the use
+	Editor  builtin.Editor // Vim or Neovim
+	Version Version        // version which introduced the feature
+	Feature string         // e.g. "method call", ":const" and "popup_create()"
+}
+
+// Report is the requirements of a file.
+type Report struct {
+	Vim    *Requirement // requirement of the newest Vim; or nil
+	Neovim *Requirement // requirement of the newest Neovim; or nil
+
+	// Requirements is all the requirements sorted by the positions.
+	Requirements []*Requirement
+}
+
+// Since returns the requirements of the editor e whose versions are newer
+// than v.
+func (r *Report) Since(e builtin.Editor, v Version) []*Requirement {
+	var list []*Requirement
+	for _, req := range r.Requirements {
+		if req.Editor == e && v.Less(req.Version) {
+			list = append(list, req)
+		}
+	}
+	return list
+}
+
+// syntax is the versions which introduced syntax. The versions of Neovim
+// are the first releases which have the patches of Vim.
+var syntax = map[string]struct{ vim, neovim string }{
+	"lambda":                       {"7.4.2044", "0.2.0"},
+	"blob literal":                 {"8.1.0735", "0.5.0"},
+	"string concatenation with ..": {"8.1.1114", "0.4.0"},
+	"heredoc":                      {"8.1.1354", "0.4.0"},
+	":const":                       {"8.1.1539", "0.4.0"},
+	"literal dictionary":           {"8.1.1705", "0.5.0"},
+	"method call":                  {"8.1.1803", "0.5.0"},
+	":eval":                        {"8.1.1807", "0.5.0"},
+}
+
+// MinVersion returns the requirements of f. As in Portability, code under
+// the conditions of exists() of the feature, e.g. exists('*popup_create'),
+// doesn't require the feature, and code under has('patch-8.1.1391') doesn't
+// require Vim of the patch or older.
+func MinVersion(f *ast.File) *Report {
+	c := &checker{info: scope.Resolve(f)}
+	inspectGuarded(f.Body, guard{editors: builtin.Both}, c.visit)
+	r := &Report{Requirements: c.reqs}
+	sort.SliceStable(r.Requirements, func(i, j int) bool {
+		p, q := r.Requirements[i].Pos, r.Requirements[j].Pos
+		return p.Offset < q.Offset
+	})
+	for _, req := range r.Requirements {
+		newest := &r.Vim
+		if req.Editor == builtin.Neovim {
+			newest = &r.Neovim
+		}
+		if *newest == nil || (*newest).Version.Less(req.Version) {
+			*newest = req
+		}
+	}
+	return r
+}
+
+type checker struct {
+	info *scope.Info
+	reqs []*Requirement
+}
+
+// add adds the requirement unless the editor e doesn't run the code under g,
+// or the feature is checked by exists() with any of the names or the Vim
+// patch is checked by has() under g.
+func (c *checker) add(g guard, pos ast.Pos, e builtin.Editor, version, feature string, names ...string) {
+	if version == "" || g.editors&e == 0 || g.checked(names) {
+		return
+	}
+	v := mustParse(version)
+	if e == builtin.Vim && !g.patch.Less(v) {
+		return
+	}
+	c.reqs = append(c.reqs, &Requirement{
+		Pos:     pos,
+		Editor:  e,
+		Version: v,
+		Feature: feature,
+	})
+}
+
+// addSyntax adds the requirements of the syntax feature.
+func (c *checker) addSyntax(g guard, pos ast.Pos, feature string) {
+	s := syntax[feature]
+	c.add(g, pos, builtin.Vim, s.vim, feature)
+	c.add(g, pos, builtin.Neovim, s.neovim, feature)
+}
+
+func (c *checker) visit(n ast.Node, g guard) {
+	switch n := n.(type) {
+	case *ast.MethodExpr:
+		c.addSyntax(g, n.Method.Pos(), "method call")
+		c.call(n.Method, g)
+	case *ast.CallExpr:
+		c.call(n.Fun, g)
+	case *ast.LambdaExpr:
+		c.addSyntax(g, n.Pos(), "lambda")
+	case *ast.HeredocExpr:
+		c.addSyntax(g, n.Pos(), "heredoc")
+	case *ast.Dict:
+		if n.Literal {
+			c.addSyntax(g, n.Pos(), "literal dictionary")
+		}
+	case *ast.BasicLit:
+		if n.Kind == token.BLOB {
+			c.addSyntax(g, n.Pos(), "blob literal")
+		}
+	case *ast.BinaryExpr:
+		if n.Op == token.DOTDOT {
+			c.addSyntax(g, n.Pos(), "string concatenation with ..")
+		}
+	case *ast.Let:
+		if n.Op == "..=" {
+			c.addSyntax(g, n.Pos(), "string concatenation with ..")
+		}
+	}
+	if n, ok := n.(ast.ExCommand); ok {
+		c.command(n, g)
+	}
+}
+
+// command adds the requirements of the Ex command n.
+func (c *checker) command(n ast.ExCommand, g guard) {
+	cmdname := cmdName(n)
+	if cmdname == "" {
+		return
+	}
+	name := ":" + cmdname
+	if _, ok := syntax[name]; ok {
+		c.addSyntax(g, n.Pos(), name)
+	} else if cmd := builtin.LookupCommand(cmdname); cmd != nil {
+		c.add(g, n.Pos(), sinceEditor(cmd.Editors), cmd.Since, name, name)
+	}
+}
+
+// cmdName returns the name of the Ex command n, or "" if n only has a range
+// or modifiers, e.g. ":5".
+func cmdName(n ast.ExCommand) string {
+	if e, ok := n.(*ast.Excmd); ok && e.ExArg.Cmd == nil {
+		return ""
+	}
+	return n.Cmd().Name
+}
+
+// call adds the requirements of the builtin function called by fun.
+// Funcref variables with such names are not builtin functions.
+func (c *checker) call(fun ast.Expr, g guard) {
+	id, ok := fun.(*ast.Ident)
+	if !ok || strings.ContainsAny(id.Name, ":#") || c.info.Uses[id] != nil {
+		return
+	}
+	fn := builtin.LookupFunction(id.Name)
+	if fn == nil {
+		return
+	}
+	c.add(g, id.Pos(), sinceEditor(fn.Editors), fn.Since, fn.Name+"()", "*"+fn.Name)
+}
+
+// sinceEditor returns the editor whose version is Since of the builtin
+// commands and functions of editors e.
+func sinceEditor(e builtin.Editor) builtin.Editor {
+	if e == builtin.Neovim {
+		return builtin.Neovim
+	}
+	return builtin.Vim
+}
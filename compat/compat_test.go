@@ -0,0 +1,151 @@
+package compat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/builtin"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Version
+		str  string
+	}{
+		{"8.2", Version{8, 2, 0}, "8.2.0000"},
+		{"8.1.0735", Version{8, 1, 735}, "8.1.0735"},
+		{"7.4.2044", Version{7, 4, 2044}, "7.4.2044"},
+		{"0.5.0", Version{0, 5, 0}, "0.5.0"},
+	}
+	for _, tt := range tests {
+		got, err := ParseVersion(tt.in)
+		if err != nil || got != tt.want || got.String() != tt.str {
+			t.Errorf("ParseVersion(%q) = %v, %v; want %v", tt.in, got, err, tt.str)
+		}
+	}
+	for _, in := range []string{"", "8", "8.2.1.1", "8.x", "8.-1"} {
+		if _, err := ParseVersion(in); err == nil {
+			t.Errorf("ParseVersion(%q) succeeded", in)
+		}
+	}
+}
+
+func TestVersion_Less(t *testing.T) {
+	versions := []Version{{0, 4, 0}, {0, 5, 0}, {7, 4, 2044}, {8, 0, 0}, {8, 1, 735}, {8, 1, 1803}, {8, 2, 0}}
+	for i, v := range versions {
+		for j, w := range versions {
+			if got := v.Less(w); got != (i < j) {
+				t.Errorf("%v.Less(%v) = %v", v, w, got)
+			}
+		}
+	}
+}
+
+func minVersion(t *testing.T, src string) *Report {
+	t.Helper()
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return MinVersion(f)
+}
+
+func TestMinVersion(t *testing.T) {
+	r := minVersion(t, `let s:n = [1]->len()
+const s:b = 0z00
+let s:d = #{a: 1}
+let s:e = {'a': 1}
+let s:F = {x -> x}
+eval s:F(1)
+let s:s = 'a' .. 'b'
+let s:h =<< END
+END
+call popup_create('a', {})
+call jobstart('ls')
+function! s:f(strlen) abort
+  let l:Len = function('len')
+  return l:Len(a:strlen)
+endfunction
+cdo s/a/b/
+`)
+	var got []string
+	for _, req := range r.Since(builtin.Vim, Version{8, 0, 0}) {
+		got = append(got, fmt.Sprintf("%d:%d: %s %v", req.Pos.Line, req.Pos.Column, req.Feature, req.Version))
+	}
+	for _, req := range r.Since(builtin.Neovim, Version{}) {
+		got = append(got, fmt.Sprintf("%d:%d: %s nvim %v", req.Pos.Line, req.Pos.Column, req.Feature, req.Version))
+	}
+	want := []string{
+		"1:16: method call 8.1.1803",
+		"2:1: :const 8.1.1539",
+		"2:13: blob literal 8.1.0735",
+		"3:11: literal dictionary 8.1.1705",
+		"6:1: :eval 8.1.1807",
+		"7:15: string concatenation with .. 8.1.1114",
+		"8:1: heredoc 8.1.1354",
+		"10:6: popup_create() 8.1.1391",
+		"1:16: method call nvim 0.5.0",
+		"2:1: :const nvim 0.4.0",
+		"2:13: blob literal nvim 0.5.0",
+		"3:11: literal dictionary nvim 0.5.0",
+		"5:11: lambda nvim 0.2.0",
+		"6:1: :eval nvim 0.5.0",
+		"7:15: string concatenation with .. nvim 0.4.0",
+		"8:1: heredoc nvim 0.4.0",
+		"11:6: jobstart() nvim 0.1.0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if r.Vim.Feature != ":eval" || r.Neovim.Feature != "method call" {
+		t.Errorf("newest: Vim %s, Neovim %s", r.Vim.Feature, r.Neovim.Feature)
+	}
+}
+
+func TestMinVersion_guard(t *testing.T) {
+	r := minVersion(t, `echo exists('*popup_create') ? popup_create('z', {}) : 0
+if has('patch-8.1.1803')
+  echo [1]->len()
+endif
+if !has('patch-8.1.1539')
+  finish
+endif
+const s:x = 1
+if exists(':cdo') && has('nvim')
+  cdo s/a/b/
+  call jobstart('ls')
+endif
+eval s:x
+`)
+	var got []string
+	for _, req := range r.Since(builtin.Vim, Version{8, 0, 0}) {
+		got = append(got, fmt.Sprintf("%d:%d: %s %v", req.Pos.Line, req.Pos.Column, req.Feature, req.Version))
+	}
+	for _, req := range r.Since(builtin.Neovim, Version{}) {
+		got = append(got, fmt.Sprintf("%d:%d: %s nvim %v", req.Pos.Line, req.Pos.Column, req.Feature, req.Version))
+	}
+	want := []string{
+		"13:1: :eval 8.1.1807",
+		"3:13: method call nvim 0.5.0",
+		"8:1: :const nvim 0.4.0",
+		"11:8: jobstart() nvim 0.1.0",
+		"13:1: :eval nvim 0.5.0",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestMinVersion_old(t *testing.T) {
+	r := minVersion(t, "let s:x = strlen('abc')\necho s:x\n:5\n")
+	if r.Neovim != nil {
+		t.Errorf("Neovim = %+v", r.Neovim)
+	}
+	if r.Vim == nil || (Version{8, 0, 0}).Less(r.Vim.Version) {
+		t.Errorf("Vim = %+v", r.Vim)
+	}
+}
@@ -0,0 +1,269 @@
+package compat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/scope"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// Problem is a use of a feature which an editor doesn't have.
+type Problem struct {
+	Pos     ast.Pos        // position of the use
+	Missing builtin.Editor // editors which don't have the feature
+	Feature string         // e.g. ":shell", "popup_create()" and "&pyxversion"
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%v: %s is not available in %s", p.Pos, p.Feature, p.Missing)
+}
+
+// Portability returns the uses of commands, functions and options in f
+// which Vim or Neovim doesn't have, sorted by the positions. f should be
+// parsed with ParseOption.AllCommands to accept the commands of both
+// editors.
+//
+// Code which runs only in the editors which have the feature is not
+// reported. The conditions of :if, :elseif and the ternary operator are
+// understood if they are has('nvim') and exists() of the feature, e.g.
+// exists('*popup_create'), possibly negated or combined with &&. The rest
+// of the body after an :if which ends with :finish or :return runs only
+// if the condition is false.
+func Portability(f *ast.File) []*Problem {
+	p := &portability{info: scope.Resolve(f)}
+	inspectGuarded(f.Body, guard{editors: builtin.Both}, p.check)
+	return p.probs
+}
+
+// guard is what is known when code runs.
+type guard struct {
+	editors builtin.Editor  // editors which may run the code
+	exists  map[string]bool // arguments of exists() which are true
+	patch   Version         // newest Vim patch which has() is true for
+}
+
+// and returns the guard of code which runs under both g and h.
+func (g guard) and(h guard) guard {
+	r := guard{editors: g.editors & h.editors, patch: g.patch}
+	if r.patch.Less(h.patch) {
+		r.patch = h.patch
+	}
+	if len(g.exists)+len(h.exists) > 0 {
+		r.exists = make(map[string]bool)
+		for k := range g.exists {
+			r.exists[k] = true
+		}
+		for k := range h.exists {
+			r.exists[k] = true
+		}
+	}
+	return r
+}
+
+// checked reports whether any of the names is checked by exists().
+func (g guard) checked(names []string) bool {
+	for _, name := range names {
+		if g.exists[name] {
+			return true
+		}
+	}
+	return false
+}
+
+// cond returns the guard of code which runs if x is truthy, or falsy if not
+// truthy.
+func cond(x ast.Expr, truthy bool) guard {
+	unknown := guard{editors: builtin.Both}
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return cond(x.X, truthy)
+	case *ast.UnaryExpr:
+		if x.Op == token.NOT {
+			return cond(x.X, !truthy)
+		}
+	case *ast.BinaryExpr:
+		// a && b is truthy if both are truthy; a || b is falsy if both
+		// are falsy.
+		if x.Op == token.ANDAND && truthy || x.Op == token.OROR && !truthy {
+			return cond(x.Left, truthy).and(cond(x.Right, truthy))
+		}
+	case *ast.CallExpr:
+		id, ok := x.Fun.(*ast.Ident)
+		if !ok || len(x.Args) != 1 {
+			break
+		}
+		arg, ok := stringLit(x.Args[0])
+		if !ok {
+			break
+		}
+		switch {
+		case id.Name == "has" && (arg == "nvim" || strings.HasPrefix(arg, "nvim-")):
+			if truthy {
+				return guard{editors: builtin.Neovim}
+			} else if arg == "nvim" {
+				return guard{editors: builtin.Vim}
+			}
+		case id.Name == "has" && strings.HasPrefix(arg, "patch-") && truthy:
+			if v, err := ParseVersion(arg[len("patch-"):]); err == nil {
+				return guard{editors: builtin.Both, patch: v}
+			}
+		case id.Name == "exists" && truthy:
+			return guard{editors: builtin.Both, exists: map[string]bool{arg: true}}
+		}
+	}
+	return unknown
+}
+
+// stringLit returns the value of the string literal x.
+func stringLit(x ast.Expr) (string, bool) {
+	lit, ok := x.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING || len(lit.Value) < 2 {
+		return "", false
+	}
+	return lit.Value[1 : len(lit.Value)-1], true
+}
+
+// inspectGuarded traverses the statements under g and calls f for each
+// node with the guard of the node. Nodes which no editor runs are skipped.
+// The rest of the statements after an :if which exits run only if the
+// condition is false.
+func inspectGuarded(stmts []ast.Statement, g guard, f func(ast.Node, guard)) {
+	for _, s := range stmts {
+		ast.Walk(&visitor{g, f}, s)
+		if n, ok := s.(*ast.If); ok && len(n.ElseIf) == 0 && n.Else == nil && exits(n.Body) {
+			g = g.and(cond(n.Condition, false))
+		}
+	}
+}
+
+// visitor visits nodes under a guard.
+type visitor struct {
+	g guard
+	f func(ast.Node, guard)
+}
+
+// exits reports whether the body ends with :finish or :return.
+func exits(body []ast.Statement) bool {
+	if len(body) == 0 {
+		return false
+	}
+	switch n := body[len(body)-1].(type) {
+	case *ast.Return:
+		return true
+	case *ast.Excmd:
+		return cmdName(n) == "finish"
+	}
+	return false
+}
+
+func (v *visitor) Visit(n ast.Node) ast.Visitor {
+	if v.g.editors == 0 {
+		// no editor runs the code.
+		return nil
+	}
+	v.f(n, v.g)
+	switch n := n.(type) {
+	case *ast.Function:
+		ast.Walk(v, n.Name)
+		for _, x := range n.DefaultArgs {
+			ast.Walk(v, x)
+		}
+		inspectGuarded(n.Body, v.g, v.f)
+		return nil
+	case *ast.If:
+		ast.Walk(v, n.Condition)
+		g := v.g
+		inspectGuarded(n.Body, g.and(cond(n.Condition, true)), v.f)
+		g = g.and(cond(n.Condition, false))
+		for _, e := range n.ElseIf {
+			v.f(e, g)
+			ast.Walk(&visitor{g, v.f}, e.Condition)
+			inspectGuarded(e.Body, g.and(cond(e.Condition, true)), v.f)
+			g = g.and(cond(e.Condition, false))
+		}
+		if n.Else != nil {
+			inspectGuarded(n.Else.Body, g, v.f)
+		}
+		return nil
+	case *ast.TernaryExpr:
+		ast.Walk(v, n.Condition)
+		ast.Walk(&visitor{v.g.and(cond(n.Condition, true)), v.f}, n.Left)
+		ast.Walk(&visitor{v.g.and(cond(n.Condition, false)), v.f}, n.Right)
+		return nil
+	}
+	return v
+}
+
+type portability struct {
+	info  *scope.Info
+	probs []*Problem
+}
+
+// check checks the node n which runs under g.
+func (p *portability) check(n ast.Node, g guard) {
+	if c, ok := n.(ast.ExCommand); ok {
+		p.command(c, g)
+	}
+	switch n := n.(type) {
+	case *ast.CallExpr:
+		p.function(n.Fun, g)
+	case *ast.MethodExpr:
+		p.function(n.Method, g)
+	case *ast.BasicLit:
+		if n.Kind == token.OPTION {
+			name := strings.TrimPrefix(n.Value, "&")
+			if len(name) > 2 && name[1] == ':' {
+				name = name[2:]
+			}
+			p.option(n.Pos(), name, g)
+		}
+	case *ast.Set:
+		for _, o := range n.Options {
+			if o.Full != "" {
+				p.option(o.Pos, o.Full, g)
+			}
+		}
+	}
+}
+
+// report reports the feature if the editors which may run the code under g
+// don't have it and it's not checked by exists() with any of the names.
+func (p *portability) report(g guard, pos ast.Pos, editors builtin.Editor, feature string, names ...string) {
+	missing := g.editors &^ editors
+	if missing == 0 || g.checked(names) {
+		return
+	}
+	p.probs = append(p.probs, &Problem{Pos: pos, Missing: missing, Feature: feature})
+}
+
+func (p *portability) command(n ast.ExCommand, g guard) {
+	name := cmdName(n)
+	if name == "" {
+		return
+	}
+	if cmd := builtin.LookupCommand(name); cmd != nil {
+		p.report(g, n.Pos(), cmd.Editors, ":"+name, ":"+name)
+	}
+}
+
+// function checks the builtin function called by fun. Funcref variables
+// with such names are not builtin functions.
+func (p *portability) function(fun ast.Expr, g guard) {
+	id, ok := fun.(*ast.Ident)
+	if !ok || strings.ContainsAny(id.Name, ":#") || p.info.Uses[id] != nil {
+		return
+	}
+	if fn := builtin.LookupFunction(id.Name); fn != nil {
+		p.report(g, id.Pos(), fn.Editors, fn.Name+"()", "*"+fn.Name)
+	}
+}
+
+// option checks the option name, which is the full or short name.
+func (p *portability) option(pos ast.Pos, name string, g guard) {
+	if o := builtin.LookupOption(name); o != nil {
+		p.report(g, pos, o.Editors, "&"+o.Name, "&"+name, "+"+name, "&"+o.Name, "+"+o.Name)
+	}
+}
@@ -0,0 +1,92 @@
+package compat
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+)
+
+func TestPortability(t *testing.T) {
+	src := `shell
+rshada
+call popup_create('a', {})
+call jobstart('ls')
+echo &pyxversion &l:winhighlight
+set termguicolors wincolor=Error
+if has('nvim')
+  call jobstart('ls')
+  set winhighlight=
+  tearoff File
+elseif exists('*popup_create')
+  call popup_create('a', {})
+  call jobstart('ls')
+else
+  call job_start('ls')
+endif
+if !has('nvim') && exists(':tearoff')
+  tearoff File
+endif
+let s:job = has('nvim') ? jobstart('ls') : job_start('ls')
+function! s:f() abort
+  if !has('nvim')
+    return
+  endif
+  call nvim_buf_get_lines(0, 0, -1, v:true)
+  let l:Popup = function('popup_create')
+  call l:Popup('a', {})
+endfunction
+if has('nvim') && !has('nvim')
+  shell
+endif
+if has('nvim')
+  finish
+endif
+call popup_create('a', {})
+nnoremap x <Cmd>wshada<CR>
+`
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", &vimlparser.ParseOption{AllCommands: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range Portability(f) {
+		got = append(got, p.String())
+	}
+	want := []string{
+		"1:1: :shell is not available in Neovim",
+		"2:1: :rshada is not available in Vim",
+		"3:6: popup_create() is not available in Neovim",
+		"4:6: jobstart() is not available in Vim",
+		"5:18: &winhighlight is not available in Vim",
+		"6:19: &wincolor is not available in Neovim",
+		"10:3: :tearoff is not available in Neovim",
+		"13:8: jobstart() is not available in Vim",
+		"36:17: :wshada is not available in Vim",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestPortability_range(t *testing.T) {
+	src := `if has('nvim')
+  :5
+endif
+:1,3
+call popup_create('a', {})
+`
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", &vimlparser.ParseOption{AllCommands: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range Portability(f) {
+		got = append(got, p.String())
+	}
+	want := []string{"5:6: popup_create() is not available in Neovim"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,60 @@
+package compat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a version of Vim or Neovim, e.g. Vim 8.2.1234 and Neovim 0.5.0.
+// The patch level of Vim is Patch.
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion parses s in the form of "major.minor[.patch]", e.g. "8.2",
+// "8.2.1234" and "0.5.0".
+func ParseVersion(s string) (Version, error) {
+	fields := strings.Split(s, ".")
+	if len(fields) < 2 || len(fields) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+	var nums [3]int
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return Version{}, fmt.Errorf("invalid version %q", s)
+		}
+		nums[i] = n
+	}
+	return Version{nums[0], nums[1], nums[2]}, nil
+}
+
+// mustParse is ParseVersion for the versions in the tables.
+func mustParse(s string) Version {
+	v, err := ParseVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Less reports whether v is older than w.
+func (v Version) Less(w Version) bool {
+	if v.Major != w.Major {
+		return v.Major < w.Major
+	}
+	if v.Minor != w.Minor {
+		return v.Minor < w.Minor
+	}
+	return v.Patch < w.Patch
+}
+
+// String returns v in the form of "major.minor.patch". The patch levels of
+// Vim 8 and later have four digits as in the release notes, e.g. "8.1.0735".
+func (v Version) String() string {
+	if v.Major >= 8 {
+		return fmt.Sprintf("%d.%d.%04d", v.Major, v.Minor, v.Patch)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
@@ -25,6 +25,12 @@ type Compiler struct {
 	// Current state
 	buffer *bytes.Buffer // raw compiler result
 	indent int           // current indentation
+	err    error         // error in statements of expressions
+
+	// comments are the trailing comments of statements. They are compiled
+	// after the statements as vim-vimlparser, or at the beginning of the
+	// bodies of blocks for the first lines of the blocks.
+	comments map[ast.Node][]*ast.Comment
 }
 
 // Compile compiles node and writes to writer.
@@ -35,9 +41,13 @@ func Compile(w io.Writer, node ast.Node) error {
 // Compile compiles node and writes to writer.
 func (c *Compiler) Compile(w io.Writer, node ast.Node) error {
 	c.buffer = bytes.NewBuffer(make([]byte, 0))
+	c.err = nil
 	if err := c.compile(node); err != nil {
 		return err
 	}
+	if c.err != nil {
+		return c.err
+	}
 	if _, err := io.Copy(w, c.buffer); err != nil {
 		return err
 	}
@@ -71,25 +81,38 @@ func (c *Compiler) compile(node interface{}) error {
 		return c.compileComment(n)
 	case ast.ExCommand:
 		return c.compileExcommand(n)
+	case *ast.Assign:
+		c.compileAssign(n)
+	case *ast.ExprStmt:
+		c.fprintln("%s", c.compileExpr(n.X))
 	case []ast.Statement:
 		for _, s := range n {
 			if err := c.compile(s); err != nil {
 				return err
 			}
+			c.compileTrailingComments(s)
 		}
 	case ast.Expr:
 		c.fprint(c.compileExpr(n))
+	default:
+		return fmt.Errorf("compile: unexpected Node: %T", n)
 	}
 	return nil
 }
 
 func (c *Compiler) compileFile(node *ast.File) error {
-	for _, stmt := range node.Body {
-		if err := c.compile(stmt); err != nil {
-			return err
+	c.comments = make(map[ast.Node][]*ast.Comment)
+	for n, groups := range ast.NewCommentMap(node, node.Comments) {
+		for _, g := range groups {
+			for _, comment := range g.List {
+				// "\ comments in line continuations are not statements.
+				if !strings.HasPrefix(comment.Text, `\ `) {
+					c.comments[n] = append(c.comments[n], comment)
+				}
+			}
 		}
 	}
-	return nil
+	return c.compile(node.Body)
 }
 
 func (c *Compiler) compileComment(node *ast.Comment) error {
@@ -97,12 +120,56 @@ func (c *Compiler) compileComment(node *ast.Comment) error {
 	return nil
 }
 
+// compileComments compiles the trailing comments of n.
+func (c *Compiler) compileComments(n ast.Node) {
+	for _, comment := range c.comments[n] {
+		c.compileComment(comment)
+	}
+}
+
+// compileTrailingComments compiles the trailing comments after the statement
+// s, which are of the end of the block or of s and the statements in s, e.g.
+// the commands of :autocmd.
+func (c *Compiler) compileTrailingComments(s ast.Statement) {
+	switch s := s.(type) {
+	case *ast.Function:
+		c.compileComments(s.EndFunction)
+	case *ast.Def:
+		c.compileComments(s.EndDef)
+	case *ast.Class:
+		c.compileComments(s.EndClass)
+	case *ast.If:
+		c.compileComments(s.EndIf)
+	case *ast.While:
+		c.compileComments(s.EndWhile)
+	case *ast.For:
+		c.compileComments(s.EndFor)
+	case *ast.Try:
+		c.compileComments(s.EndTry)
+	default:
+		ast.Inspect(s, func(n ast.Node) bool {
+			c.compileComments(n)
+			return true
+		})
+	}
+}
+
 func (c *Compiler) compileExcommand(node ast.ExCommand) error {
 	switch n := node.(type) {
 	case *ast.Excmd:
 		c.compileExcmd(n)
+	case ast.ExcmdNode:
+		c.fprintln(`(excmd "%s")`, escape(n.ExcmdText(), `\"`))
 	case *ast.Function:
-		c.compileFunction(n)
+		return c.compileFunction(n)
+	case *ast.Def:
+		return c.compileDef(n)
+	case *ast.Class:
+		return c.compileClass(n)
+	case *ast.VarDecl:
+		c.compileVarDecl(n)
+	case *ast.Import:
+		c.compileImport(n)
 	case *ast.DelFunction:
 		c.compileDelfunction(n)
 	case *ast.Return:
@@ -118,15 +185,15 @@ func (c *Compiler) compileExcommand(node ast.ExCommand) error {
 	case *ast.UnLockVar:
 		c.compileUnlockvar(n)
 	case *ast.If:
-		c.compileIf(n)
+		return c.compileIf(n)
 	case *ast.While:
-		c.compileWhile(n)
+		return c.compileWhile(n)
 	case *ast.For:
-		c.compileFor(n)
+		return c.compileFor(n)
 	case *ast.Continue, *ast.Break:
 		c.compileSingleCmd(n)
 	case *ast.Try:
-		c.compileTry(n)
+		return c.compileTry(n)
 	case *ast.Throw:
 		c.compileThrow(n)
 	case *ast.Eval:
@@ -138,8 +205,10 @@ func (c *Compiler) compileExcommand(node ast.ExCommand) error {
 	case *ast.Execute:
 		c.compileExecute(n)
 	case *ast.EndFor, *ast.EndIf, *ast.Finally, *ast.EndFunction, *ast.EndTry,
-		*ast.EndWhile, *ast.Catch, *ast.Else, *ast.ElseIf:
+		*ast.EndWhile, *ast.Catch, *ast.Else, *ast.ElseIf, *ast.EndDef, *ast.EndClass:
 		return fmt.Errorf("compileExcommand: unexpected Node: %v", n)
+	default:
+		return fmt.Errorf("compileExcommand: unexpected Node: %T", n)
 	}
 	return nil
 }
@@ -148,7 +217,7 @@ func (c *Compiler) compileExcmd(node *ast.Excmd) {
 	c.fprintln(`(excmd "%s")`, escape(node.Command, `\"`))
 }
 
-func (c *Compiler) compileFunction(node *ast.Function) {
+func (c *Compiler) compileFunction(node *ast.Function) error {
 	c.fprint("(function (%s", c.compileExpr(node.Name))
 	if len(node.Params) > 0 {
 		c.fprint(" ")
@@ -173,10 +242,14 @@ func (c *Compiler) compileFunction(node *ast.Function) {
 	}
 	c.fprintln(")")
 	c.indent++
-	c.compile(node.Body)
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
 	c.trimLineBreak()
 	c.writeString(")\n")
 	c.indent--
+	return nil
 }
 
 func (c *Compiler) compileDelfunction(node *ast.DelFunction) {
@@ -197,24 +270,28 @@ func (c *Compiler) compileExcall(node *ast.ExCall) {
 
 func (c *Compiler) compileLet(node *ast.Let) {
 	cmd := node.Cmd().Name
-	lhs := ""
-	if node.Left != nil {
-		lhs = c.compileExpr(node.Left)
-	} else {
-		ls := make([]string, 0, len(node.List))
-		for _, n := range node.List {
-			ls = append(ls, c.compileExpr(n))
-		}
-		rest := ""
-		if node.Rest != nil {
-			rest = " . " + c.compileExpr(node.Rest)
-		}
-		lhs = fmt.Sprintf("(%s%s)", strings.Join(ls, " "), rest)
-	}
+	lhs := c.compileLhs(node.Left, node.List, node.Rest)
 	rhs := c.compileExpr(node.Right)
 	c.fprintln("(%s %s %s %s)", cmd, node.Op, lhs, rhs)
 }
 
+// compileLhs compiles the variable of :let, or the list of variables with
+// the rest if left is nil.
+func (c *Compiler) compileLhs(left ast.Expr, list []ast.Expr, rest ast.Expr) string {
+	if left != nil {
+		return c.compileExpr(left)
+	}
+	ls := make([]string, 0, len(list))
+	for _, n := range list {
+		ls = append(ls, c.compileExpr(n))
+	}
+	r := ""
+	if rest != nil {
+		r = " . " + c.compileExpr(rest)
+	}
+	return fmt.Sprintf("(%s%s)", strings.Join(ls, " "), r)
+}
+
 func (c *Compiler) compileUnlet(node *ast.UnLet) {
 	cmd := node.Cmd().Name
 	list := make([]string, 0, len(node.List))
@@ -250,39 +327,53 @@ func (c *Compiler) compileUnlockvar(node *ast.UnLockVar) {
 	}
 }
 
-func (c *Compiler) compileIf(node *ast.If) {
+func (c *Compiler) compileIf(node *ast.If) error {
 	cmd := node.Cmd().Name
 	c.fprintln("(%s %s", cmd, c.compileExpr(node.Condition))
 	c.indent++
-	c.compile(node.Body)
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
 	c.indent--
 	for _, n := range node.ElseIf {
 		c.fprintln(" %s %s", n.Cmd().Name, c.compileExpr(n.Condition))
 		c.indent++
-		c.compile(n.Body)
+		c.compileComments(n)
+		if err := c.compile(n.Body); err != nil {
+			return err
+		}
 		c.indent--
 	}
 	if node.Else != nil {
 		c.fprintln(" %s", node.Else.Cmd().Name)
 		c.indent++
-		c.compile(node.Else.Body)
+		c.compileComments(node.Else)
+		if err := c.compile(node.Else.Body); err != nil {
+			return err
+		}
 		c.indent--
 	}
 	c.trimLineBreak()
 	c.writeString(")\n")
+	return nil
 }
 
-func (c *Compiler) compileWhile(node *ast.While) {
+func (c *Compiler) compileWhile(node *ast.While) error {
 	cmd := node.Cmd().Name
 	c.fprintln("(%s %s", cmd, c.compileExpr(node.Condition))
 	c.indent++
-	c.compile(node.Body)
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
 	c.trimLineBreak()
 	c.writeString(")\n")
 	c.indent--
+	return nil
 }
 
-func (c *Compiler) compileFor(node *ast.For) {
+func (c *Compiler) compileFor(node *ast.For) error {
 	cmd := node.Cmd().Name
 	left := ""
 	if node.Left != nil {
@@ -301,41 +392,58 @@ func (c *Compiler) compileFor(node *ast.For) {
 	right := c.compileExpr(node.Right)
 	c.fprintln("(%s %s %s", cmd, left, right)
 	c.indent++
-	c.compile(node.Body)
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
 	c.trimLineBreak()
 	c.writeString(")\n")
 	c.indent--
+	return nil
 }
 
 func (c *Compiler) compileSingleCmd(node ast.ExCommand) {
 	c.fprintln("(%s)", node.Cmd().Name)
 }
 
-func (c *Compiler) compileTry(node *ast.Try) {
+func (c *Compiler) compileTry(node *ast.Try) error {
 	c.fprintln("(%s", node.Cmd().Name)
 	c.indent++
-	c.compile(node.Body)
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
 	for _, n := range node.Catch {
 		c.indent--
 		if n.Pattern != "" {
 			c.fprintln(" %s /%s/", n.Cmd().Name, n.Pattern)
 			c.indent++
-			c.compile(n.Body)
+			c.compileComments(n)
+			if err := c.compile(n.Body); err != nil {
+				return err
+			}
 		} else {
 			c.fprintln(" %s", n.Cmd().Name)
 			c.indent++
-			c.compile(n.Body)
+			c.compileComments(n)
+			if err := c.compile(n.Body); err != nil {
+				return err
+			}
 		}
 	}
 	if node.Finally != nil {
 		c.indent--
 		c.fprintln(" %s", node.Finally.Cmd().Name)
 		c.indent++
-		c.compile(node.Finally.Body)
+		c.compileComments(node.Finally)
+		if err := c.compile(node.Finally.Body); err != nil {
+			return err
+		}
 	}
 	c.trimLineBreak()
 	c.writeString(")\n")
 	c.indent--
+	return nil
 }
 
 func (c *Compiler) compileThrow(node *ast.Throw) {
@@ -371,6 +479,120 @@ func (c *Compiler) compileExecute(node *ast.Execute) {
 	c.fprintln("(%s %s)", cmd, strings.Join(list, " "))
 }
 
+func (c *Compiler) compileDef(node *ast.Def) error {
+	c.fprintln("(%s (%s)", node.Cmd().Name, strings.TrimSpace(c.compileName(node.Name)+" "+c.compileParams(node.Params)))
+	c.indent++
+	c.compileComments(node)
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
+	c.trimLineBreak()
+	c.writeString(")\n")
+	c.indent--
+	return nil
+}
+
+// compileName compiles the name of :def, which is nil for the body of
+// lambda.
+func (c *Compiler) compileName(name ast.Expr) string {
+	if name == nil {
+		return ""
+	}
+	return c.compileExpr(name)
+}
+
+// compileParams compiles the parameters of :def and lambda of Vim9 script
+// as :function, without the types.
+func (c *Compiler) compileParams(params []*ast.Param) string {
+	ps := make([]string, 0, len(params))
+	for _, p := range params {
+		switch {
+		case p.Variadic:
+			ps = append(ps, ". "+p.Name.Name)
+		case p.Default != nil:
+			ps = append(ps, fmt.Sprintf("(%s %s)", p.Name.Name, c.compileExpr(p.Default)))
+		default:
+			ps = append(ps, p.Name.Name)
+		}
+	}
+	return strings.Join(ps, " ")
+}
+
+func (c *Compiler) compileClass(node *ast.Class) error {
+	c.fprint("(%s %s", node.Cmd().Name, node.Name.Name)
+	if node.Extends != nil {
+		c.writeString(fmt.Sprintf(" (extends %s)", c.compileExpr(node.Extends)))
+	}
+	if len(node.Implements) > 0 {
+		c.writeString(fmt.Sprintf(" (implements %s)", c.compileExprList(node.Implements)))
+	}
+	c.writeString("\n")
+	c.indent++
+	c.compileComments(node)
+	if len(node.Values) > 0 {
+		c.fprintln("(values %s)", c.compileExprList(node.Values))
+	}
+	if err := c.compile(node.Body); err != nil {
+		return err
+	}
+	c.trimLineBreak()
+	c.writeString(")\n")
+	c.indent--
+	return nil
+}
+
+func (c *Compiler) compileVarDecl(node *ast.VarDecl) {
+	cmd := node.Cmd().Name
+	lhs := c.compileLhs(node.Left, node.List, node.Rest)
+	if node.Right == nil {
+		c.fprintln("(%s %s)", cmd, lhs)
+		return
+	}
+	c.fprintln("(%s = %s %s)", cmd, lhs, c.compileExpr(node.Right))
+}
+
+func (c *Compiler) compileAssign(node *ast.Assign) {
+	lhs := c.compileLhs(node.Left, node.List, node.Rest)
+	if node.Right == nil {
+		c.fprintln("(assign %s %s)", node.Op, lhs)
+		return
+	}
+	c.fprintln("(assign %s %s %s)", node.Op, lhs, c.compileExpr(node.Right))
+}
+
+func (c *Compiler) compileImport(node *ast.Import) {
+	cmd := node.Cmd().Name
+	if node.Autoload {
+		cmd += " autoload"
+	}
+	if node.As != nil {
+		c.fprintln("(%s %s %s)", cmd, c.compileExpr(node.Path), node.As.Name)
+	} else {
+		c.fprintln("(%s %s)", cmd, c.compileExpr(node.Path))
+	}
+}
+
+func (c *Compiler) compileExprList(list []ast.Expr) string {
+	xs := make([]string, 0, len(list))
+	for _, x := range list {
+		xs = append(xs, c.compileExpr(x))
+	}
+	return strings.Join(xs, " ")
+}
+
+// compileBlock compiles the statements of block in a line.
+func (c *Compiler) compileBlock(body []ast.Statement) string {
+	b := &Compiler{buffer: new(bytes.Buffer)}
+	if err := b.compile(body); err != nil && c.err == nil {
+		c.err = err
+	}
+	if b.err != nil && c.err == nil {
+		c.err = b.err
+	}
+	lines := strings.Split(strings.TrimRight(b.buffer.String(), "\n"), "\n")
+	return fmt.Sprintf("(block %s)", strings.Join(lines, " "))
+}
+
 func (c *Compiler) compileExpr(node ast.Expr) string {
 	switch n := node.(type) {
 	case *ast.TernaryExpr:
@@ -382,7 +604,7 @@ func (c *Compiler) compileExpr(node ast.Expr) string {
 		l := c.compileExpr(n.Left)
 		r := c.compileExpr(n.Right)
 		op := n.Op.String()
-		if op == "." {
+		if n.Op == token.DOT || n.Op == token.DOTDOT {
 			op = "concat"
 		}
 		return fmt.Sprintf("(%s %s %s)", op, l, r)
@@ -488,6 +710,19 @@ func (c *Compiler) compileExpr(node ast.Expr) string {
 		return fmt.Sprintf(`(heredoc %s "%s" %s)`, flags, n.EndMarker, body)
 	case *ast.ParenExpr:
 		return c.compileExpr(n.X)
+	case *ast.Vim9LambdaExpr:
+		if n.Body != nil {
+			return fmt.Sprintf("(lambda (%s) %s)", c.compileParams(n.Params), c.compileBlock(n.Body))
+		}
+		return fmt.Sprintf("(lambda (%s) %s)", c.compileParams(n.Params), c.compileExpr(n.Expr))
+	case *ast.CastExpr:
+		return fmt.Sprintf("(cast %s %s)", n.Type.Name, c.compileExpr(n.X))
+	case *ast.InterpolatedString:
+		return n.Value
+	case *ast.BadExpr:
+		return n.Text
+	case *ast.Placeholder:
+		return "<" + n.Name + ">"
 	}
 	return ""
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
 )
 
 var skipTests = map[string]bool{
@@ -35,6 +36,25 @@ func TestCompiler_Compile(t *testing.T) {
 	}
 }
 
+// TestCompiler_Compile_vim9 compiles the files which vim-vimlparser can't
+// parse, e.g. Vim9 script.
+func TestCompiler_Compile_vim9(t *testing.T) {
+	vimfiles, err := filepath.Glob("testdata/*.vim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, vimfile := range vimfiles {
+		testFile(t, vimfile, strings.TrimSuffix(vimfile, ".vim")+".ok")
+	}
+}
+
+func TestCompiler_Compile_unknown(t *testing.T) {
+	f := &ast.File{Body: []ast.Statement{&ast.BadStmt{Text: "x"}}}
+	if err := Compile(ioutil.Discard, f); err == nil {
+		t.Error("Compile(BadStmt) succeeded")
+	}
+}
+
 const okErrPrefix = "vimlparser: "
 
 func testFile(t *testing.T, file, okfile string) {
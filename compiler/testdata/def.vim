@@ -0,0 +1,5 @@
+def Foo()
+  echo 1
+enddef
+let x = 1
+call Foo()
@@ -0,0 +1,31 @@
+vim9script
+# comment
+import autoload "foo.vim" as bar
+export def Foo(x: number, y = 2, ...rest: list<any>): number
+  var a: number = x
+  final [b, c; d] = [1, 2, 3]
+  a += 1
+  ++a
+  Bar(a)
+  var F = (v) => v + 1
+  var G = (v) => {
+    return v
+  }
+  var n = <number>$"a{a}"
+  return a
+enddef
+class A extends B implements I, J
+  var x: number
+  def new()
+  enddef
+endclass
+enum E
+  X, Y
+endenum
+def Bar()
+  var H = (v) => {
+    if v
+      echo 'a  b'
+    endif
+  }
+enddef
@@ -0,0 +1,230 @@
+package eval
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+)
+
+// builtinFunc evaluates a builtin function. xs are the expressions of the
+// arguments for errors.
+type builtinFunc func(call ast.Expr, xs []ast.Expr, args []Value) (Value, error)
+
+// builtinFuncs is the builtin functions which don't depend on the state of
+// the editor.
+var builtinFuncs map[string]builtinFunc
+
+func init() {
+	builtinFuncs = map[string]builtinFunc{
+		"abs":     fnAbs,
+		"and":     bitwise(func(a, b int64) int64 { return a & b }),
+		"empty":   fnEmpty,
+		"invert":  fnInvert,
+		"join":    fnJoin,
+		"keys":    fnKeys,
+		"len":     fnLen,
+		"or":      bitwise(func(a, b int64) int64 { return a | b }),
+		"repeat":  fnRepeat,
+		"string":  fnString,
+		"tolower": caseMapping(unicode.ToLower),
+		"toupper": caseMapping(unicode.ToUpper),
+		"type":    fnType,
+		"values":  fnValues,
+		"xor":     bitwise(func(a, b int64) int64 { return a ^ b }),
+	}
+}
+
+// call calls the function fun with the arguments. recv is the receiver of
+// the method call; or nil.
+func call(x ast.Expr, fun ast.Expr, recv ast.Expr, args []ast.Expr) (Value, error) {
+	id, ok := fun.(*ast.Ident)
+	if !ok || strings.ContainsAny(id.Name, ":#") || id.Name == "" || !('a' <= id.Name[0] && id.Name[0] <= 'z') {
+		return nil, unknown(x, "call of user function")
+	}
+	fn := builtin.LookupFunction(id.Name)
+	if fn == nil {
+		return nil, errorf(x, "E117: Unknown function: %s", id.Name)
+	}
+	if recv != nil {
+		args = append([]ast.Expr{recv}, args...)
+	}
+	switch {
+	case len(args) < fn.MinArgs:
+		return nil, errorf(x, "E119: Not enough arguments for function: %s", fn.Name)
+	case fn.MaxArgs >= 0 && len(args) > fn.MaxArgs:
+		return nil, errorf(x, "E118: Too many arguments for function: %s", fn.Name)
+	}
+	f, ok := builtinFuncs[id.Name]
+	if !ok {
+		return nil, unknown(x, "call of "+id.Name+"()")
+	}
+	values := make([]Value, len(args))
+	for i, arg := range args {
+		v, err := eval(arg)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return f(x, args, values)
+}
+
+func fnAbs(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	if f, ok := args[0].(Float); ok {
+		if f < 0 {
+			return -f, nil
+		}
+		return f, nil
+	}
+	n, msg := toNumber(args[0])
+	if msg != "" {
+		return nil, errorf(xs[0], msg)
+	}
+	if n < 0 {
+		return Number(-n), nil
+	}
+	return Number(n), nil
+}
+
+func bitwise(op func(a, b int64) int64) builtinFunc {
+	return func(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+		var n [2]int64
+		for i := range n {
+			var msg string
+			if n[i], msg = toNumber(args[i]); msg != "" {
+				return nil, errorf(xs[i], msg)
+			}
+		}
+		return Number(op(n[0], n[1])), nil
+	}
+}
+
+func fnInvert(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	n, msg := toNumber(args[0])
+	if msg != "" {
+		return nil, errorf(xs[0], msg)
+	}
+	return Number(^n), nil
+}
+
+func fnEmpty(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	return boolNumber(!truthy(args[0])), nil
+}
+
+func fnJoin(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	l, ok := args[0].(*List)
+	if !ok {
+		return nil, errorf(xs[0], "E1211: List required for argument 1")
+	}
+	sep := " "
+	if len(args) > 1 {
+		var msg string
+		if sep, msg = toString(args[1]); msg != "" {
+			return nil, errorf(xs[1], msg)
+		}
+	}
+	items := make([]string, len(l.Items))
+	for i, v := range l.Items {
+		if s, ok := v.(String); ok {
+			items[i] = string(s)
+		} else {
+			items[i] = Repr(v)
+		}
+	}
+	return String(strings.Join(items, sep)), nil
+}
+
+func fnKeys(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	d, ok := args[0].(*Dict)
+	if !ok {
+		return nil, errorf(xs[0], "E1206: Dictionary required for argument 1")
+	}
+	l := &List{Items: []Value{}}
+	for _, k := range d.Keys() {
+		l.Items = append(l.Items, String(k))
+	}
+	return l, nil
+}
+
+func fnValues(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	d, ok := args[0].(*Dict)
+	if !ok {
+		return nil, errorf(xs[0], "E1206: Dictionary required for argument 1")
+	}
+	l := &List{Items: []Value{}}
+	for _, k := range d.Keys() {
+		v, _ := d.Get(k)
+		l.Items = append(l.Items, v)
+	}
+	return l, nil
+}
+
+func fnLen(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	switch v := args[0].(type) {
+	case Number, String:
+		s, _ := toString(v)
+		return Number(len(s)), nil
+	case *List:
+		return Number(len(v.Items)), nil
+	case *Dict:
+		return Number(v.Len()), nil
+	case *Blob:
+		return Number(len(v.Bytes)), nil
+	}
+	return nil, errorf(xs[0], "E701: Invalid type for len()")
+}
+
+func fnRepeat(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	n, msg := toNumber(args[1])
+	if msg != "" {
+		return nil, errorf(xs[1], msg)
+	}
+	if l, ok := args[0].(*List); ok {
+		r := &List{Items: []Value{}}
+		for i := int64(0); i < n; i++ {
+			r.Items = append(r.Items, l.Items...)
+		}
+		return r, nil
+	}
+	s, msg := toString(args[0])
+	if msg != "" {
+		return nil, errorf(xs[0], msg)
+	}
+	if n <= 0 {
+		return String(""), nil
+	}
+	return String(strings.Repeat(s, int(n))), nil
+}
+
+func fnString(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	return String(Repr(args[0])), nil
+}
+
+func fnType(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+	return Number(args[0].Type()), nil
+}
+
+// caseMapping returns toupper() or tolower(), which map the characters
+// and keep invalid bytes.
+func caseMapping(mapping func(rune) rune) builtinFunc {
+	return func(call ast.Expr, xs []ast.Expr, args []Value) (Value, error) {
+		s, msg := toString(args[0])
+		if msg != "" {
+			return nil, errorf(xs[0], msg)
+		}
+		var b strings.Builder
+		for s != "" {
+			r, n := utf8.DecodeRuneInString(s)
+			if r == utf8.RuneError && n == 1 {
+				b.WriteByte(s[0])
+			} else {
+				b.WriteRune(mapping(r))
+			}
+			s = s[n:]
+		}
+		return String(b.String()), nil
+	}
+}
@@ -0,0 +1,117 @@
+package eval
+
+// Dict is a Dictionary. The keys are stored in a hash table which works as
+// the one of Vim, so that Keys, and string() and keys() of Vim, list them
+// in the same order.
+type Dict struct {
+	slots []*dictItem // nil for empty slots
+	used  int         // number of items
+}
+
+type dictItem struct {
+	key   string
+	hash  uint64
+	value Value
+}
+
+// initSize is the initial size of hash tables, HT_INIT_SIZE of Vim.
+const initSize = 16
+
+// NewDict returns an empty Dictionary.
+func NewDict() *Dict {
+	return &Dict{slots: make([]*dictItem, initSize)}
+}
+
+// Len returns the number of the items.
+func (d *Dict) Len() int {
+	return d.used
+}
+
+// Get returns the value of the key.
+func (d *Dict) Get(key string) (Value, bool) {
+	if d.slots == nil {
+		return nil, false
+	}
+	if item := d.slots[d.lookup(key, hash(key))]; item != nil {
+		return item.value, true
+	}
+	return nil, false
+}
+
+// Set sets the value of the key, adding the key if it doesn't exist.
+func (d *Dict) Set(key string, v Value) {
+	if d.slots == nil {
+		d.slots = make([]*dictItem, initSize)
+	}
+	h := hash(key)
+	i := d.lookup(key, h)
+	if d.slots[i] != nil {
+		d.slots[i].value = v
+		return
+	}
+	d.slots[i] = &dictItem{key: key, hash: h, value: v}
+	d.used++
+	d.mayResize()
+}
+
+// Keys returns the keys in the order of the hash table.
+func (d *Dict) Keys() []string {
+	keys := make([]string, 0, d.used)
+	for _, item := range d.slots {
+		if item != nil {
+			keys = append(keys, item.key)
+		}
+	}
+	return keys
+}
+
+// hash returns the hash of the key like hash_hash() of Vim.
+func hash(key string) uint64 {
+	if key == "" {
+		return 0
+	}
+	h := uint64(key[0])
+	for i := 1; i < len(key); i++ {
+		h = h*101 + uint64(key[i])
+	}
+	return h
+}
+
+// lookup returns the index of the slot which has the key or the empty slot
+// to add it to, probing like hash_lookup() of Vim. Only the low bits of the
+// index matter, so it doesn't have to be truncated to 32 bits as Vim does.
+func (d *Dict) lookup(key string, h uint64) int {
+	mask := uint64(len(d.slots) - 1)
+	idx := h & mask
+	for perturb := h; ; perturb >>= 5 {
+		item := d.slots[idx&mask]
+		if item == nil || item.hash == h && item.key == key {
+			return int(idx & mask)
+		}
+		idx = idx*5 + perturb + 1
+	}
+}
+
+// mayResize grows the hash table when it's filled like hash_may_resize()
+// of Vim. Items are never removed, so the number of filled slots is the
+// number of the items.
+func (d *Dict) mayResize() {
+	size := len(d.slots)
+	if size == initSize && d.used < initSize-1 {
+		return
+	}
+	if d.used*3 < size*2 {
+		return
+	}
+	newSize := initSize
+	for newSize < d.used*4 {
+		newSize <<= 1
+	}
+	old := d.slots
+	d.slots = make([]*dictItem, newSize)
+	for _, item := range old {
+		if item != nil {
+			d.slots[d.lookup(item.key, item.hash)] = item
+		}
+	}
+}
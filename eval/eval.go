@@ -0,0 +1,724 @@
+// Package eval evaluates constant expressions of legacy Vim script as Vim
+// does, e.g. `'abc' . 1` is 'abc1' and `[1, 2, 3][-2:]` is [2, 3].
+//
+// Eval supports literals, the operators, indexes and slices, the constants
+// of v: variables, e.g. v:true and v:t_list, and a few builtin functions
+// which don't depend on the state of the editor, e.g. len() and join().
+// Errors which Vim raises on the evaluation are returned as *Error, with
+// the error number of Vim. Expressions whose values are not known
+// statically, e.g. variables, return *UnknownError.
+//
+// The options which affect the evaluation have their default values, e.g.
+// 'noignorecase' for "==" and 'magic' for "=~".
+package eval
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// Error is an error which Vim raises on the evaluation.
+type Error struct {
+	Pos ast.Pos // position of the expression
+	End ast.Pos // position immediately after the expression
+	Msg string  // message with the error number of Vim, e.g. "E745: ..."
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %s", e.Pos, e.Msg)
+}
+
+// UnknownError reports that the value of the expression is not known
+// statically, e.g. a variable, or the expression is not supported.
+type UnknownError struct {
+	Pos    ast.Pos // position of the expression
+	End    ast.Pos // position immediately after the expression
+	Reason string  // e.g. "variable s:x"
+}
+
+func (e *UnknownError) Error() string {
+	return fmt.Sprintf("%v: cannot evaluate %s", e.Pos, e.Reason)
+}
+
+// Eval returns the value of x. The error is *Error or *UnknownError.
+func Eval(x ast.Expr) (Value, error) {
+	return eval(x)
+}
+
+// errorf returns the error of Vim at x.
+func errorf(x ast.Node, format string, args ...interface{}) error {
+	return &Error{Pos: start(x), End: x.End(), Msg: fmt.Sprintf(format, args...)}
+}
+
+// unknown returns the error for x which is not evaluated.
+func unknown(x ast.Node, reason string) error {
+	return &UnknownError{Pos: start(x), End: x.End(), Reason: reason}
+}
+
+// start returns the position of the first character of x. Pos of some
+// expressions is the position of the operator, e.g. "+" of `1 + 2`.
+func start(x ast.Node) ast.Pos {
+	switch x := x.(type) {
+	case *ast.BinaryExpr:
+		return start(x.Left)
+	case *ast.TernaryExpr:
+		return start(x.Condition)
+	case *ast.SubscriptExpr:
+		return start(x.Left)
+	case *ast.SliceExpr:
+		return start(x.X)
+	case *ast.DotExpr:
+		return start(x.Left)
+	case *ast.CallExpr:
+		return start(x.Fun)
+	case *ast.MethodExpr:
+		return start(x.Left)
+	}
+	return x.Pos()
+}
+
+// constants is the v: variables which are constant.
+var constants = map[string]Value{
+	"v:false":      Bool(false),
+	"v:true":       Bool(true),
+	"v:none":       None,
+	"v:null":       Null,
+	"v:t_number":   Number(NumberType),
+	"v:t_string":   Number(StringType),
+	"v:t_func":     Number(FuncType),
+	"v:t_list":     Number(ListType),
+	"v:t_dict":     Number(DictType),
+	"v:t_float":    Number(FloatType),
+	"v:t_bool":     Number(BoolType),
+	"v:t_none":     Number(SpecialType),
+	"v:t_job":      Number(8),
+	"v:t_channel":  Number(9),
+	"v:t_blob":     Number(BlobType),
+	"v:numbermax":  Number(math.MaxInt64),
+	"v:numbermin":  Number(math.MinInt64),
+	"v:numbersize": Number(64),
+}
+
+func eval(x ast.Expr) (Value, error) {
+	switch x := x.(type) {
+	case *ast.ParenExpr:
+		return eval(x.X)
+	case *ast.BasicLit:
+		return literal(x)
+	case *ast.Ident:
+		if v, ok := constants[x.Name]; ok {
+			return v, nil
+		}
+		return nil, unknown(x, "variable "+x.Name)
+	case *ast.List:
+		l := &List{Items: make([]Value, 0, len(x.Values))}
+		for _, item := range x.Values {
+			v, err := eval(item)
+			if err != nil {
+				return nil, err
+			}
+			l.Items = append(l.Items, v)
+		}
+		return l, nil
+	case *ast.Dict:
+		return dict(x)
+	case *ast.UnaryExpr:
+		return unary(x)
+	case *ast.BinaryExpr:
+		return binary(x)
+	case *ast.TernaryExpr:
+		c, err := eval(x.Condition)
+		if err != nil {
+			return nil, err
+		}
+		n, msg := toNumber(c)
+		if msg != "" {
+			return nil, errorf(x.Condition, msg)
+		}
+		if n != 0 {
+			return eval(x.Left)
+		}
+		return eval(x.Right)
+	case *ast.SubscriptExpr:
+		return index(x)
+	case *ast.SliceExpr:
+		return slice(x)
+	case *ast.DotExpr:
+		v, err := eval(x.Left)
+		if err != nil {
+			return nil, err
+		}
+		d, ok := v.(*Dict)
+		if !ok {
+			return nil, unknown(x, "dot")
+		}
+		if v, ok := d.Get(x.Right.Name); ok {
+			return v, nil
+		}
+		return nil, errorf(x, "E716: Key not present in Dictionary: %q", x.Right.Name)
+	case *ast.CallExpr:
+		return call(x, x.Fun, nil, x.Args)
+	case *ast.MethodExpr:
+		return call(x, x.Method, x.Left, x.Args)
+	case *ast.LambdaExpr:
+		return nil, unknown(x, "lambda")
+	case *ast.CurlyName:
+		return nil, unknown(x, "curly braces name")
+	}
+	return nil, unknown(x, "expression")
+}
+
+func literal(x *ast.BasicLit) (Value, error) {
+	switch x.Kind {
+	case token.NUMBER:
+		return number(x.Value), nil
+	case token.STRING:
+		s, reason := str(x.Value)
+		if reason != "" {
+			return nil, unknown(x, reason)
+		}
+		return String(s), nil
+	case token.BLOB:
+		return &Blob{Bytes: blob(x.Value)}, nil
+	case token.OPTION:
+		return nil, unknown(x, "option "+x.Value)
+	case token.ENV:
+		return nil, unknown(x, "environment variable "+x.Value)
+	case token.REG:
+		return nil, unknown(x, "register "+x.Value)
+	}
+	return nil, unknown(x, "literal "+x.Value)
+}
+
+func dict(x *ast.Dict) (Value, error) {
+	d := NewDict()
+	for _, e := range x.Entries {
+		var key string
+		if x.Literal {
+			lit := e.Key.(*ast.BasicLit)
+			key, _ = str(lit.Value)
+		} else {
+			k, err := eval(e.Key)
+			if err != nil {
+				return nil, err
+			}
+			var msg string
+			if key, msg = toString(k); msg != "" {
+				return nil, errorf(e.Key, msg)
+			}
+		}
+		v, err := eval(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := d.Get(key); ok {
+			return nil, errorf(e.Key, "E721: Duplicate key in Dictionary: %q", key)
+		}
+		d.Set(key, v)
+	}
+	return d, nil
+}
+
+func unary(x *ast.UnaryExpr) (Value, error) {
+	v, err := eval(x.X)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := v.(Float); ok {
+		switch x.Op {
+		case token.NOT:
+			if f == 0 {
+				return Float(1), nil
+			}
+			return Float(0), nil
+		case token.MINUS:
+			return -f, nil
+		}
+		return f, nil
+	}
+	n, msg := toNumber(v)
+	if msg != "" {
+		return nil, errorf(x.X, msg)
+	}
+	switch x.Op {
+	case token.NOT:
+		if n == 0 {
+			return Number(1), nil
+		}
+		return Number(0), nil
+	case token.MINUS:
+		return Number(-n), nil
+	}
+	return Number(n), nil
+}
+
+// comparisons is the operators of comparisons, and whether they ignore
+// the case.
+var comparisons = map[token.Token]struct {
+	op token.Token // the one which uses 'ignorecase'
+	ic bool
+}{
+	token.EQEQ:      {token.EQEQ, false},
+	token.EQEQCI:    {token.EQEQ, true},
+	token.EQEQCS:    {token.EQEQ, false},
+	token.NEQ:       {token.NEQ, false},
+	token.NEQCI:     {token.NEQ, true},
+	token.NEQCS:     {token.NEQ, false},
+	token.GT:        {token.GT, false},
+	token.GTCI:      {token.GT, true},
+	token.GTCS:      {token.GT, false},
+	token.GTEQ:      {token.GTEQ, false},
+	token.GTEQCI:    {token.GTEQ, true},
+	token.GTEQCS:    {token.GTEQ, false},
+	token.LT:        {token.LT, false},
+	token.LTCI:      {token.LT, true},
+	token.LTCS:      {token.LT, false},
+	token.LTEQ:      {token.LTEQ, false},
+	token.LTEQCI:    {token.LTEQ, true},
+	token.LTEQCS:    {token.LTEQ, false},
+	token.MATCH:     {token.MATCH, false},
+	token.MATCHCI:   {token.MATCH, true},
+	token.MATCHCS:   {token.MATCH, false},
+	token.NOMATCH:   {token.NOMATCH, false},
+	token.NOMATCHCI: {token.NOMATCH, true},
+	token.NOMATCHCS: {token.NOMATCH, false},
+	token.IS:        {token.IS, false},
+	token.ISCI:      {token.IS, true},
+	token.ISCS:      {token.IS, false},
+	token.ISNOT:     {token.ISNOT, false},
+	token.ISNOTCI:   {token.ISNOT, true},
+	token.ISNOTCS:   {token.ISNOT, false},
+}
+
+func binary(x *ast.BinaryExpr) (Value, error) {
+	left, err := eval(x.Left)
+	if err != nil {
+		return nil, err
+	}
+	switch x.Op {
+	case token.OROR, token.ANDAND:
+		n, msg := toNumber(left)
+		if msg != "" {
+			return nil, errorf(x.Left, msg)
+		}
+		if (n != 0) == (x.Op == token.OROR) {
+			return boolNumber(n != 0), nil
+		}
+		right, err := eval(x.Right)
+		if err != nil {
+			return nil, err
+		}
+		n, msg = toNumber(right)
+		if msg != "" {
+			return nil, errorf(x.Right, msg)
+		}
+		return boolNumber(n != 0), nil
+	case token.FALSY:
+		if truthy(left) {
+			return left, nil
+		}
+		return eval(x.Right)
+	}
+	right, err := eval(x.Right)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := comparisons[x.Op]; ok {
+		return compare(x, c.op, c.ic, left, right)
+	}
+	switch x.Op {
+	case token.DOT, token.DOTDOT:
+		s1, msg := toString(left)
+		if msg != "" {
+			return nil, errorf(x.Left, msg)
+		}
+		s2, msg := toString(right)
+		if msg != "" {
+			return nil, errorf(x.Right, msg)
+		}
+		return String(s1 + s2), nil
+	case token.PLUS, token.MINUS, token.STAR, token.SLASH, token.PERCENT:
+		return arith(x, left, right)
+	}
+	return nil, unknown(x, "operator "+x.Op.String())
+}
+
+func boolNumber(b bool) Number {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func arith(x *ast.BinaryExpr, left, right Value) (Value, error) {
+	if x.Op == token.PLUS {
+		switch l := left.(type) {
+		case *List:
+			if r, ok := right.(*List); ok {
+				items := make([]Value, 0, len(l.Items)+len(r.Items))
+				return &List{Items: append(append(items, l.Items...), r.Items...)}, nil
+			}
+		case *Blob:
+			if r, ok := right.(*Blob); ok {
+				b := make([]byte, 0, len(l.Bytes)+len(r.Bytes))
+				return &Blob{Bytes: append(append(b, l.Bytes...), r.Bytes...)}, nil
+			}
+		}
+	}
+	// Numbers are converted to Float if the other is a Float.
+	var n [2]int64
+	for i, v := range []Value{left, right} {
+		if _, ok := v.(Float); ok {
+			continue
+		}
+		var msg string
+		if n[i], msg = toNumber(v); msg != "" {
+			return nil, errorf([]ast.Expr{x.Left, x.Right}[i], msg)
+		}
+	}
+	f1, ok1 := left.(Float)
+	f2, ok2 := right.(Float)
+	if ok1 || ok2 {
+		if !ok1 {
+			f1 = Float(n[0])
+		}
+		if !ok2 {
+			f2 = Float(n[1])
+		}
+		switch x.Op {
+		case token.PLUS:
+			return f1 + f2, nil
+		case token.MINUS:
+			return f1 - f2, nil
+		case token.STAR:
+			return f1 * f2, nil
+		case token.SLASH:
+			return f1 / f2, nil
+		}
+		return nil, errorf(x, "E804: Cannot use '%%' with Float")
+	}
+	n1, n2 := n[0], n[1]
+	switch x.Op {
+	case token.PLUS:
+		return Number(n1 + n2), nil
+	case token.MINUS:
+		return Number(n1 - n2), nil
+	case token.STAR:
+		return Number(n1 * n2), nil
+	case token.SLASH:
+		switch {
+		case n2 == 0 && n1 == 0:
+			return Number(math.MinInt64), nil
+		case n2 == 0 && n1 < 0:
+			return Number(-math.MaxInt64), nil
+		case n2 == 0:
+			return Number(math.MaxInt64), nil
+		case n1 == math.MinInt64 && n2 == -1:
+			return Number(math.MaxInt64), nil
+		}
+		return Number(n1 / n2), nil
+	}
+	if n2 == 0 {
+		return Number(0), nil
+	}
+	return Number(n1 % n2), nil
+}
+
+// compare compares the values like typval_compare() of Vim. op is one of
+// EQEQ, NEQ, GT, GTEQ, LT, LTEQ, MATCH, NOMATCH, IS and ISNOT.
+func compare(x *ast.BinaryExpr, op token.Token, ic bool, left, right Value) (Value, error) {
+	isOp := op == token.IS || op == token.ISNOT
+	eqOp := op == token.EQEQ || op == token.NEQ
+	// result returns the result of "==" or "is", negated for "!=" and
+	// "isnot".
+	result := func(eq bool) (Value, error) {
+		return boolNumber(eq != (op == token.NEQ || op == token.ISNOT)), nil
+	}
+	lt, rt := left.Type(), right.Type()
+	switch {
+	case isOp && lt != rt:
+		return result(false)
+	case (left == Null || right == Null) && lt != rt && eqOp:
+		// Comparing v:null with the other types compares whether it's
+		// null. Numbers and Floats are null if they are zero.
+		v := left
+		if left == Null {
+			v = right
+		}
+		switch v := v.(type) {
+		case Number:
+			return result(v == 0)
+		case Float:
+			return result(v == 0)
+		}
+		return result(false)
+	case lt == BlobType || rt == BlobType:
+		switch {
+		case isOp:
+			return result(left == right)
+		case lt != rt:
+			return nil, errorf(x, "E977: Can only compare Blob with Blob")
+		case !eqOp:
+			return nil, errorf(x, "E978: Invalid operation for Blob")
+		}
+		return result(equal(left, right, ic))
+	case lt == ListType || rt == ListType:
+		switch {
+		case isOp:
+			return result(left == right)
+		case lt != rt:
+			return nil, errorf(x, "E691: Can only compare List with List")
+		case !eqOp:
+			return nil, errorf(x, "E692: Invalid operation for List")
+		}
+		return result(equal(left, right, ic))
+	case lt == DictType || rt == DictType:
+		switch {
+		case isOp:
+			return result(left == right)
+		case lt != rt:
+			return nil, errorf(x, "E735: Can only compare Dictionary with Dictionary")
+		case !eqOp:
+			return nil, errorf(x, "E736: Invalid operation for Dictionary")
+		}
+		return result(equal(left, right, ic))
+	}
+	match := op == token.MATCH || op == token.NOMATCH
+	var c int
+	switch {
+	case (lt == FloatType || rt == FloatType) && !match:
+		f1, msg := toFloat(left)
+		if msg != "" {
+			return nil, errorf(x.Left, msg)
+		}
+		f2, msg := toFloat(right)
+		if msg != "" {
+			return nil, errorf(x.Right, msg)
+		}
+		if f1 != f2 && (math.IsNaN(f1) || math.IsNaN(f2)) {
+			// NaN is not equal to, less than or greater than anything.
+			return result(false)
+		}
+		c = compareFloats(f1, f2)
+	case (lt == NumberType || rt == NumberType) && !match:
+		n1, _ := toNumber(left)
+		n2, _ := toNumber(right)
+		c = compareNumbers(n1, n2)
+	default:
+		s1, _ := toString(left)
+		s2, _ := toString(right)
+		if match {
+			re, reason := compilePattern(s2, ic)
+			if reason != "" {
+				return nil, unknown(x.Right, reason)
+			}
+			return boolNumber(re.MatchString(s1) == (op == token.MATCH)), nil
+		}
+		c = compareStrings(s1, s2, ic)
+	}
+	switch op {
+	case token.GT:
+		return boolNumber(c > 0), nil
+	case token.GTEQ:
+		return boolNumber(c >= 0), nil
+	case token.LT:
+		return boolNumber(c < 0), nil
+	case token.LTEQ:
+		return boolNumber(c <= 0), nil
+	}
+	return result(c == 0)
+}
+
+func compareNumbers(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// compareStrings compares the strings by bytes, or by the characters
+// folded to lower case if ic.
+func compareStrings(a, b string, ic bool) int {
+	if !ic {
+		return strings.Compare(a, b)
+	}
+	for a != "" && b != "" {
+		r1, n1 := utf8.DecodeRuneInString(a)
+		r2, n2 := utf8.DecodeRuneInString(b)
+		if r1 == utf8.RuneError && n1 == 1 {
+			r1 = rune(a[0])
+		}
+		if r2 == utf8.RuneError && n2 == 1 {
+			r2 = rune(b[0])
+		}
+		if c := compareNumbers(int64(unicode.ToLower(r1)), int64(unicode.ToLower(r2))); c != 0 {
+			return c
+		}
+		a, b = a[n1:], b[n2:]
+	}
+	return compareNumbers(int64(len(a)), int64(len(b)))
+}
+
+// canIndex checks v can be indexed like check_can_index() of Vim.
+func canIndex(x ast.Expr, v Value) error {
+	switch v.(type) {
+	case Float:
+		return errorf(x, "E806: Using a Float as a String")
+	case Bool, Special:
+		return errorf(x, "E909: Cannot index a special variable")
+	}
+	return nil
+}
+
+func index(x *ast.SubscriptExpr) (Value, error) {
+	v, err := eval(x.Left)
+	if err != nil {
+		return nil, err
+	}
+	if err := canIndex(x.Left, v); err != nil {
+		return nil, err
+	}
+	i, err := eval(x.Right)
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := v.(*Dict); ok {
+		key, msg := toString(i)
+		if msg != "" {
+			return nil, errorf(x.Right, msg)
+		}
+		if item, ok := d.Get(key); ok {
+			return item, nil
+		}
+		return nil, errorf(x, "E716: Key not present in Dictionary: %q", key)
+	}
+	n, msg := toNumber(i)
+	if msg != "" {
+		return nil, errorf(x.Right, msg)
+	}
+	switch v := v.(type) {
+	case *List:
+		// The error has the index before adding the length.
+		orig := n
+		if n < 0 {
+			n += int64(len(v.Items))
+		}
+		if n < 0 || n >= int64(len(v.Items)) {
+			return nil, errorf(x, "E684: List index out of range: %d", orig)
+		}
+		return v.Items[n], nil
+	case *Blob:
+		if n < 0 {
+			n += int64(len(v.Bytes))
+		}
+		if n < 0 || n >= int64(len(v.Bytes)) {
+			return nil, errorf(x, "E979: Blob index out of range: %d", n)
+		}
+		return Number(v.Bytes[n]), nil
+	}
+	// Numbers are indexed as Strings.
+	s, _ := toString(v)
+	if n < 0 || n >= int64(len(s)) {
+		return String(""), nil
+	}
+	return String(s[n : n+1]), nil
+}
+
+func slice(x *ast.SliceExpr) (Value, error) {
+	v, err := eval(x.X)
+	if err != nil {
+		return nil, err
+	}
+	if err := canIndex(x.X, v); err != nil {
+		return nil, err
+	}
+	// The range is [0:-1] if omitted.
+	bounds := [2]int64{0, -1}
+	for i, y := range []ast.Expr{x.Low, x.High} {
+		if y == nil {
+			continue
+		}
+		b, err := eval(y)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := v.(*Dict); ok {
+			continue
+		}
+		var msg string
+		if bounds[i], msg = toNumber(b); msg != "" {
+			return nil, errorf(y, msg)
+		}
+	}
+	n1, n2 := bounds[0], bounds[1]
+	switch v := v.(type) {
+	case *Dict:
+		return nil, errorf(x, "E719: Cannot slice a Dictionary")
+	case *List:
+		l := int64(len(v.Items))
+		if n1 < 0 {
+			n1 += l
+		}
+		if n1 < 0 || n1 >= l {
+			return &List{Items: []Value{}}, nil
+		}
+		if n2 < 0 {
+			n2 += l
+		} else if n2 >= l {
+			n2 = l - 1
+		}
+		if n2 < 0 || n2+1 < n1 {
+			return &List{Items: []Value{}}, nil
+		}
+		return &List{Items: append([]Value{}, v.Items[n1:n2+1]...)}, nil
+	case *Blob:
+		if n1, n2, ok := sliceRange(n1, n2, int64(len(v.Bytes))); ok {
+			return &Blob{Bytes: append([]byte{}, v.Bytes[n1:n2]...)}, nil
+		}
+		return &Blob{}, nil
+	}
+	s, _ := toString(v)
+	if n1, n2, ok := sliceRange(n1, n2, int64(len(s))); ok {
+		return String(s[n1:n2]), nil
+	}
+	return String(""), nil
+}
+
+// sliceRange returns the range [n1:n2) of the String or Blob of the length
+// l for the slice [n1:n2]; or false if it's empty.
+func sliceRange(n1, n2, l int64) (int64, int64, bool) {
+	if n1 < 0 {
+		n1 += l
+		if n1 < 0 {
+			n1 = 0
+		}
+	}
+	if n2 < 0 {
+		n2 += l
+	} else if n2 >= l {
+		n2 = l - 1
+	}
+	if n1 >= l || n2 < 0 || n1 > n2 {
+		return 0, 0, false
+	}
+	return n1, n2 + 1, true
+}
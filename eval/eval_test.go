@@ -0,0 +1,319 @@
+package eval
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+func evalString(t *testing.T, src string) (Value, error) {
+	t.Helper()
+	x, err := vimlparser.ParseExpr(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("%s: %v", src, err)
+	}
+	return Eval(x)
+}
+
+// The results are string() and type() of the values, or the errors, which
+// Vim 9.0 returns for the expressions.
+func TestEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{`1 + 2 * 3`, "7 | 0"},
+		{`7 / 2`, "3 | 0"},
+		{`-7 / 2`, "-3 | 0"},
+		{`7 % -3`, "1 | 0"},
+		{`1 / 0`, "9223372036854775807 | 0"},
+		{`-1 / 0`, "-9223372036854775807 | 0"},
+		{`0 / 0`, "-9223372036854775808 | 0"},
+		{`5 % 0`, "0 | 0"},
+		{`v:numbermin / -1`, "9223372036854775807 | 0"},
+		{`v:numbermax + 1`, "-9223372036854775808 | 0"},
+		{`99999999999999999999`, "9223372036854775807 | 0"},
+		{`-99999999999999999999`, "-9223372036854775807 | 0"},
+		{`0x7FFFFFFFFFFFFFFF`, "9223372036854775807 | 0"},
+		{`0b1010 + '0o17' + 017 + 019`, "59 | 0"},
+		{`1.0 / 3`, "0.333333 | 5"},
+		{`1.0 / 0`, "inf | 5"},
+		{`-1.0 / 0`, "-inf | 5"},
+		{`0.0 / 0`, "nan | 5"},
+		{`123456789.0`, "1.234568e8 | 5"},
+		{`0.0001`, "1.0e-4 | 5"},
+		{`123456.789`, "123456.789 | 5"},
+		{`1.0e-320`, "9.999889e-321 | 5"},
+		{`1.5 * 2`, "3.0 | 5"},
+		{`1 + 1.5`, "2.5 | 5"},
+		{`5.5 % 2`, "E804: Cannot use '%' with Float"},
+		{`v:true + 1.5`, "2.5 | 5"},
+		{`v:true + 1`, "2 | 0"},
+		{`[1] + [2, 'a']`, "[1, 2, 'a'] | 3"},
+		{`0z0102 + 0z03`, "0z010203 | 10"},
+		{`[1] + 1`, "E745: Using a List as a Number"},
+		{`{} - 1`, "E728: Using a Dictionary as a Number"},
+		{`0z00 * 2`, "E974: Using a Blob as a Number"},
+		{`1.5 + 'a'`, "1.5 | 5"},
+		{`'10' + '0x10' + '010' + '1e3' + 'abc'`, "35 | 0"},
+		{`'-5' + '+5' + '  5'`, "-5 | 0"},
+		{`'-99999999999999999999' + 0`, "-9223372036854775808 | 0"},
+		{`'abc' . 1`, "'abc1' | 1"},
+		{`'abc' .. v:true .. v:none`, "'abcv:truev:none' | 1"},
+		{`1 . 2`, "'12' | 1"},
+		{`'a' .. [1]`, "E730: Using a List as a String"},
+		{`'a' .. {}`, "E731: Using a Dictionary as a String"},
+		{`'a' .. 0z00`, "E976: Using a Blob as a String"},
+		{`-'abc'`, "0 | 0"},
+		{`!'abc'`, "1 | 0"},
+		{`!1.5`, "0.0 | 5"},
+		{`!0.0`, "1.0 | 5"},
+		{`-1.5`, "-1.5 | 5"},
+		{`+'12'`, "12 | 0"},
+		{`'A' == 'a'`, "0 | 0"},
+		{`'A' ==? 'a'`, "1 | 0"},
+		{`'A' ==# 'a'`, "0 | 0"},
+		{`'A' is? 'a'`, "1 | 0"},
+		{`'é' ==? 'É'`, "1 | 0"},
+		{`'abc' < 'abd'`, "1 | 0"},
+		{`'b' >? 'A'`, "1 | 0"},
+		{`'10' < 9`, "0 | 0"},
+		{`10 == '10'`, "1 | 0"},
+		{`1 == 1.0`, "1 | 0"},
+		{`1 is 1.0`, "0 | 0"},
+		{`[1] is [1]`, "0 | 0"},
+		{`[1] == [1]`, "1 | 0"},
+		{`[1] == ['1']`, "0 | 0"},
+		{`[1, 2] == [1, 2.0]`, "0 | 0"},
+		{`['A'] ==? ['a']`, "1 | 0"},
+		{`{'a': 1} == {'a': 1}`, "1 | 0"},
+		{`{'a': 1} isnot {'a': 1}`, "1 | 0"},
+		{`0z01 == 0z01`, "1 | 0"},
+		{`[1] == 1`, "E691: Can only compare List with List"},
+		{`[1] < [2]`, "E692: Invalid operation for List"},
+		{`{} == []`, "E691: Can only compare List with List"},
+		{`{} < {}`, "E736: Invalid operation for Dictionary"},
+		{`0z00 == 1`, "E977: Can only compare Blob with Blob"},
+		{`0z00 < 0z01`, "E978: Invalid operation for Blob"},
+		{`1.0 == 'a'`, "E892: Using a String as a Float"},
+		{`v:true == 1.0`, "E362: Using a boolean value as a Float"},
+		{`v:none == 1.0`, "E907: Using a special value as a Float"},
+		{`v:true == v:true`, "1 | 0"},
+		{`v:null == v:none`, "0 | 0"},
+		{`v:none == 0`, "1 | 0"},
+		{`'v:true' == v:true`, "1 | 0"},
+		{`v:true == 'x'`, "0 | 0"},
+		{`v:false < v:true`, "1 | 0"},
+		{`v:null == 0`, "1 | 0"},
+		{`v:null == ''`, "0 | 0"},
+		{`v:null == []`, "0 | 0"},
+		{`v:true is 1`, "0 | 0"},
+		{`'abc' =~ 'b'`, "1 | 0"},
+		{`'ABC' =~ 'b'`, "0 | 0"},
+		{`'ABC' =~? 'b'`, "1 | 0"},
+		{`'ABC' =~# '\cb'`, "1 | 0"},
+		{`'foo123' =~ '^\a\+\d\{2,}$'`, "1 | 0"},
+		{`'foo' !~ 'o\|x'`, "0 | 0"},
+		{`'a.c' =~ 'a\.c'`, "1 | 0"},
+		{`'a*' =~ '^*'`, "0 | 0"},
+		{`'x[' =~ '[[]'`, "1 | 0"},
+		{`'ab' =~ '\(a\|b\)\{2}'`, "1 | 0"},
+		{`123 =~ '2'`, "1 | 0"},
+		{`1.5 =~ '\.'`, "1 | 0"},
+		{`[1] =~ 'a'`, "E691: Can only compare List with List"},
+		{`1 || [1]`, "1 | 0"},
+		{`0 || 2`, "1 | 0"},
+		{`0 && [1]`, "0 | 0"},
+		{`1 && 0`, "0 | 0"},
+		{`'abc' && 1`, "0 | 0"},
+		{`1.5 || 1`, "E805: Using a Float as a Number"},
+		{`[1] ? 1 : 2`, "E745: Using a List as a Number"},
+		{`'' ? 1 : 2`, "2 | 0"},
+		{`'1' ? 'a' : 'b'`, "'a' | 1"},
+		{`'abc'[1]`, "'b' | 1"},
+		{`'abc'[-1]`, "'' | 1"},
+		{`'abc'[5]`, "'' | 1"},
+		{`'abc'[1:]`, "'bc' | 1"},
+		{`'abc'[-2:]`, "'bc' | 1"},
+		{`'abc'[:-2]`, "'ab' | 1"},
+		{`'abc'[2:1]`, "'' | 1"},
+		{`'abc'[1:10]`, "'bc' | 1"},
+		{`'abc'[-5:]`, "'abc' | 1"},
+		{`'abc'['1']`, "'b' | 1"},
+		{`123[1]`, "'2' | 1"},
+		{`123[1:]`, "'23' | 1"},
+		{`1.5[0]`, "E806: Using a Float as a String"},
+		{`v:true[0]`, "E909: Cannot index a special variable"},
+		{`[1, 2, 3][-1]`, "3 | 0"},
+		{`[1, 2, 3][3]`, "E684: List index out of range: 3"},
+		{`[1, 2, 3][-4]`, "E684: List index out of range: -4"},
+		{`[1, 2, 3][1:]`, "[2, 3] | 3"},
+		{`[1, 2, 3][-2:]`, "[2, 3] | 3"},
+		{`[1, 2, 3][:-2]`, "[1, 2] | 3"},
+		{`[1, 2, 3][5:]`, "[] | 3"},
+		{`[1, 2, 3][1.5]`, "E805: Using a Float as a Number"},
+		{`[1, 2, 3]['x']`, "1 | 0"},
+		{`0z010203[1]`, "2 | 0"},
+		{`0z010203[-1]`, "3 | 0"},
+		{`0z010203[3]`, "E979: Blob index out of range: 3"},
+		{`0z01[-3]`, "E979: Blob index out of range: -2"},
+		{`0z010203[1:]`, "0z0203 | 10"},
+		{`0z010203[-5:1]`, "0z0102 | 10"},
+		{`{'a': 1}['a']`, "1 | 0"},
+		{`{'a': 1}['b']`, "E716: Key not present in Dictionary: \"b\""},
+		{`{'a': 1}[1.5]`, "E716: Key not present in Dictionary: \"1.5\""},
+		{`{'a': 1}[[1]]`, "E730: Using a List as a String"},
+		{`{'a': 1}[0:1]`, "E719: Cannot slice a Dictionary"},
+		{`{'1.5': 2}[1.5]`, "2 | 0"},
+		{`{1: 'a', 1.5: 'b', v:true : 'c'}`, "{'1': 'a', '1.5': 'b', 'v:true': 'c'} | 4"},
+		{`{'a': 1, 'a': 2}`, "E721: Duplicate key in Dictionary: \"a\""},
+		{`{[1]: 1}`, "E730: Using a List as a String"},
+		{`#{a: 1, b-c: 2}`, "{'a': 1, 'b-c': 2} | 4"},
+		{`{'b': 1, 'a': 2, 'c': 3, 'aa': 4, 'zz': 5, 'key': 6, 'foo': 7, 'bar': 8}`, "{'foo': 7, 'a': 2, 'b': 1, 'c': 3, 'key': 6, 'aa': 4, 'bar': 8, 'zz': 5} | 4"},
+		{`{'k1':1,'k2':2,'k3':3,'k4':4,'k5':5,'k6':6,'k7':7,'k8':8,'k9':9,'k10':10,'k11':11,'k12':12,'k13':13,'k14':14,'k15':15,'k16':16,'k17':17,'k18':18,'k19':19,'k20':20}`, "{'k18': 18, 'k19': 19, 'k20': 20, 'k1': 1, 'k2': 2, 'k3': 3, 'k4': 4, 'k5': 5, 'k6': 6, 'k7': 7, 'k8': 8, 'k9': 9, 'k10': 10, 'k11': 11, 'k12': 12, 'k13': 13, 'k14': 14, 'k15': 15, 'k16': 16, 'k17': 17} | 4"},
+		{`{'': 1, 'é': 2, 'x': 3}`, "{'': 1, 'x': 3, 'é': 2} | 4"},
+		{`{'a': {'b': [1, 'x', 1.5]}}`, "{'a': {'b': [1, 'x', 1.5]}} | 4"},
+		{`{'a': 1}.a`, "1 | 0"},
+		{`'it''s'`, "'it''s' | 1"},
+		{`"a\tb\"c\\d"`, "'a\x09b\"c\\d' | 1"},
+		{`"\x41\x4a\X4" .. "\xg"`, "'AJ\x04xg' | 1"},
+		{`"é\U0001F600"`, "'é😀' | 1"},
+		{`"\101\1011\o101"`, "'AA1o101' | 1"},
+		{`"a\0b"`, "'a' | 1"},
+		{`"\400"`, "'' | 1"},
+		{`"\e\b\r\f\n"`, "'\x1b\x08\x0d\x0c\n' | 1"},
+		{`"\<Tab>\<CR>\<Esc>\<lt>\<Bslash>\<Bar>\<Space>"`, "'\x09\x0d\x1b<\\| ' | 1"},
+		{`"\<C-a>\<C-[>\<C-?>\<C-S-a>\<S-a>"`, "'\x01\x1b\x7f\x01A' | 1"},
+		{`"\<M-a>\<M-Tab>\<Char-65>\<char-0x42>"`, "'áAB' | 1"},
+		{`"\<Up>\<F10>\<S-Tab>\<End>"`, "'\x80ku\x80k;\x80kB\x80@7' | 1"},
+		{`"\<Nul>\<C-@>"`, "'\x80\xffX\x80\xffX' | 1"},
+		{`"\<Foo"`, "'<Foo' | 1"},
+		{`"<a b>\<x y>"`, "'<a b><x y>' | 1"},
+		{`0z01020304050607080910`, "0z01020304.05060708.0910 | 10"},
+		{`0z`, "0z | 10"},
+		{`[0z, 0z01.02]`, "[0z, 0z0102] | 3"},
+		{`[1.0, -0.0, 1.0e100, 0.00099999, -1.5e-7]`, "[1.0, -0.0, 1.0e100, 9.9999e-4, -1.5e-7] | 3"},
+		{`[1, 'a', [v:true, v:none], {}]`, "[1, 'a', [v:true, v:none], {}] | 3"},
+		{`len('abc') + len([1, 2]) + len({}) + len(0z0102) + len(1234)`, "11 | 0"},
+		{`len(1.5)`, "E701: Invalid type for len()"},
+		{`len()`, "E119: Not enough arguments for function: len"},
+		{`len(1, 2)`, "E118: Too many arguments for function: len"},
+		{`'abc'->len()`, "3 | 0"},
+		{`[1, 2]->join('-')`, "'1-2' | 1"},
+		{`join([1, 'a', [2], 1.5, {'x': 'y'}])`, "'1 a [2] 1.5 {''x'': ''y''}' | 1"},
+		{`join('a')`, "E1211: List required for argument 1"},
+		{`keys({'b': 1, 'a': 2})`, "['a', 'b'] | 3"},
+		{`values({'b': 1, 'a': 2})`, "[2, 1] | 3"},
+		{`keys([])`, "E1206: Dictionary required for argument 1"},
+		{`empty('') + empty([]) + empty(0z) + empty(v:null) + empty(v:none) + empty(0.0) + empty({})`, "7 | 0"},
+		{`empty(1) + empty('a') + empty(v:true)`, "0 | 0"},
+		{`string('x''y')`, "'''x''''y''' | 1"},
+		{`string([1, 'a'])`, "'[1, ''a'']' | 1"},
+		{`type(v:null) . type(v:true) . type(0z) . type(1.5) . type({})`, "'761054' | 1"},
+		{`toupper('abcé') . tolower('ABCÉ')`, "'ABCÉabcé' | 1"},
+		{`repeat('ab', 3)`, "'ababab' | 1"},
+		{`repeat([1, 2], 2)`, "[1, 2, 1, 2] | 3"},
+		{`repeat('a', -1)`, "'' | 1"},
+		{`abs(-5) + abs(-1.5)`, "6.5 | 5"},
+		{`and(12, 10) . or(12, 10) . xor(12, 10) . invert(0)`, "'8146-1' | 1"},
+		{`nosuchfunc(1)`, "E117: Unknown function: nosuchfunc"},
+		{`v:t_list + v:t_blob + v:numbersize`, "77 | 0"},
+	}
+	for _, tt := range tests {
+		v, err := evalString(t, tt.expr)
+		var got string
+		switch err := err.(type) {
+		case nil:
+			got = fmt.Sprintf("%s | %d", Repr(v), v.Type())
+		case *Error:
+			got = err.Msg
+		default:
+			t.Errorf("%s: %v", tt.expr, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEval_falsy(t *testing.T) {
+	// "??" is parsed only in Vim9 script.
+	src := `vim9script
+echo 0 ?? 'x'
+echo '' ?? 'x'
+echo [] ?? 'x'
+echo 0z ?? 'x'
+echo v:false ?? 'x'
+echo v:null ?? 'x'
+echo 'a' ?? 'x'
+echo 0.0 ?? 1
+echo 1 ?? [][0]
+`
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range f.Body {
+		if e, ok := s.(*ast.EchoCmd); ok {
+			v, err := Eval(e.Exprs[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, Repr(v))
+		}
+	}
+	want := []string{"'x'", "'x'", "'x'", "'x'", "'x'", "'x'", "'a'", "1", "1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEval_unknown(t *testing.T) {
+	tests := []struct {
+		expr   string
+		reason string
+	}{
+		{"s:x + 1", "variable s:x"},
+		{"&tabstop", "option &tabstop"},
+		{"$HOME . '/x'", "environment variable $HOME"},
+		{"@a", "register @a"},
+		{"{x -> x}", "lambda"},
+		{"s:F(1)", "call of user function"},
+		{"line('.')", "call of line()"},
+		{"0 || s:x", "variable s:x"},
+		{`"\<C-Left>"`, "key with modifiers <C-Left>"},
+		{`"\<Foo>"`, "key <Foo>"},
+		{`'abc' =~ '\<b'`, `\< in pattern`},
+		{`'abc' =~ 'a~'`, "~ in pattern, which is the last substitute string"},
+		{`'abc' =~ ''`, "empty pattern, which is the last search pattern"},
+	}
+	for _, tt := range tests {
+		_, err := evalString(t, tt.expr)
+		if e, ok := err.(*UnknownError); !ok || e.Reason != tt.reason {
+			t.Errorf("%s: got %v, want %q", tt.expr, err, tt.reason)
+		}
+	}
+	// Short-circuit operators don't evaluate the rest.
+	for _, expr := range []string{"1 || s:x", "0 && s:x", "1 ? 2 : s:x"} {
+		if _, err := evalString(t, expr); err != nil {
+			t.Errorf("%s: %v", expr, err)
+		}
+	}
+}
+
+func TestEval_errorPos(t *testing.T) {
+	_, err := evalString(t, "[1, 2] + ([3] - 1)")
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("got %v", err)
+	}
+	if e.Pos.Column != 11 || e.End.Column != 14 || e.Msg != "E745: Using a List as a Number" {
+		t.Errorf("got %v (end %v)", e, e.End)
+	}
+}
@@ -0,0 +1,341 @@
+package eval
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var floatLit = regexp.MustCompile(`^\d+\.\d+([eE][-+]?\d+)?$`)
+
+// number returns the value of the number literal, which is a Number or a
+// Float.
+func number(lit string) Value {
+	if floatLit.MatchString(lit) {
+		// ParseFloat returns ±Inf for too large numbers as strtod().
+		f, _ := strconv.ParseFloat(lit, 64)
+		return Float(f)
+	}
+	return Number(str2nr(lit))
+}
+
+// str2nr converts s to a Number like vim_str2nr() of Vim with all the
+// prefixes. It returns 0 unless s starts with a number, optionally
+// preceded by "-", and ignores the rest. Overflow saturates.
+func str2nr(s string) int64 {
+	neg := false
+	if strings.HasPrefix(s, "-") {
+		neg = true
+		s = s[1:]
+	}
+	base := uint64(10)
+	if len(s) >= 2 && s[0] == '0' && s[1] != '8' && s[1] != '9' {
+		switch {
+		case (s[1] == 'x' || s[1] == 'X') && len(s) > 2 && digitValue(s[2]) < 16:
+			base, s = 16, s[2:]
+		case (s[1] == 'b' || s[1] == 'B') && len(s) > 2 && digitValue(s[2]) < 2:
+			base, s = 2, s[2:]
+		case (s[1] == 'o' || s[1] == 'O') && len(s) > 2 && digitValue(s[2]) < 8:
+			base, s = 8, s[2:]
+		default:
+			// "0", "08" and "0129" are decimal.
+			for i := 1; i < len(s) && '0' <= s[i] && s[i] <= '9'; i++ {
+				if s[i] > '7' {
+					base = 10
+					break
+				}
+				base = 8
+			}
+		}
+	}
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		d := digitValue(s[i])
+		if d >= base {
+			break
+		}
+		if n <= (math.MaxUint64-d)/base {
+			n = n*base + d
+		} else {
+			n = math.MaxUint64
+		}
+	}
+	switch {
+	case neg && n > math.MaxInt64:
+		return math.MinInt64
+	case neg:
+		return -int64(n)
+	case n > math.MaxInt64:
+		return math.MaxInt64
+	}
+	return int64(n)
+}
+
+// digitValue returns the value of the hexadecimal digit c; or 16 if c is
+// not a digit.
+func digitValue(c byte) uint64 {
+	switch {
+	case '0' <= c && c <= '9':
+		return uint64(c - '0')
+	case 'a' <= c && c <= 'f':
+		return uint64(c - 'a' + 10)
+	case 'A' <= c && c <= 'F':
+		return uint64(c - 'A' + 10)
+	}
+	return 16
+}
+
+// blob returns the bytes of the blob literal, e.g. "0z0102.03".
+func blob(lit string) []byte {
+	var b []byte
+	s := strings.Replace(lit[2:], ".", "", -1)
+	for i := 0; i+1 < len(s); i += 2 {
+		b = append(b, byte(digitValue(s[i])<<4|digitValue(s[i+1])))
+	}
+	return b
+}
+
+// str returns the value of the string literal quoted with ' or ". Vim
+// strings end at NUL, e.g. "a\0b" is "a". It returns the reason if the
+// literal has an escape sequence which is not supported, e.g. "\<C-Left>".
+func str(lit string) (string, string) {
+	if lit[0] == '\'' {
+		return strings.Replace(lit[1:len(lit)-1], "''", "'", -1), ""
+	}
+	s := lit[1 : len(lit)-1]
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b = append(b, s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; c {
+		case 'x', 'X', 'u', 'U':
+			n := 8
+			switch c {
+			case 'x', 'X':
+				n = 2
+			case 'u':
+				n = 4
+			}
+			if i+1 == len(s) || digitValue(s[i+1]) >= 16 {
+				b = append(b, c)
+				break
+			}
+			var r uint64
+			for ; n > 0 && i+1 < len(s) && digitValue(s[i+1]) < 16; n-- {
+				i++
+				r = r<<4 | digitValue(s[i])
+			}
+			if c == 'x' || c == 'X' {
+				b = append(b, byte(r))
+			} else {
+				b = appendChar(b, r)
+			}
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			r := c - '0'
+			for n := 0; n < 2 && i+1 < len(s) && '0' <= s[i+1] && s[i+1] <= '7'; n++ {
+				i++
+				r = r<<3 | (s[i] - '0')
+			}
+			b = append(b, r)
+		case 'b':
+			b = append(b, '\b')
+		case 'e':
+			b = append(b, 0x1b)
+		case 'f':
+			b = append(b, '\f')
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case '<':
+			k, n, reason := specialKey(s[i:])
+			if reason != "" {
+				return "", reason
+			}
+			if n == 0 {
+				b = append(b, c)
+				break
+			}
+			b = append(b, k...)
+			i += n - 1
+		default:
+			// "\\", "\"" and unknown escapes, e.g. "\d" is "d".
+			_, n := utf8.DecodeRuneInString(s[i:])
+			b = append(b, s[i:i+n]...)
+			i += n - 1
+		}
+	}
+	if i := strings.IndexByte(string(b), 0); i >= 0 {
+		b = b[:i]
+	}
+	return string(b), ""
+}
+
+// appendChar appends the character in UTF-8 like utf_char2bytes() of Vim,
+// which encodes any value up to 31 bits.
+func appendChar(b []byte, r uint64) []byte {
+	switch {
+	case r < 0x80:
+		return append(b, byte(r))
+	case r < 0x800:
+		return append(b, byte(0xc0|r>>6), byte(0x80|r&0x3f))
+	case r < 0x10000:
+		return append(b, byte(0xe0|r>>12), byte(0x80|r>>6&0x3f), byte(0x80|r&0x3f))
+	case r < 0x200000:
+		return append(b, byte(0xf0|r>>18), byte(0x80|r>>12&0x3f), byte(0x80|r>>6&0x3f), byte(0x80|r&0x3f))
+	case r < 0x4000000:
+		return append(b, byte(0xf8|r>>24), byte(0x80|r>>18&0x3f), byte(0x80|r>>12&0x3f), byte(0x80|r>>6&0x3f), byte(0x80|r&0x3f))
+	}
+	return append(b, byte(0xfc|r>>30&0x01), byte(0x80|r>>24&0x3f), byte(0x80|r>>18&0x3f), byte(0x80|r>>12&0x3f), byte(0x80|r>>6&0x3f), byte(0x80|r&0x3f))
+}
+
+// Bytes of special keys in strings of Vim, e.g. "\<Up>" is "\x80ku".
+const (
+	kSpecial = "\x80"
+	kZero    = kSpecial + "\xffX" // NUL
+	kEscaped = kSpecial + "\xfeX" // byte 0x80
+)
+
+// keyChars is the names of keys of characters in lower case.
+var keyChars = map[string]rune{
+	"nul":      0,
+	"tab":      '\t',
+	"nl":       '\n',
+	"newline":  '\n',
+	"linefeed": '\n',
+	"lf":       '\n',
+	"cr":       '\r',
+	"return":   '\r',
+	"enter":    '\r',
+	"esc":      0x1b,
+	"space":    ' ',
+	"lt":       '<',
+	"bslash":   '\\',
+	"bar":      '|',
+}
+
+// specialKeys is the bytes of the other keys by the names in lower case.
+var specialKeys = map[string]string{
+	"bs":        "kb",
+	"backspace": "kb",
+	"del":       "kD",
+	"delete":    "kD",
+	"up":        "ku",
+	"down":      "kd",
+	"left":      "kl",
+	"right":     "kr",
+	"home":      "kh",
+	"end":       "@7",
+	"insert":    "kI",
+	"pageup":    "kP",
+	"pagedown":  "kN",
+	"help":      "%1",
+	"undo":      "&8",
+	"f1":        "k1",
+	"f2":        "k2",
+	"f3":        "k3",
+	"f4":        "k4",
+	"f5":        "k5",
+	"f6":        "k6",
+	"f7":        "k7",
+	"f8":        "k8",
+	"f9":        "k9",
+	"f10":       "k;",
+	"f11":       "F1",
+	"f12":       "F2",
+}
+
+// specialKey returns the bytes of the key notation at the start of s, e.g.
+// "<C-a>", and its length. The length is 0 if s doesn't start with a key
+// notation. It returns the reason if the key is not supported, e.g. keys
+// with modifiers which are kept as modifiers, such as "<C-Left>".
+func specialKey(s string) (string, int, string) {
+	end := strings.IndexByte(s[1:], '>') + 1
+	if end <= 1 {
+		return "", 0, ""
+	}
+	name := s[1:end]
+	var shift, ctrl, alt, other bool
+	for len(name) > 2 && name[1] == '-' {
+		switch name[0] {
+		case 's', 'S':
+			shift = true
+		case 'c', 'C':
+			ctrl = true
+		case 'm', 'M', 'a', 'A':
+			alt = true
+		case 'd', 'D', 't', 'T':
+			other = true
+		default:
+			return "", 0, ""
+		}
+		name = name[2:]
+	}
+	key, n := utf8.DecodeRuneInString(name)
+	if n != len(name) {
+		lower := strings.ToLower(name)
+		r, ok := keyChars[lower]
+		switch {
+		case ok:
+			key = r
+		case specialKeys[lower] != "":
+			if shift || ctrl || alt || other {
+				return "", 0, "key with modifiers " + s[:end+1]
+			}
+			return kSpecial + specialKeys[lower], end + 1, ""
+		case strings.HasPrefix(lower, "char-") && len(lower) > 5:
+			c := str2nr(lower[5:])
+			if c < 0 || c > math.MaxInt32 {
+				return "", 0, ""
+			}
+			key = rune(c)
+		default:
+			for _, c := range name {
+				if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '_') {
+					return "", 0, ""
+				}
+			}
+			return "", 0, "key " + s[:end+1]
+		}
+	}
+	if shift && key == '\t' {
+		if ctrl || alt || other {
+			return "", 0, "key with modifiers " + s[:end+1]
+		}
+		return kSpecial + "kB", end + 1, ""
+	}
+	if shift && ('a' <= key && key <= 'z' || 'A' <= key && key <= 'Z') {
+		key, shift = key&^0x20, false
+	}
+	if ctrl && '?' <= key && key <= '_' {
+		key, ctrl = key^0x40, false
+	}
+	if ctrl && 'a' <= key && key <= 'z' {
+		key, ctrl = key&^0x20^0x40, false
+	}
+	if alt && key < 0x80 {
+		key, alt = key|0x80, false
+	}
+	if shift || ctrl || alt || other {
+		return "", 0, "key with modifiers " + s[:end+1]
+	}
+	if key == 0 {
+		return kZero, end + 1, ""
+	}
+	var b []byte
+	for _, c := range appendChar(nil, uint64(key)) {
+		if c == 0x80 {
+			b = append(b, kEscaped...)
+		} else {
+			b = append(b, c)
+		}
+	}
+	return string(b), end + 1, ""
+}
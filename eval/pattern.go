@@ -0,0 +1,269 @@
+package eval
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// classes is the character classes of patterns which don't depend on
+// options, e.g. \d.
+var classes = map[byte]string{
+	's': `[ \t]`,
+	'S': `[^ \t]`,
+	'd': `[0-9]`,
+	'D': `[^0-9]`,
+	'x': `[0-9A-Fa-f]`,
+	'X': `[^0-9A-Fa-f]`,
+	'o': `[0-7]`,
+	'O': `[^0-7]`,
+	'w': `[0-9A-Za-z_]`,
+	'W': `[^0-9A-Za-z_]`,
+	'h': `[A-Za-z_]`,
+	'H': `[^A-Za-z_]`,
+	'a': `[A-Za-z]`,
+	'A': `[^A-Za-z]`,
+	'l': `[a-z]`,
+	'L': `[^a-z]`,
+	'u': `[A-Z]`,
+	'U': `[^A-Z]`,
+}
+
+// escapes is the escaped characters of patterns, e.g. \t.
+var escapes = map[byte]string{
+	'e': `\x1b`,
+	't': `\t`,
+	'r': `\r`,
+	'b': `\x08`,
+	'n': `\n`,
+}
+
+// posixClasses is the character classes in collections which Go supports
+// as Vim does, e.g. [:alpha:].
+var posixClasses = []string{
+	"[:alnum:]", "[:alpha:]", "[:blank:]", "[:cntrl:]", "[:digit:]",
+	"[:graph:]", "[:lower:]", "[:print:]", "[:punct:]", "[:space:]",
+	"[:upper:]", "[:xdigit:]",
+}
+
+// compilePattern compiles the magic pattern of Vim, which "=~" uses with
+// 'magic' on. ic ignores the case, which \c and \C override. It returns
+// the reason if the pattern uses items which are not supported, e.g. \< and
+// \zs, or items which depend on options or the state of the editor, e.g.
+// \k and ~.
+func compilePattern(pat string, ic bool) (*regexp.Regexp, string) {
+	if pat == "" {
+		return nil, "empty pattern, which is the last search pattern"
+	}
+	var b strings.Builder
+	// atomStart is true at the start of branches, where "^" is an anchor
+	// and "*" is literal.
+	atomStart := true
+	for i := 0; i < len(pat); i++ {
+		c := pat[i]
+		start := atomStart
+		atomStart = false
+		switch c {
+		case '^':
+			if start {
+				b.WriteString("^")
+				atomStart = true
+			} else {
+				b.WriteString(`\^`)
+			}
+			continue
+		case '$':
+			if rest := pat[i+1:]; rest == "" || strings.HasPrefix(rest, `\|`) || strings.HasPrefix(rest, `\)`) {
+				b.WriteString("$")
+			} else {
+				b.WriteString(`\$`)
+			}
+			continue
+		case '.':
+			b.WriteString(".")
+			continue
+		case '*':
+			if start {
+				b.WriteString(`\*`)
+			} else {
+				b.WriteString("*")
+			}
+			continue
+		case '~':
+			return nil, "~ in pattern, which is the last substitute string"
+		case '[':
+			class, n, reason := collection(pat[i:])
+			if reason != "" {
+				return nil, reason
+			}
+			if n == 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			b.WriteString(class)
+			i += n - 1
+			continue
+		case '\\':
+		default:
+			_, n := utf8.DecodeRuneInString(pat[i:])
+			b.WriteString(regexp.QuoteMeta(pat[i : i+n]))
+			i += n - 1
+			continue
+		}
+		if i+1 == len(pat) {
+			return nil, `trailing \ in pattern`
+		}
+		i++
+		c = pat[i]
+		if s, ok := classes[c]; ok {
+			b.WriteString(s)
+			continue
+		}
+		if s, ok := escapes[c]; ok {
+			b.WriteString(s)
+			continue
+		}
+		switch c {
+		case '+', '=', '?':
+			if c == '+' {
+				b.WriteString("+")
+			} else {
+				b.WriteString("?")
+			}
+		case '{':
+			end := strings.IndexByte(pat[i:], '}')
+			if end < 0 {
+				return nil, `unterminated \{ in pattern`
+			}
+			s, ok := multi(strings.TrimSuffix(pat[i+1:i+end], `\`))
+			if !ok {
+				return nil, `invalid \{ in pattern`
+			}
+			b.WriteString(s)
+			i += end
+		case '(':
+			b.WriteString("(")
+			atomStart = true
+		case '%':
+			if i+1 < len(pat) && pat[i+1] == '(' {
+				b.WriteString("(?:")
+				atomStart = true
+				i++
+				break
+			}
+			return nil, `\% in pattern`
+		case ')':
+			b.WriteString(")")
+		case '|':
+			b.WriteString("|")
+			atomStart = true
+		case 'c':
+			ic = true
+		case 'C':
+			ic = false
+		case 'm':
+			// 'magic' is on already.
+		case '\\', '/', '.', '*', '[', ']', '~', '^', '$':
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		default:
+			return nil, `\` + string(c) + " in pattern"
+		}
+	}
+	expr := b.String()
+	if ic {
+		expr = "(?i)" + expr
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, "pattern: " + err.Error()
+	}
+	return re, ""
+}
+
+// multi returns the Go repetition of the contents of \{}, e.g. "{2,3}" for
+// "2,3" and "*?" for "-".
+func multi(s string) (string, bool) {
+	lazy := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || c == ',') {
+			return "", false
+		}
+	}
+	var r string
+	switch {
+	case s == "" || s == ",":
+		r = "*"
+	case strings.Count(s, ",") > 1:
+		return "", false
+	case strings.HasPrefix(s, ","):
+		r = "{0" + s + "}"
+	default:
+		r = "{" + s + "}"
+	}
+	if lazy {
+		r += "?"
+	}
+	return r, true
+}
+
+// collection returns the Go character class of the collection at the start
+// of pat, e.g. "[a-z]", and its length. The length is 0 if the collection
+// is not terminated, which makes "[" literal.
+func collection(pat string) (string, int, string) {
+	var b strings.Builder
+	b.WriteByte('[')
+	i := 1
+	if i < len(pat) && pat[i] == '^' {
+		b.WriteByte('^')
+		i++
+	}
+	if i < len(pat) && pat[i] == ']' {
+		b.WriteString(`\]`)
+		i++
+	}
+	for ; i < len(pat); i++ {
+		c := pat[i]
+		switch {
+		case c == ']':
+			b.WriteByte(']')
+			return b.String(), i + 1, ""
+		case c == '[':
+			for _, cls := range posixClasses {
+				if strings.HasPrefix(pat[i:], cls) {
+					b.WriteString(cls)
+					i += len(cls) - 1
+					break
+				}
+			}
+			if pat[i] == '[' {
+				if strings.HasPrefix(pat[i:], "[:") || strings.HasPrefix(pat[i:], "[=") || strings.HasPrefix(pat[i:], "[.") {
+					return "", 0, pat[i:i+2] + " in collection"
+				}
+				b.WriteString(`\[`)
+			}
+		case c == '\\' && i+1 < len(pat):
+			switch d := pat[i+1]; d {
+			case '\\', ']', '^', '-':
+				b.WriteString(`\` + string(d))
+				i++
+			case 'e', 't', 'r', 'b', 'n':
+				b.WriteString(escapes[d])
+				i++
+			case 'd', 'o', 'x', 'u', 'U':
+				return "", 0, `\` + string(d) + " in collection"
+			default:
+				// A backslash followed by the other characters is
+				// literal.
+				b.WriteString(`\\`)
+			}
+		case c == '-':
+			b.WriteByte('-')
+		default:
+			_, n := utf8.DecodeRuneInString(pat[i:])
+			b.WriteString(regexp.QuoteMeta(pat[i : i+n]))
+			i += n - 1
+		}
+	}
+	return "", 0, ""
+}
@@ -0,0 +1,324 @@
+package eval
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of values, which is the result of type() of Vim, e.g.
+// v:t_number.
+type Type int
+
+const (
+	NumberType  Type = 0
+	StringType  Type = 1
+	FuncType    Type = 2
+	ListType    Type = 3
+	DictType    Type = 4
+	FloatType   Type = 5
+	BoolType    Type = 6
+	SpecialType Type = 7 // v:null and v:none
+	BlobType    Type = 10
+)
+
+// Value is a value of Vim script. It's one of Number, Float, String, Bool,
+// Special, *List, *Dict and *Blob. Lists, dictionaries and blobs are
+// pointers since "is" compares the identity of them.
+type Value interface {
+	Type() Type
+}
+
+type (
+	Number int64
+	Float  float64
+	String string // bytes in UTF-8; it doesn't contain NUL
+	Bool   bool   // v:true and v:false
+)
+
+// Special is v:null or v:none.
+type Special int
+
+const (
+	None Special = 2 // v:none
+	Null Special = 3 // v:null
+)
+
+// List is a List.
+type List struct {
+	Items []Value
+}
+
+// Blob is a Blob.
+type Blob struct {
+	Bytes []byte
+}
+
+func (Number) Type() Type  { return NumberType }
+func (Float) Type() Type   { return FloatType }
+func (String) Type() Type  { return StringType }
+func (Bool) Type() Type    { return BoolType }
+func (Special) Type() Type { return SpecialType }
+func (*List) Type() Type   { return ListType }
+func (*Dict) Type() Type   { return DictType }
+func (*Blob) Type() Type   { return BlobType }
+
+// Repr returns the string representation of v, which is the result of
+// string().
+func Repr(v Value) string {
+	var b strings.Builder
+	repr(&b, v)
+	return b.String()
+}
+
+func repr(b *strings.Builder, v Value) {
+	switch v := v.(type) {
+	case String:
+		b.WriteString(quote(string(v)))
+	case *List:
+		b.WriteByte('[')
+		for i, x := range v.Items {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			repr(b, x)
+		}
+		b.WriteByte(']')
+	case *Dict:
+		b.WriteByte('{')
+		for i, k := range v.Keys() {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			x, _ := v.Get(k)
+			b.WriteString(quote(k))
+			b.WriteString(": ")
+			repr(b, x)
+		}
+		b.WriteByte('}')
+	case *Blob:
+		b.WriteString("0z")
+		for i, c := range v.Bytes {
+			if i > 0 && i%4 == 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(strings.ToUpper(strconv.FormatUint(uint64(c)|0x100, 16)[1:]))
+		}
+	default:
+		s, _ := toString(v)
+		b.WriteString(s)
+	}
+}
+
+// quote returns s in single quotes.
+func quote(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
+
+// formatFloat formats f like "%g" of vim_snprintf(): it uses the
+// exponential format if f is out of [0.001, 1e7), removes superfluous zeros
+// but the one just after the dot, and removes "+" and leading zeros from
+// the exponent, e.g. "1.0", "0.333333" and "1.234568e8".
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	case math.IsNaN(f):
+		return "nan"
+	}
+	if abs := math.Abs(f); abs >= 0.001 && abs < 1e7 || abs == 0 {
+		return trimZeros(strconv.FormatFloat(f, 'f', 6, 64))
+	}
+	s := strconv.FormatFloat(f, 'e', 6, 64)
+	i := strings.IndexByte(s, 'e')
+	exp := s[i+1:]
+	sign := ""
+	if exp[0] == '-' {
+		sign = "-"
+	}
+	exp = strings.TrimLeft(exp[1:], "0")
+	return trimZeros(s[:i]) + "e" + sign + exp
+}
+
+// trimZeros removes trailing zeros of the fraction but the one just after
+// the dot.
+func trimZeros(s string) string {
+	for len(s) > 2 && s[len(s)-1] == '0' && s[len(s)-2] != '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// The conversions return the messages of the errors of Vim.
+
+// toNumber converts v to a Number like tv_get_number_chk().
+func toNumber(v Value) (int64, string) {
+	switch v := v.(type) {
+	case Number:
+		return int64(v), ""
+	case String:
+		return str2nr(string(v)), ""
+	case Bool:
+		if v {
+			return 1, ""
+		}
+		return 0, ""
+	case Special:
+		return 0, ""
+	case Float:
+		return 0, "E805: Using a Float as a Number"
+	case *List:
+		return 0, "E745: Using a List as a Number"
+	case *Dict:
+		return 0, "E728: Using a Dictionary as a Number"
+	case *Blob:
+		return 0, "E974: Using a Blob as a Number"
+	}
+	panic("unreachable")
+}
+
+// toFloat converts v to a Float like tv_get_float().
+func toFloat(v Value) (float64, string) {
+	switch v := v.(type) {
+	case Number:
+		return float64(v), ""
+	case Float:
+		return float64(v), ""
+	case String:
+		return 0, "E892: Using a String as a Float"
+	case Bool:
+		return 0, "E362: Using a boolean value as a Float"
+	case Special:
+		return 0, "E907: Using a special value as a Float"
+	case *List:
+		return 0, "E893: Using a List as a Float"
+	case *Dict:
+		return 0, "E894: Using a Dictionary as a Float"
+	case *Blob:
+		return 0, "E975: Using a Blob as a Float"
+	}
+	panic("unreachable")
+}
+
+// toString converts v to a String like tv_get_string_chk(). Floats are
+// formatted with formatFloat.
+func toString(v Value) (string, string) {
+	switch v := v.(type) {
+	case Number:
+		return strconv.FormatInt(int64(v), 10), ""
+	case Float:
+		return formatFloat(float64(v)), ""
+	case String:
+		return string(v), ""
+	case Bool:
+		if v {
+			return "v:true", ""
+		}
+		return "v:false", ""
+	case Special:
+		if v == None {
+			return "v:none", ""
+		}
+		return "v:null", ""
+	case *List:
+		return "", "E730: Using a List as a String"
+	case *Dict:
+		return "", "E731: Using a Dictionary as a String"
+	case *Blob:
+		return "", "E976: Using a Blob as a String"
+	}
+	panic("unreachable")
+}
+
+// truthy reports whether v is truthy for "??" like tv2bool().
+func truthy(v Value) bool {
+	switch v := v.(type) {
+	case Number:
+		return v != 0
+	case Float:
+		return v != 0
+	case String:
+		return v != ""
+	case Bool:
+		return bool(v)
+	case *List:
+		return len(v.Items) > 0
+	case *Dict:
+		return v.Len() > 0
+	case *Blob:
+		return len(v.Bytes) > 0
+	}
+	return false
+}
+
+// equal reports whether v and w are equal like tv_equal(). ic ignores the
+// case of strings.
+func equal(v, w Value, ic bool) bool {
+	if v.Type() != w.Type() && !(isSpecial(v) && isSpecial(w)) {
+		return false
+	}
+	switch v := v.(type) {
+	case *List:
+		w := w.(*List)
+		if v == w {
+			return true
+		}
+		if len(v.Items) != len(w.Items) {
+			return false
+		}
+		for i := range v.Items {
+			if !equal(v.Items[i], w.Items[i], ic) {
+				return false
+			}
+		}
+		return true
+	case *Dict:
+		w := w.(*Dict)
+		if v == w {
+			return true
+		}
+		if v.Len() != w.Len() {
+			return false
+		}
+		for _, k := range v.Keys() {
+			x, _ := v.Get(k)
+			y, ok := w.Get(k)
+			if !ok || !equal(x, y, ic) {
+				return false
+			}
+		}
+		return true
+	case *Blob:
+		return string(v.Bytes) == string(w.(*Blob).Bytes)
+	case String:
+		return compareStrings(string(v), string(w.(String)), ic) == 0
+	case Float:
+		return v == w.(Float)
+	case Number:
+		return v == w.(Number)
+	}
+	return specialNumber(v) == specialNumber(w)
+}
+
+// isSpecial reports whether v is a Bool or a Special.
+func isSpecial(v Value) bool {
+	t := v.Type()
+	return t == BoolType || t == SpecialType
+}
+
+// specialNumber returns the number of the Bool or Special v in Vim, e.g.
+// VVAL_TRUE.
+func specialNumber(v Value) int {
+	switch v := v.(type) {
+	case Bool:
+		if v {
+			return 1
+		}
+		return 0
+	case Special:
+		return int(v)
+	}
+	return -1
+}
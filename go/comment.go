@@ -0,0 +1,83 @@
+package vimlparser
+
+import (
+	"sort"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// take_trailing_comments removes trailing comments (`echo 1 " comment`)
+// from bodies of node and returns them. Comments after `|` are kept as
+// statements because they are separate commands.
+func (self *StringReader) take_trailing_comments(node *VimNode) []*VimNode {
+	if node == nil {
+		return nil
+	}
+	var comments []*VimNode
+	var body = node.body[:0]
+	for _, n := range node.body {
+		if n != nil && n.type_ == NODE_COMMENT && self.is_trailing(n.pos) {
+			comments = append(comments, n)
+			continue
+		}
+		body = append(body, n)
+		comments = append(comments, self.take_trailing_comments(n)...)
+	}
+	node.body = body
+	for _, n := range node.elseif {
+		comments = append(comments, self.take_trailing_comments(n)...)
+	}
+	comments = append(comments, self.take_trailing_comments(node.else_)...)
+	for _, n := range node.catch {
+		comments = append(comments, self.take_trailing_comments(n)...)
+	}
+	comments = append(comments, self.take_trailing_comments(node.finally)...)
+	return comments
+}
+
+// is_trailing reports whether the comment at p follows a command in the same
+// line.
+func (self *StringReader) is_trailing(p *pos) bool {
+	for i := p.i - 1; i >= 0; i-- {
+		switch c := self.buf[i]; c {
+		case " ", "\t", ":":
+		case "<EOL>", "|":
+			return false
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// newFile converts TOPLEVEL node to ast.File with comments and blank lines
+// which are not part of the tree.
+func newFile(n *VimNode, reader *StringReader, filename string) *ast.File {
+	var comments = append(reader.take_trailing_comments(n), reader.comments...)
+	sort.SliceStable(comments, func(i, j int) bool {
+		var p, q = comments[i].pos, comments[j].pos
+		return p.lnum < q.lnum || p.lnum == q.lnum && p.col < q.col
+	})
+	var continuation = make(map[*VimNode]bool, len(reader.comments))
+	for _, c := range reader.comments {
+		continuation[c] = true
+	}
+	reader.set_endpos(n)
+	f := newAstNode(n, filename).(*ast.File)
+	var g *ast.CommentGroup
+	for _, c := range comments {
+		comment := newAstNode(c, filename).(*ast.Comment)
+		// `"\ ` comments in consecutive lines make a group.
+		if g != nil && continuation[c] && c.pos.lnum == g.List[len(g.List)-1].Quote.Line+1 {
+			g.List = append(g.List, comment)
+			continue
+		}
+		g = &ast.CommentGroup{List: []*ast.Comment{comment}}
+		f.Comments = append(f.Comments, g)
+		if !continuation[c] {
+			g = nil
+		}
+	}
+	f.BlankLines = reader.blanklines
+	return f
+}
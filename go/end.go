@@ -0,0 +1,441 @@
+package vimlparser
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// set_endpos sets endpos of node and its descendants. The parser doesn't
+// record where nodes end, so it scans the buffer from the end of the last
+// child node. endpos is the position immediately after the node and the end
+// of statements doesn't include trailing white spaces, comments and "|".
+func (self *StringReader) set_endpos(node *VimNode) {
+	if node != nil && node.endpos == nil {
+		self.end(node)
+	}
+}
+
+// end returns the buffer index immediately after node, setting endpos.
+func (self *StringReader) end(node *VimNode) int {
+	if node.endpos != nil {
+		return node.endpos.i
+	}
+	var i = self.end_index(node)
+	node.endpos = self.endpos(i)
+	return i
+}
+
+// endpos returns the position immediately after the character at i-1. It's
+// different from the position of the character at i if the character is in
+// the next line.
+func (self *StringReader) endpos(i int) *pos {
+	if i <= 0 || i > len(self.buf) {
+		var p = self.pos[0]
+		if i > 0 {
+			p = self.pos[len(self.pos)-1]
+		}
+		p.i = i
+		return &p
+	}
+	var p = self.pos[i-1]
+	var c = self.buf[i-1]
+	if c == "<EOL>" {
+		c = "\n"
+	}
+	p.col += len(c)
+	p.offset += len(c)
+	p.i = i
+	return &p
+}
+
+func (self *StringReader) end_index(node *VimNode) int {
+	switch node.type_ {
+	case NODE_TOPLEVEL:
+		self.end_body(node.body, 0)
+		return len(self.buf)
+
+	case NODE_COMMENT:
+		return node.pos.i + len("\"") + runes(node.str)
+
+	case NODE_EXCMD:
+		return node.ea.linepos.i + runes(node.str)
+
+	case NODE_FUNCTION:
+		var i = self.end(node.left)
+		for _, n := range node.rlist {
+			i = max(i, self.end(n))
+		}
+		for _, n := range node.default_args {
+			i = max(i, self.end(n))
+		}
+		i = self.find(i, ")")
+		for {
+			var j = self.skip_white_from(i)
+			var k = j
+			for k < len(self.buf) && isalpha(self.buf[k]) {
+				k++
+			}
+			switch self.getstr(&pos{i: j}, &pos{i: k}) {
+			case "range", "abort", "dict", "closure":
+				i = k
+				continue
+			}
+			break
+		}
+		return self.end_block(node, i, node.endfunction)
+
+	case NODE_ENDFUNCTION, NODE_ENDIF, NODE_ENDWHILE, NODE_ENDFOR,
+		NODE_ENDTRY, NODE_CONTINUE, NODE_BREAK:
+		return self.end_cmdname(node)
+
+	case NODE_DELFUNCTION, NODE_EXCALL, NODE_THROW, NODE_EVAL:
+		return self.end(node.left)
+
+	case NODE_RETURN:
+		if node.left == nil {
+			return self.end_cmdname(node)
+		}
+		return self.end(node.left)
+
+	case NODE_LET, NODE_CONST:
+		for _, n := range node.list {
+			self.end(n)
+		}
+		self.set_endpos(node.left)
+		self.set_endpos(node.rest)
+		return self.end(node.right)
+
+	case NODE_UNLET, NODE_LOCKVAR, NODE_UNLOCKVAR,
+		NODE_ECHO, NODE_ECHON, NODE_ECHOMSG, NODE_ECHOERR, NODE_EXECUTE:
+		var i = self.end_cmdname(node)
+		for _, n := range node.list {
+			i = self.end(n)
+		}
+		return i
+
+	case NODE_IF:
+		var i = self.end(node.cond)
+		for _, n := range node.elseif {
+			i = self.end(n)
+		}
+		if node.else_ != nil {
+			i = self.end(node.else_)
+		}
+		return self.end_block(node, i, node.endif)
+
+	case NODE_ELSEIF:
+		return self.end_body(node.body, self.end(node.cond))
+
+	case NODE_ELSE, NODE_FINALLY:
+		return self.end_body(node.body, self.end_cmdname(node))
+
+	case NODE_WHILE:
+		return self.end_block(node, self.end(node.cond), node.endwhile)
+
+	case NODE_FOR:
+		for _, n := range node.list {
+			self.end(n)
+		}
+		self.set_endpos(node.left)
+		self.set_endpos(node.rest)
+		return self.end_block(node, self.end(node.right), node.endfor)
+
+	case NODE_TRY:
+		var i = self.end_cmdname(node)
+		for _, n := range node.catch {
+			i = self.end(n)
+		}
+		if node.finally != nil {
+			i = self.end(node.finally)
+		}
+		return self.end_block(node, i, node.endtry)
+
+	case NODE_CATCH:
+		var i = self.end_cmdname(node)
+		if node.pattern != "" {
+			i = self.skip_white_from(i)
+			var delim = self.buf[i]
+			i += len(delim) + runes(node.pattern)
+			if i < len(self.buf) && self.buf[i] == delim {
+				i++
+			}
+		}
+		return self.end_body(node.body, i)
+
+	case NODE_ECHOHL:
+		return self.skip_white_from(self.end_cmdname(node)) + runes(node.str)
+
+	case NODE_DEF:
+		var i = self.end(node.left)
+		for _, n := range node.params {
+			i = max(i, self.end(n))
+		}
+		i = self.find(i, ")")
+		if node.rtype != nil {
+			i = self.end(node.rtype)
+		}
+		return self.end_block(node, i, node.enddef)
+
+	case NODE_ENDDEF, NODE_ENDCLASS:
+		return self.end_cmdname(node)
+
+	case NODE_PARAM:
+		var i = self.end(node.left)
+		if node.rtype != nil {
+			i = self.end(node.rtype)
+		}
+		if node.right != nil {
+			i = self.end(node.right)
+		}
+		return i
+
+	case NODE_TYPE:
+		return node.pos.i + runes(node.str)
+
+	case NODE_VAR, NODE_ASSIGN:
+		var i = node.pos.i
+		for _, n := range node.list {
+			i = self.end(n)
+		}
+		if node.rest != nil {
+			i = self.end(node.rest)
+		}
+		if node.list != nil {
+			i = self.find(i, "]")
+		}
+		if node.left != nil {
+			i = self.end(node.left)
+		}
+		if node.rtype != nil {
+			i = self.end(node.rtype)
+		}
+		if node.right != nil {
+			i = self.end(node.right)
+		}
+		return i
+
+	case NODE_EXPRSTMT:
+		return self.end(node.left)
+
+	case NODE_IMPORT:
+		var i = self.end(node.left)
+		if node.right != nil {
+			i = self.end(node.right)
+		}
+		return i
+
+	case NODE_CLASS:
+		var i = self.end(node.left)
+		if node.right != nil {
+			i = self.end(node.right)
+		}
+		for _, n := range node.list {
+			i = self.end(n)
+		}
+		for _, n := range node.rlist {
+			i = max(i, self.end(n))
+		}
+		return self.end_block(node, i, node.endclass)
+
+	case NODE_LAMBDA9:
+		var i = node.pos.i + 1
+		for _, n := range node.params {
+			i = self.end(n)
+		}
+		if node.rtype != nil {
+			i = self.end(node.rtype)
+		}
+		if node.left != nil {
+			return self.end(node.left)
+		}
+		return self.find(self.end_body(node.body, i), "}")
+
+	case NODE_CAST:
+		self.end(node.rtype)
+		return self.end(node.left)
+
+	case NODE_AUTOCMD:
+		if len(node.body) > 0 {
+			return self.end_body(node.body, 0)
+		}
+		return node.ea.linepos.i + runes(node.str)
+
+	case NODE_AUGROUP, NODE_MAP, NODE_USERCOMMAND, NODE_HIGHLIGHT, NODE_SYNTAX, NODE_SET, NODE_FOREIGNCODE:
+		return node.ea.linepos.i + runes(node.str)
+
+	case NODE_INTERPOLATED:
+		for _, n := range node.list {
+			self.end(n)
+		}
+		return node.pos.i + runes(node.value.(string))
+
+	case NODE_TERNARY:
+		self.end(node.cond)
+		self.end(node.left)
+		return self.end(node.right)
+
+	case NODE_OR, NODE_AND, NODE_EQUAL, NODE_EQUALCI, NODE_EQUALCS,
+		NODE_NEQUAL, NODE_NEQUALCI, NODE_NEQUALCS, NODE_GREATER,
+		NODE_GREATERCI, NODE_GREATERCS, NODE_GEQUAL, NODE_GEQUALCI,
+		NODE_GEQUALCS, NODE_SMALLER, NODE_SMALLERCI, NODE_SMALLERCS,
+		NODE_SEQUAL, NODE_SEQUALCI, NODE_SEQUALCS, NODE_MATCH,
+		NODE_MATCHCI, NODE_MATCHCS, NODE_NOMATCH, NODE_NOMATCHCI,
+		NODE_NOMATCHCS, NODE_IS, NODE_ISCI, NODE_ISCS, NODE_ISNOT,
+		NODE_ISNOTCI, NODE_ISNOTCS, NODE_ADD, NODE_SUBTRACT, NODE_CONCAT,
+		NODE_MULTIPLY, NODE_DIVIDE, NODE_REMAINDER, NODE_DOT, NODE_METHOD,
+		NODE_FALSY:
+		if node.type_ == NODE_CONCAT && node.pos.i+1 < len(self.buf) && self.buf[node.pos.i+1] == "." {
+			node.dotdot = true
+		}
+		self.end(node.left)
+		return self.end(node.right)
+
+	case NODE_NOT, NODE_MINUS, NODE_PLUS:
+		return self.end(node.left)
+
+	case NODE_SUBSCRIPT:
+		self.end(node.left)
+		return self.find(self.end(node.right), "]")
+
+	case NODE_SLICE:
+		var i = max(self.end(node.left), node.pos.i+1)
+		for _, n := range node.rlist {
+			if n != nil {
+				i = self.end(n)
+			}
+		}
+		return self.find(i, "]")
+
+	case NODE_CALL:
+		var i = max(self.end(node.left), node.pos.i+1)
+		for _, n := range node.rlist {
+			i = self.end(n)
+		}
+		return self.find(i, ")")
+
+	case NODE_LIST:
+		var i = node.pos.i + 1
+		for _, n := range node.value.([]interface{}) {
+			i = self.end(n.(*VimNode))
+		}
+		return self.find(i, "]")
+
+	case NODE_DICT:
+		node.literal = node.pos.i < len(self.buf) && self.buf[node.pos.i] == "#"
+		var i = node.pos.i + 1
+		for _, kv := range node.value.([]interface{}) {
+			self.end(kv.([]interface{})[0].(*VimNode))
+			i = self.end(kv.([]interface{})[1].(*VimNode))
+		}
+		return self.find(i, "}")
+
+	case NODE_STRING:
+		var s = node.value.(string)
+		if node.pos.i < len(self.buf) && s != "" && !strings.HasPrefix(s, self.buf[node.pos.i]) {
+			// literal key of #{} dictionary. The value is quoted and the
+			// position is at the second character.
+			var i = node.pos.i
+			for i < len(self.buf) && (isalnum(self.buf[i]) || self.buf[i] == "_" || self.buf[i] == "-") {
+				i++
+			}
+			return i
+		}
+		return node.pos.i + runes(s)
+
+	case NODE_NUMBER, NODE_BLOB, NODE_OPTION, NODE_IDENTIFIER, NODE_ENV,
+		NODE_REG, NODE_CURLYNAMEPART:
+		return node.pos.i + runes(node.value.(string))
+
+	case NODE_CURLYNAME:
+		var i int
+		for _, n := range node.value.([]*VimNode) {
+			i = self.end(n)
+		}
+		return i
+
+	case NODE_CURLYNAMEEXPR, NODE_PARENEXPR:
+		var i = self.end(node.value.(*VimNode))
+		if node.type_ == NODE_PARENEXPR {
+			return self.find(i, ")")
+		}
+		return self.find(i, "}")
+
+	case NODE_LAMBDA:
+		for _, n := range node.rlist {
+			self.end(n)
+		}
+		return self.find(self.end(node.left), "}")
+
+	case NODE_HEREDOC:
+		var i = node.pos.i
+		for _, n := range node.rlist {
+			self.end(n)
+		}
+		for _, n := range node.body {
+			i = self.end(n)
+		}
+		return self.find(i, "<EOL>") + runes(node.op)
+	}
+	return node.pos.i
+}
+
+// end_body returns the end of the last statement in body or i if body is
+// empty.
+func (self *StringReader) end_body(body []*VimNode, i int) int {
+	for _, n := range body {
+		i = self.end(n)
+	}
+	return i
+}
+
+// end_block returns the end of the block statement. The end node is nil if
+// the block is not closed in the error-recovering mode, so it returns the end
+// of the last statement instead.
+func (self *StringReader) end_block(node *VimNode, i int, endnode *VimNode) int {
+	i = max(i, self.end_body(node.body, i))
+	if endnode != nil {
+		return self.end(endnode)
+	}
+	return i
+}
+
+// end_cmdname returns the end of the command name of the node including "!".
+func (self *StringReader) end_cmdname(node *VimNode) int {
+	var i = node.ea.cmdpos.i
+	for i < len(self.buf) && isalpha(self.buf[i]) {
+		i++
+	}
+	if node.ea.forceit && i < len(self.buf) && self.buf[i] == "!" {
+		i++
+	}
+	return i
+}
+
+// find returns the index immediately after c from i.
+func (self *StringReader) find(i int, c string) int {
+	for ; i < len(self.buf); i++ {
+		if self.buf[i] == c {
+			return i + 1
+		}
+	}
+	return i
+}
+
+// skip_white_from returns the index of the first non-white character from i.
+func (self *StringReader) skip_white_from(i int) int {
+	for i < len(self.buf) && iswhite(self.buf[i]) {
+		i++
+	}
+	return i
+}
+
+func runes(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
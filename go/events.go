@@ -0,0 +1,139 @@
+package vimlparser
+
+// autocmd_events are events of :autocmd in lower case, including events of
+// Neovim.
+var autocmd_events = map[string]bool{
+	"bufadd":               true,
+	"bufcreate":            true,
+	"bufdelete":            true,
+	"bufenter":             true,
+	"buffilepost":          true,
+	"buffilepre":           true,
+	"bufhidden":            true,
+	"bufleave":             true,
+	"bufmodifiedset":       true,
+	"bufnew":               true,
+	"bufnewfile":           true,
+	"bufread":              true,
+	"bufreadcmd":           true,
+	"bufreadpost":          true,
+	"bufreadpre":           true,
+	"bufunload":            true,
+	"bufwinenter":          true,
+	"bufwinleave":          true,
+	"bufwipeout":           true,
+	"bufwrite":             true,
+	"bufwritecmd":          true,
+	"bufwritepost":         true,
+	"bufwritepre":          true,
+	"chaninfo":             true,
+	"chanopen":             true,
+	"cmdlinechanged":       true,
+	"cmdlineenter":         true,
+	"cmdlineleave":         true,
+	"cmdundefined":         true,
+	"cmdwinenter":          true,
+	"cmdwinleave":          true,
+	"colorscheme":          true,
+	"colorschemepre":       true,
+	"completechanged":      true,
+	"completedone":         true,
+	"completedonepre":      true,
+	"cursorhold":           true,
+	"cursorholdi":          true,
+	"cursormoved":          true,
+	"cursormovedi":         true,
+	"diffupdated":          true,
+	"dirchanged":           true,
+	"dirchangedpre":        true,
+	"encodingchanged":      true,
+	"exitpre":              true,
+	"fileappendcmd":        true,
+	"fileappendpost":       true,
+	"fileappendpre":        true,
+	"filechangedro":        true,
+	"filechangedshell":     true,
+	"filechangedshellpost": true,
+	"fileencoding":         true,
+	"filereadcmd":          true,
+	"filereadpost":         true,
+	"filereadpre":          true,
+	"filetype":             true,
+	"filewritecmd":         true,
+	"filewritepost":        true,
+	"filewritepre":         true,
+	"filterreadpost":       true,
+	"filterreadpre":        true,
+	"filterwritepost":      true,
+	"filterwritepre":       true,
+	"focusgained":          true,
+	"focuslost":            true,
+	"funcundefined":        true,
+	"guienter":             true,
+	"guifailed":            true,
+	"insertchange":         true,
+	"insertcharpre":        true,
+	"insertenter":          true,
+	"insertleave":          true,
+	"insertleavepre":       true,
+	"menupopup":            true,
+	"modechanged":          true,
+	"optionset":            true,
+	"quickfixcmdpost":      true,
+	"quickfixcmdpre":       true,
+	"quitpre":              true,
+	"recordingenter":       true,
+	"recordingleave":       true,
+	"remotereply":          true,
+	"safestate":            true,
+	"safestateagain":       true,
+	"searchwrapped":        true,
+	"sessionloadpost":      true,
+	"sessionwritepost":     true,
+	"shellcmdpost":         true,
+	"shellfilterpost":      true,
+	"signal":               true,
+	"sigusr1":              true,
+	"sourcecmd":            true,
+	"sourcepost":           true,
+	"sourcepre":            true,
+	"spellfilemissing":     true,
+	"stdinreadpost":        true,
+	"stdinreadpre":         true,
+	"swapexists":           true,
+	"syntax":               true,
+	"tabclosed":            true,
+	"tabenter":             true,
+	"tableave":             true,
+	"tabnew":               true,
+	"tabnewentered":        true,
+	"termchanged":          true,
+	"termclose":            true,
+	"termenter":            true,
+	"terminalopen":         true,
+	"terminalwinopen":      true,
+	"termleave":            true,
+	"termopen":             true,
+	"termresponse":         true,
+	"textchanged":          true,
+	"textchangedi":         true,
+	"textchangedp":         true,
+	"textchangedt":         true,
+	"textyankpost":         true,
+	"uienter":              true,
+	"uileave":              true,
+	"user":                 true,
+	"usergettingbored":     true,
+	"vimenter":             true,
+	"vimleave":             true,
+	"vimleavepre":          true,
+	"vimresized":           true,
+	"vimresume":            true,
+	"vimsuspend":           true,
+	"winclosed":            true,
+	"winenter":             true,
+	"winleave":             true,
+	"winnew":               true,
+	"winresized":           true,
+	"winscrolled":          true,
+}
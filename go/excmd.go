@@ -0,0 +1,1204 @@
+package vimlparser
+
+import "strings"
+
+// Node types of Ex commands which vim-vimlparser parses as EXCMD. They only
+// exist in the Go port.
+var NODE_AUTOCMD = 316
+var NODE_AUGROUP = 317
+var NODE_MAP = 318
+var NODE_USERCOMMAND = 319
+var NODE_PLACEHOLDER = 320
+var NODE_HIGHLIGHT = 321
+var NODE_SYNTAX = 322
+var NODE_SET = 323
+var NODE_FOREIGNCODE = 324
+
+// AUTOCMD .ea .str .autocmd .body
+// AUGROUP .ea .str .value
+// MAP .ea .str .mapping
+// USERCOMMAND .ea .str .usercmd
+// PLACEHOLDER .value
+// HIGHLIGHT .ea .str .highlight
+// SYNTAX .ea .str .syntax
+// SET .ea .str .options
+// FOREIGNCODE .ea .str .foreign
+
+// AutocmdArg is arguments of :autocmd before the command.
+type AutocmdArg struct {
+	group    string
+	events   []string
+	patterns []string
+	once     bool
+	nested   bool
+}
+
+// parse_excmd replaces the EXCMD node at n of parent.body, which is added by
+// parse_cmd_common, with the node of the command parsed from its argument.
+// The str of the new node is the text of the command as EXCMD.
+func (self *VimLParser) parse_excmd(parent *VimNode, n int) {
+	var m = len(parent.body)
+	// A trailing comment, e.g. `set ts=4 " comment`, is added after the
+	// command.
+	if m == n+2 && parent.body[n+1].type_ == NODE_COMMENT {
+		m = n + 1
+	}
+	if m != n+1 {
+		return
+	}
+	var node = parent.body[n]
+	if node.type_ != NODE_EXCMD || node.ea == nil || node.ea.cmd == nil || node.ea.argpos == nil {
+		return
+	}
+	if len(parent.body) > m {
+		var comment = parent.body[m]
+		parent.body = parent.body[:m]
+		defer func() {
+			parent.body = append(parent.body, comment)
+		}()
+	}
+	switch node.ea.cmd.name {
+	case "autocmd":
+		self.parse_cmd_autocmd(parent, n)
+	case "augroup":
+		parent.body[n] = self.parse_cmd_augroup(node)
+	case "command":
+		parent.body[n] = self.parse_cmd_usercommand(node)
+	case "highlight":
+		parent.body[n] = self.parse_cmd_highlight(node)
+	case "syntax":
+		parent.body[n] = self.parse_cmd_syntax_args(node)
+	case "set", "setlocal", "setglobal":
+		parent.body[n] = self.parse_cmd_set(node)
+	default:
+		if foreign_languages[node.ea.cmd.parser] {
+			parent.body[n] = self.parse_cmd_foreign(node)
+		} else if map_modes(node.ea.cmd.name, node.ea.forceit) != "" {
+			parent.body[n] = self.parse_cmd_map(node)
+		}
+	}
+}
+
+// parse_cmd_autocmd parses :autocmd from the argument. The command is parsed
+// in the context of AUTOCMD node until the end of line, because "|" is a
+// part of the command.
+//
+//	:au[tocmd][!] [group] {event} {aupat} [++once] [++nested] {cmd}
+func (self *VimLParser) parse_cmd_autocmd(parent *VimNode, n int) {
+	var r = self.reader
+	var excmd = parent.body[n]
+	var node = Node(NODE_AUTOCMD)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	node.autocmd = &AutocmdArg{}
+	r.seek_set(node.ea.argpos.i)
+	// The group is not known by the parser. A word is a group unless it's
+	// events.
+	var word = self.read_autocmd_word()
+	if word != "" && !is_autocmd_events(word) {
+		node.autocmd.group = word
+		r.skip_white()
+		word = self.read_autocmd_word()
+	}
+	if word != "" {
+		node.autocmd.events = strings.Split(word, ",")
+		r.skip_white()
+	}
+	if r.peek() != "|" {
+		var s = ""
+		for !iswhite(r.peek()) && !self.ends_autocmd_line() || strings.HasSuffix(s, "\\") && iswhite(r.peek()) {
+			s += r.get()
+		}
+		node.autocmd.patterns = split_autocmd_patterns(s)
+		self.parse_autocmd_flags(node.autocmd)
+	}
+	if r.peek() == "|" || self.ends_autocmd_line() {
+		// no command: the rest of the line is the next command.
+		node.str = strings.TrimRight(r.getstr(node.ea.linepos, r.getpos()), " \t")
+		if r.peek() != "<EOF>" {
+			r.get()
+		}
+		parent.body[n] = node
+		return
+	}
+	var context = self.context
+	defer func() {
+		if e := recover(); e != nil {
+			// let the error-recovering parser skip the whole :autocmd.
+			self.context = context
+			parent.body = parent.body[:n]
+			self.ea = node.ea
+			panic(e)
+		}
+	}()
+	self.push_context(node)
+	for {
+		self.parse_next_cmd()
+		if r.peek() == "<EOF>" || r.buf[r.tell()-1] == "<EOL>" {
+			break
+		}
+	}
+	self.check_missing_endfunction("AUTOCMD", r.getpos())
+	self.check_missing_endif("AUTOCMD", r.getpos())
+	self.check_missing_endtry("AUTOCMD", r.getpos())
+	self.check_missing_endwhile("AUTOCMD", r.getpos())
+	self.check_missing_endfor("AUTOCMD", r.getpos())
+	self.pop_context()
+	parent.body[n] = node
+}
+
+// read_autocmd_word reads the group or events of :autocmd.
+func (self *VimLParser) read_autocmd_word() string {
+	var s = ""
+	for !iswhite(self.reader.peek()) && self.reader.peek() != "|" && !self.ends_autocmd_line() {
+		s += self.reader.get()
+	}
+	return s
+}
+
+func (self *VimLParser) ends_autocmd_line() bool {
+	var c = self.reader.peek()
+	return c == "<EOL>" || c == "<EOF>"
+}
+
+// parse_autocmd_flags parses ++once, ++nested and "nested" of legacy script
+// after the patterns.
+func (self *VimLParser) parse_autocmd_flags(arg *AutocmdArg) {
+	var r = self.reader
+	for {
+		r.skip_white()
+		var pos = r.tell()
+		var s = r.read_nonwhite()
+		if !iswhite(r.peek()) {
+			r.seek_set(pos)
+			return
+		}
+		if s == "++once" {
+			arg.once = true
+		} else if s == "++nested" || s == "nested" && !self.is_vim9() {
+			arg.nested = true
+		} else {
+			r.seek_set(pos)
+			return
+		}
+	}
+}
+
+// is_autocmd_events reports whether s is "*" or comma separated events.
+func is_autocmd_events(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for _, e := range strings.Split(s, ",") {
+		if !autocmd_events[strings.ToLower(e)] {
+			return false
+		}
+	}
+	return true
+}
+
+// split_autocmd_patterns splits comma separated patterns of :autocmd. A
+// comma in braces or after backslash doesn't separate patterns, e.g.
+// "*.{c,h}".
+func split_autocmd_patterns(s string) []string {
+	var patterns []string
+	var level = 0
+	var start = 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			level++
+		case '}':
+			level--
+		case ',':
+			if level == 0 && (i == 0 || s[i-1] != '\\') {
+				patterns = append(patterns, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if start < len(s) {
+		patterns = append(patterns, s[start:])
+	}
+	return patterns
+}
+
+// parse_cmd_augroup parses :augroup from the argument. The reader is kept at
+// the position after the command.
+//
+//	:aug[roup][!] {name}
+func (self *VimLParser) parse_cmd_augroup(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_AUGROUP)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var end = r.tell()
+	r.seek_set(node.ea.argpos.i)
+	var name = ""
+	for !iswhite(r.peek()) && !self.ends_excmds(r.peek()) {
+		name += r.get()
+	}
+	node.value = name
+	r.seek_set(end)
+	return node
+}
+
+// MapArg is arguments of mapping commands.
+type MapArg struct {
+	modes   string
+	noremap bool
+	unmap   bool
+	buffer  bool
+	nowait  bool
+	silent  bool
+	special bool
+	script  bool
+	expr    bool
+	unique  bool
+	lhs     string
+	rhs     string
+	rhsexpr *VimNode   // rhs of <expr>
+	cmds    []*VimNode // commands of <Cmd>...<CR> in rhs
+}
+
+// map_prefix_modes is modes of mapping commands by the prefix, e.g. "n" of
+// :nnoremap. Modes are n (Normal), x (Visual), s (Select), o
+// (Operator-pending), i (Insert), c (Command-line), l (Lang-Arg) and t
+// (Terminal).
+var map_prefix_modes = map[string]string{
+	"":  "nxso",
+	"n": "n",
+	"v": "xs",
+	"x": "x",
+	"s": "s",
+	"o": "o",
+	"i": "i",
+	"c": "c",
+	"l": "l",
+	"t": "t",
+}
+
+// map_modes returns modes of the mapping command name, e.g. "xs" for
+// "vnoremap". :map! and :noremap! are for Insert and Command-line mode. It
+// returns "" if name is not a mapping command.
+func map_modes(name string, forceit bool) string {
+	var prefix = ""
+	if strings.HasSuffix(name, "noremap") {
+		prefix = strings.TrimSuffix(name, "noremap")
+	} else if strings.HasSuffix(name, "unmap") {
+		prefix = strings.TrimSuffix(name, "unmap")
+	} else if strings.HasSuffix(name, "map") {
+		prefix = strings.TrimSuffix(name, "map")
+	} else {
+		return ""
+	}
+	if prefix == "" && forceit {
+		return "ic"
+	}
+	return map_prefix_modes[prefix]
+}
+
+// map_special_args is special arguments of mapping commands in lower case.
+var map_special_args = []string{"<buffer>", "<nowait>", "<silent>", "<special>", "<script>", "<expr>", "<unique>"}
+
+// parse_cmd_map parses mapping commands from the argument. The rhs of <expr>
+// is parsed as an expression and <Cmd>...<CR> in the rhs are parsed as
+// commands. The reader is kept at the position after the command.
+//
+//	:{mode}map [<buffer>] [<silent>] ... {lhs} {rhs}
+//	:{mode}noremap [<buffer>] [<silent>] ... {lhs} {rhs}
+//	:{mode}unmap [<buffer>] {lhs}
+func (self *VimLParser) parse_cmd_map(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_MAP)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var name = node.ea.cmd.name
+	var arg = &MapArg{
+		modes:   map_modes(name, node.ea.forceit),
+		noremap: strings.HasSuffix(name, "noremap"),
+		unmap:   strings.HasSuffix(name, "unmap"),
+	}
+	node.mapping = arg
+	var next = r.tell()
+	var end = node.ea.linepos.i + runes(node.str)
+	r.seek_set(node.ea.argpos.i)
+	for self.parse_map_special_arg(arg) {
+		r.skip_white()
+	}
+	var begin = r.tell()
+	if arg.unmap {
+		arg.lhs = strings.TrimRight(r.getstr(r.getpos(), &pos{i: end}), " \t")
+		r.seek_set(next)
+		return node
+	}
+	for r.tell() < end && !iswhite(r.peek()) {
+		if (r.peek() == "\\" || r.peek() == "\x16") && r.tell()+1 < end {
+			r.get()
+		}
+		r.get()
+	}
+	arg.lhs = r.getstr(&pos{i: begin}, r.getpos())
+	r.skip_white()
+	if r.tell() < end {
+		arg.rhs = r.getstr(r.getpos(), &pos{i: end})
+	}
+	if arg.expr && arg.rhs != "" {
+		arg.rhsexpr = self.parse_map_expr(r.tell(), end)
+	} else {
+		arg.cmds = self.parse_map_cmds(r.tell(), end)
+	}
+	r.seek_set(next)
+	return node
+}
+
+// parse_map_special_arg reads a special argument, e.g. <buffer>. It reports
+// whether the argument is read.
+func (self *VimLParser) parse_map_special_arg(arg *MapArg) bool {
+	var r = self.reader
+	for _, s := range map_special_args {
+		if strings.ToLower(r.peekn(len(s))) != s {
+			continue
+		}
+		r.getn(len(s))
+		switch s {
+		case "<buffer>":
+			arg.buffer = true
+		case "<nowait>":
+			arg.nowait = true
+		case "<silent>":
+			arg.silent = true
+		case "<special>":
+			arg.special = true
+		case "<script>":
+			arg.script = true
+		case "<expr>":
+			arg.expr = true
+		case "<unique>":
+			arg.unique = true
+		}
+		return true
+	}
+	return false
+}
+
+// parse_map_expr parses the rhs of <expr> mapping between begin and end of
+// the reader. The rhs isn't checked until the mapping is used, so it returns
+// nil if the rhs can't be parsed.
+func (self *VimLParser) parse_map_expr(begin, end int) (node *VimNode) {
+	defer recover_parse_error(func() { node = nil })
+	var r, _ = self.reader.notation_reader(begin, end, keycode_notations)
+	if self.is_vim9() {
+		node = NewVim9ExprParser(r, nil).parse_expr1()
+	} else {
+		node = NewExprParser(r).parse()
+	}
+	r.skip_white()
+	if r.peek() != "<EOL>" {
+		panic(Err(viml_printf("E488: Trailing characters: %s", r.peekline()), r.getpos()))
+	}
+	r.set_endpos(node)
+	return node
+}
+
+// parse_map_cmds parses commands of <Cmd>...<CR> and <ScriptCmd>...<CR>
+// between begin and end of the reader. The commands aren't checked until the
+// mapping is used, so it returns nil if any of them can't be parsed.
+func (self *VimLParser) parse_map_cmds(begin, end int) []*VimNode {
+	var r = self.reader
+	var cmds []*VimNode
+	for i := begin; i < end; i++ {
+		var key = ""
+		if r.has_key(i, end, "<cmd>") {
+			key = "<cmd>"
+		} else if r.has_key(i, end, "<scriptcmd>") {
+			key = "<scriptcmd>"
+		} else {
+			continue
+		}
+		var j = i + len(key)
+		var k = j
+		for k < end && !r.has_key(k, end, "<cr>") {
+			k++
+		}
+		if k == end {
+			break
+		}
+		var toplevel = self.parse_keycode_cmds(j, k)
+		if toplevel == nil {
+			return nil
+		}
+		cmds = append(cmds, toplevel.body...)
+		i = k + len("<cr>") - 1
+	}
+	return cmds
+}
+
+// has_key reports whether the text at i of the reader before end is key
+// notation s, which is lower case, e.g. "<cr>".
+func (self *StringReader) has_key(i, end int, s string) bool {
+	if i+len(s) > end {
+		return false
+	}
+	return strings.ToLower(strings.Join(self.buf[i:i+len(s)], "")) == s
+}
+
+// parse_keycode_cmds parses commands between begin and end of the reader in
+// which key notations are replaced, e.g. <Bar>. endpos of the nodes is set
+// by the reader of the commands. It returns nil if the commands can't be
+// parsed.
+func (self *VimLParser) parse_keycode_cmds(begin, end int) *VimNode {
+	var r, _ = self.reader.notation_reader(begin, end, keycode_notations)
+	var toplevel = self.parse_sub_script(r)
+	if toplevel != nil {
+		r.set_endpos(toplevel)
+	}
+	return toplevel
+}
+
+// keycode_notations is key notations replaced in <Cmd>...<CR> and the rhs
+// of <expr> mappings. "|" escaped by backslash or CTRL-V is also replaced.
+var keycode_notations = map[string]string{
+	"<bar>":    "|",
+	"<lt>":     "<",
+	"<bslash>": "\\",
+	"<space>":  " ",
+	"\\|":      "|",
+	"\x16|":    "|",
+}
+
+// notation_reader returns a reader of the text between begin and end of the
+// reader, in which notations, e.g. "<bar>" in lower case, are replaced.
+// Characters keep their positions in the original text and replaced ones
+// have the position of the notation. index maps indexes of the new reader to
+// the original ones.
+func (self *StringReader) notation_reader(begin, end int, notations map[string]string) (*StringReader, []int) {
+	var r = &StringReader{}
+	var index []int
+	var add = func(c string, i int) {
+		r.buf = append(r.buf, c)
+		r.pos = append(r.pos, self.pos[i])
+		index = append(index, i)
+	}
+	var i = begin
+	for i < end {
+		var replaced = false
+		for k, v := range notations {
+			if self.has_key(i, end, k) {
+				for _, c := range v {
+					add(string(c), i)
+				}
+				i += len(k)
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			add(self.buf[i], i)
+			i++
+		}
+	}
+	// <EOL> and <EOF> are at the end of the text.
+	add("<EOL>", end)
+	r.pos = append(r.pos, self.pos[end])
+	index = append(index, end)
+	for n := range r.pos {
+		r.pos[n].i = n
+	}
+	return r, index
+}
+
+// UserCommandArg is arguments of :command.
+type UserCommandArg struct {
+	nargs         string
+	range_        string
+	count         string
+	complete      string
+	complete_func string
+	addr          string
+	bang          bool
+	bar           bool
+	buffer        bool
+	register      bool
+	keepscript    bool
+	name          string
+	rep           string
+	placeholders  []*VimNode // PLACEHOLDER nodes in rep
+	cmds          []*VimNode // rep parsed as commands; or nil
+}
+
+// parse_cmd_usercommand parses :command from the argument. The reader is
+// kept at the position after the command.
+//
+//	:com[mand][!] [{attr}...] {cmd} {rep}
+func (self *VimLParser) parse_cmd_usercommand(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_USERCOMMAND)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var arg = &UserCommandArg{}
+	node.usercmd = arg
+	var next = r.tell()
+	var end = node.ea.linepos.i + runes(node.str)
+	r.seek_set(node.ea.argpos.i)
+	for r.peek() == "-" {
+		var attrpos = r.getpos()
+		var attr = ""
+		for r.tell() < end && !iswhite(r.peek()) {
+			attr += r.get()
+		}
+		self.parse_usercommand_attr(arg, attr, attrpos)
+		r.skip_white()
+	}
+	var namepos = r.getpos()
+	arg.name = r.read_alnum()
+	if arg.name != "" && !isupper(arg.name[:1]) {
+		panic(Err("E183: User defined commands must start with an uppercase letter", namepos))
+	}
+	r.skip_white()
+	if arg.name != "" && r.tell() < end {
+		arg.rep = r.getstr(r.getpos(), &pos{i: end})
+		self.parse_usercommand_rep(arg, r.tell(), end)
+	}
+	r.seek_set(next)
+	return node
+}
+
+// parse_usercommand_attr parses an attribute of :command, e.g. -nargs=1.
+// Names of attributes are case insensitive like Vim.
+func (self *VimLParser) parse_usercommand_attr(arg *UserCommandArg, attr string, pos *pos) {
+	var name = attr[1:]
+	var value = ""
+	var has_value = false
+	if i := strings.Index(name, "="); i >= 0 {
+		name, value = name[:i], name[i+1:]
+		has_value = true
+	}
+	switch strings.ToLower(name) {
+	case "bang":
+		arg.bang = true
+	case "bar":
+		arg.bar = true
+	case "buffer":
+		arg.buffer = true
+	case "register":
+		arg.register = true
+	case "keepscript":
+		arg.keepscript = true
+	case "nargs":
+		arg.nargs = value
+	case "range":
+		if !has_value {
+			value = "."
+		}
+		arg.range_ = value
+	case "count":
+		if !has_value {
+			value = "0"
+		}
+		arg.count = value
+	case "complete":
+		if i := strings.Index(value, ","); i >= 0 {
+			value, arg.complete_func = value[:i], value[i+1:]
+		}
+		arg.complete = value
+	case "addr":
+		arg.addr = value
+	default:
+		panic(Err(viml_printf("E181: Invalid attribute: %s", attr), pos))
+	}
+}
+
+// usercommand_placeholders is placeholders in the replacement of :command
+// and the text to parse the replacement with. Placeholders are replaced with
+// identifiers or numbers, so that e.g. "call F(<f-args>)" and
+// "<line1>,<line2>delete" can be parsed. <bang> is replaced with "!" for
+// "edit<bang>" and "<bang>0", and <mods> is removed for "<mods> split".
+var usercommand_placeholders = map[string]string{
+	"<line1>":    "1",
+	"<line2>":    "1",
+	"<range>":    "1",
+	"<count>":    "1",
+	"<bang>":     "!",
+	"<mods>":     "",
+	"<q-mods>":   "q_mods",
+	"<reg>":      "reg",
+	"<register>": "reg",
+	"<args>":     "args",
+	"<q-args>":   "q_args",
+	"<f-args>":   "f_args",
+	"<lt>":       "<",
+}
+
+// parse_usercommand_rep parses the replacement of :command between begin
+// and end of the reader as commands. The replacement isn't checked until the
+// command is used, so the commands are nil if it can't be parsed.
+// Identifiers and numbers replaced from placeholders become PLACEHOLDER
+// nodes, and Ex commands and strings have the text with placeholders.
+func (self *VimLParser) parse_usercommand_rep(arg *UserCommandArg, begin, end int) {
+	var r = self.reader
+	var placeholders = map[int]*VimNode{} // by the index of r
+	for i := begin; i < end; i++ {
+		for k := range usercommand_placeholders {
+			if r.has_key(i, end, k) {
+				var node = Node(NODE_PLACEHOLDER)
+				node.pos = &pos{}
+				*node.pos = r.pos[i]
+				node.pos.i = i
+				node.endpos = r.endpos(i + len(k))
+				node.value = k[1 : len(k)-1]
+				arg.placeholders = append(arg.placeholders, node)
+				placeholders[i] = node
+				break
+			}
+		}
+	}
+	var sub, index = r.notation_reader(begin, end, usercommand_placeholders)
+	var toplevel = self.parse_sub_script(sub)
+	if toplevel == nil {
+		return
+	}
+	inspect_nodes(toplevel, func(node *VimNode) {
+		if node.type_ != NODE_IDENTIFIER && node.type_ != NODE_NUMBER {
+			return
+		}
+		var i = node.pos.i
+		var p, ok = placeholders[index[i]]
+		if !ok || i > 0 && index[i-1] == index[i] {
+			return
+		}
+		if node.value != usercommand_placeholders["<"+p.value.(string)+">"] {
+			return
+		}
+		node.type_ = NODE_PLACEHOLDER
+		node.value = p.value
+		node.pos = p.pos
+		node.endpos = p.endpos
+	})
+	sub.set_endpos(toplevel)
+	inspect_nodes(toplevel, func(node *VimNode) {
+		switch node.type_ {
+		case NODE_EXCMD, NODE_AUTOCMD, NODE_AUGROUP, NODE_MAP, NODE_USERCOMMAND, NODE_HIGHLIGHT, NODE_SYNTAX, NODE_SET, NODE_FOREIGNCODE:
+			var i = node.ea.linepos.i
+			node.str = r.getstr(&pos{i: index[i]}, &pos{i: index[i+runes(node.str)]})
+		case NODE_STRING:
+			node.value = r.getstr(&pos{i: index[node.pos.i]}, &pos{i: index[node.endpos.i]})
+		}
+		if node.type_ == NODE_SET {
+			// The options from placeholders, e.g. "setlocal <args>", are
+			// not known until the command is used.
+			var options []*OptionAssign
+			for _, opt := range node.options {
+				if _, ok := placeholders[index[opt.pos.i]]; !ok {
+					options = append(options, opt)
+				}
+			}
+			node.options = options
+		}
+	})
+	arg.cmds = toplevel.body
+}
+
+// parse_sub_script parses the reader made from a part of the reader, e.g.
+// by notation_reader. It returns nil if the text can't be parsed.
+func (self *VimLParser) parse_sub_script(r *StringReader) (toplevel *VimNode) {
+	defer recover_parse_error(func() { toplevel = nil })
+	var p = self.new_sub_parser()
+	return p.parse_script(r)
+}
+
+// recover_parse_error recovers from a panic of ParseError and calls f. Other
+// panics are not recovered. It must be called directly by defer.
+func recover_parse_error(f func()) {
+	if e := recover(); e != nil {
+		if _, ok := e.(*ParseError); !ok {
+			panic(e)
+		}
+		f()
+	}
+}
+
+// inspect_nodes calls f for node and its descendants in depth-first order.
+// Nodes parsed from other readers, e.g. commands of mappings, are not
+// visited.
+func inspect_nodes(node *VimNode, f func(*VimNode)) {
+	if node == nil {
+		return
+	}
+	f(node)
+	for _, n := range []*VimNode{node.left, node.right, node.cond, node.rest, node.else_, node.finally, node.rtype} {
+		inspect_nodes(n, f)
+	}
+	for _, list := range [][]*VimNode{node.list, node.rlist, node.default_args, node.body, node.elseif, node.catch, node.params} {
+		for _, n := range list {
+			inspect_nodes(n, f)
+		}
+	}
+	switch v := node.value.(type) {
+	case *VimNode:
+		inspect_nodes(v, f)
+	case []*VimNode:
+		for _, n := range v {
+			inspect_nodes(n, f)
+		}
+	case []interface{}:
+		for _, x := range v {
+			switch x := x.(type) {
+			case *VimNode:
+				inspect_nodes(x, f)
+			case []interface{}:
+				for _, y := range x {
+					if n, ok := y.(*VimNode); ok {
+						inspect_nodes(n, f)
+					}
+				}
+			}
+		}
+	}
+}
+
+// HighlightArg is arguments of :highlight.
+type HighlightArg struct {
+	default_ bool
+	clear    bool
+	link     bool
+	none     bool
+	group    string
+	to       string
+	attrs    []*HighlightAttr
+}
+
+// HighlightAttr is {key}={arg} of :highlight.
+type HighlightAttr struct {
+	pos   *pos
+	key   string
+	value string // as written, e.g. "'Monospace 10'"
+}
+
+// parse_cmd_highlight parses :highlight from the argument. The reader is
+// kept at the position after the command.
+//
+//	:hi[ghlight][!] [default] {group} {key}={arg} ..
+//	:hi[ghlight][!] [default] link {from} {to}
+//	:hi[ghlight] clear [{group}]
+//	:hi[ghlight] {group} NONE
+func (self *VimLParser) parse_cmd_highlight(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_HIGHLIGHT)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var arg = &HighlightArg{}
+	node.highlight = arg
+	var next = r.tell()
+	var end = node.ea.linepos.i + runes(node.str)
+	r.seek_set(node.ea.argpos.i)
+	var word = r.read_arg(end)
+	if strings.EqualFold(word, "clear") {
+		arg.clear = true
+		r.skip_white()
+		arg.group = r.read_arg(end)
+		r.seek_set(next)
+		return node
+	}
+	// "def" is often used in syntax files.
+	if len(word) >= 3 && strings.HasPrefix("default", strings.ToLower(word)) {
+		arg.default_ = true
+		r.skip_white()
+		word = r.read_arg(end)
+	}
+	if strings.EqualFold(word, "link") {
+		arg.link = true
+		r.skip_white()
+		arg.group = r.read_arg(end)
+		r.skip_white()
+		arg.to = r.read_arg(end)
+		r.seek_set(next)
+		return node
+	}
+	arg.group = word
+	for {
+		r.skip_white()
+		if r.tell() >= end {
+			break
+		}
+		var attr = &HighlightAttr{pos: r.getpos()}
+		for r.tell() < end && !iswhite(r.peek()) && r.peek() != "=" {
+			attr.key += r.get()
+		}
+		if r.peek() != "=" {
+			if strings.EqualFold(attr.key, "NONE") {
+				arg.none = true
+			}
+			continue
+		}
+		r.get()
+		if r.peek() == "'" {
+			attr.value = r.get()
+			for r.tell() < end && r.peek() != "'" {
+				attr.value += r.get()
+			}
+			if r.tell() < end {
+				attr.value += r.get()
+			}
+		} else {
+			attr.value = r.read_arg(end)
+		}
+		arg.attrs = append(arg.attrs, attr)
+	}
+	r.seek_set(next)
+	return node
+}
+
+// read_arg reads non-white characters before end.
+func (self *StringReader) read_arg(end int) string {
+	var s = ""
+	for self.tell() < end && !iswhite(self.peek()) {
+		s += self.get()
+	}
+	return s
+}
+
+// SyntaxArg is arguments of :syntax.
+type SyntaxArg struct {
+	sub      string
+	args     string
+	group    string
+	keywords []string
+	patterns []*SyntaxPattern
+	options  []*SyntaxOption
+	file     string
+}
+
+// SyntaxPattern is a pattern of :syntax match and :syntax region.
+type SyntaxPattern struct {
+	pos        *pos
+	key        string // start, skip or end of region
+	pattern    string
+	delim      string
+	offsets    string
+	matchgroup string
+}
+
+// SyntaxOption is an option of :syntax, e.g. contains=Foo.
+type SyntaxOption struct {
+	pos   *pos
+	name  string
+	value string
+}
+
+// syntax_options is options of :syntax and whether they take a value.
+var syntax_options = map[string]bool{
+	"cchar":       true,
+	"conceal":     false,
+	"concealends": false,
+	"contained":   false,
+	"containedin": true,
+	"contains":    true,
+	"display":     false,
+	"excludenl":   false,
+	"extend":      false,
+	"fold":        false,
+	"grouphere":   true,
+	"groupthere":  true,
+	"keepend":     false,
+	"matchgroup":  true,
+	"nextgroup":   true,
+	"oneline":     false,
+	"skipempty":   false,
+	"skipnl":      false,
+	"skipwhite":   false,
+	"transparent": false,
+	// :syntax cluster
+	"add":    true,
+	"remove": true,
+}
+
+// parse_cmd_syntax_args parses the argument of :syntax, which is skipped by
+// parse_cmd_syntax. Groups, keywords, patterns and options of keyword,
+// match, region, cluster and include are parsed. The reader is kept at the
+// position after the command.
+//
+//	:sy[ntax] keyword {group} [{options}] {keyword} .. [{options}]
+//	:sy[ntax] match {group} [{options}] {pattern} [{options}]
+//	:sy[ntax] region {group} [{options}] start={pattern} .. end={pattern} ..
+//	:sy[ntax] cluster {name} [contains={group}..] [add=..] [remove=..]
+//	:sy[ntax] include [@{grouplist}] {file}
+func (self *VimLParser) parse_cmd_syntax_args(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_SYNTAX)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var arg = &SyntaxArg{}
+	node.syntax = arg
+	var next = r.tell()
+	var end = node.ea.linepos.i + runes(node.str)
+	r.seek_set(node.ea.argpos.i)
+	arg.sub = r.read_arg(end)
+	r.skip_white()
+	if r.tell() < end {
+		arg.args = strings.TrimRight(r.getstr(r.getpos(), &pos{i: end}), " \t")
+	}
+	switch arg.sub {
+	case "keyword", "match", "region", "cluster":
+		arg.group = r.read_arg(end)
+		self.parse_syntax_items(arg, end)
+	case "include":
+		if r.peek() == "@" {
+			arg.group = r.read_arg(end)
+			r.skip_white()
+		}
+		arg.file = strings.TrimRight(r.getstr(r.getpos(), &pos{i: end}), " \t")
+	}
+	r.seek_set(next)
+	return node
+}
+
+// parse_syntax_items parses options, keywords and patterns of :syntax
+// before end.
+func (self *VimLParser) parse_syntax_items(arg *SyntaxArg, end int) {
+	var r = self.reader
+	var matchgroup = ""
+	for {
+		r.skip_white()
+		if r.tell() >= end {
+			break
+		}
+		var p = r.getpos()
+		var name = strings.ToLower(r.read_alpha())
+		var value, ok = syntax_options[name]
+		if arg.sub == "region" && (name == "start" || name == "skip" || name == "end") && r.peek() == "=" {
+			r.get()
+			var pattern = self.read_syntax_pattern(end)
+			pattern.pos = p
+			pattern.key = name
+			pattern.matchgroup = matchgroup
+			arg.patterns = append(arg.patterns, pattern)
+			continue
+		}
+		if ok && value && r.peek() == "=" {
+			r.get()
+			var option = &SyntaxOption{pos: p, name: name, value: r.read_arg(end)}
+			if name == "matchgroup" {
+				matchgroup = option.value
+			}
+			arg.options = append(arg.options, option)
+			continue
+		}
+		if ok && !value && (r.tell() >= end || iswhite(r.peek())) {
+			arg.options = append(arg.options, &SyntaxOption{pos: p, name: name})
+			continue
+		}
+		r.seek_set(p.i)
+		if arg.sub == "match" && len(arg.patterns) == 0 {
+			var pattern = self.read_syntax_pattern(end)
+			pattern.pos = p
+			arg.patterns = append(arg.patterns, pattern)
+		} else if arg.sub == "keyword" {
+			arg.keywords = append(arg.keywords, r.read_arg(end))
+		} else {
+			r.read_arg(end)
+		}
+	}
+}
+
+// read_syntax_pattern reads a pattern surrounded by delimiters and the
+// offsets after it, e.g. "/x/me=e-1".
+func (self *VimLParser) read_syntax_pattern(end int) *SyntaxPattern {
+	var r = self.reader
+	var pattern = &SyntaxPattern{delim: r.get()}
+	for r.tell() < end && r.peek() != pattern.delim {
+		if r.peek() == "\\" && r.tell()+1 < end {
+			pattern.pattern += r.get()
+		}
+		pattern.pattern += r.get()
+	}
+	if r.tell() < end {
+		r.get()
+	}
+	pattern.offsets = r.read_arg(end)
+	return pattern
+}
+
+// OptionAssign is an argument of :set, e.g. "ts=4", "nowrap" and "ft?".
+type OptionAssign struct {
+	pos    *pos
+	endpos *pos
+	prefix string // "no" or "inv"
+	name   string
+	op     string // "=", "+=", "-=", "^=", "!", "&", "<", "?"; or empty
+	value  string // as written
+}
+
+// parse_cmd_set parses the arguments of :set, :setlocal and :setglobal. The
+// names are not checked. The reader is kept at the position after the
+// command.
+//
+//	:se[t] {option}={value} {option}+={value} no{option} inv{option} ..
+func (self *VimLParser) parse_cmd_set(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_SET)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var next = r.tell()
+	var end = node.ea.linepos.i + runes(node.str)
+	r.seek_set(node.ea.argpos.i)
+	for {
+		r.skip_white()
+		if r.tell() >= end {
+			break
+		}
+		var opt = &OptionAssign{pos: r.getpos()}
+		if r.peekn(2) == "no" && r.peekn(6) != "novice" {
+			opt.prefix = r.getn(2)
+		} else if r.peekn(3) == "inv" {
+			opt.prefix = r.getn(3)
+		}
+		opt.name = self.read_option_name(end)
+		self.read_option_op(opt, end)
+		// Skip trailing characters, e.g. "ts=4x" is E521 in Vim.
+		r.read_arg(end)
+		opt.endpos = r.getpos()
+		node.options = append(node.options, opt)
+	}
+	r.seek_set(next)
+	return node
+}
+
+// read_option_name reads the name of an option, e.g. "ts", "t_Co" and
+// "<t_F1>".
+func (self *VimLParser) read_option_name(end int) string {
+	var r = self.reader
+	if r.peek() == "<" {
+		var name = ""
+		for r.tell() < end && !iswhite(r.peek()) {
+			name += r.get()
+			if strings.HasSuffix(name, ">") {
+				break
+			}
+		}
+		return name
+	}
+	if r.peekn(2) == "t_" && r.tell()+4 <= end {
+		return r.getn(4)
+	}
+	var name = ""
+	for r.tell() < end && (isalnum(r.peek()) || r.peek() == "_") {
+		name += r.get()
+	}
+	return name
+}
+
+// read_option_op reads the operator and the value after the name of an
+// option. ":" is read as "=".
+func (self *VimLParser) read_option_op(opt *OptionAssign, end int) {
+	var r = self.reader
+	if r.tell() >= end {
+		return
+	}
+	var c = r.peek()
+	switch {
+	case c == "!" || c == "<" || c == "?":
+		opt.op = r.get()
+		return
+	case c == "&":
+		opt.op = r.get()
+		if r.peekn(3) == "vim" {
+			opt.value = r.getn(3)
+		} else if r.peekn(2) == "vi" {
+			opt.value = r.getn(2)
+		}
+		return
+	case c == "=" || c == ":":
+		r.get()
+		opt.op = "="
+	case (c == "+" || c == "-" || c == "^") && r.tell()+1 < end && r.peekn(2)[1:] == "=":
+		opt.op = r.getn(2)
+	default:
+		return
+	}
+	for r.tell() < end && !iswhite(r.peek()) {
+		if r.peek() == "\\" && r.tell()+1 < end {
+			opt.value += r.get()
+		}
+		opt.value += r.get()
+	}
+}
+
+// ForeignCodeArg is the code of other languages, e.g. :lua << EOF.
+type ForeignCodeArg struct {
+	language string
+	marker   string // end marker of heredoc; or empty for one line
+	trim     bool
+	lines    []string // as written
+	bodypos  *pos     // position of the first line
+}
+
+// foreign_languages is parsers of commands which run the code of other
+// languages. The language is the name of the parser without "parse_cmd_".
+var foreign_languages = map[string]bool{
+	"parse_cmd_lua":      true,
+	"parse_cmd_mzscheme": true,
+	"parse_cmd_perl":     true,
+	"parse_cmd_python":   true,
+	"parse_cmd_python3":  true,
+	"parse_cmd_ruby":     true,
+	"parse_cmd_tcl":      true,
+}
+
+// parse_cmd_foreign parses the code of :lua and the other commands again
+// because parse_cmd_lua doesn't know "trim". The reader is moved to the end
+// of the end marker for heredoc, or kept at the position after the command.
+//
+//	:lua {chunk}
+//	:lua << [trim] [{endmarker}]
+//	{script}
+//	{endmarker}
+func (self *VimLParser) parse_cmd_foreign(excmd *VimNode) *VimNode {
+	var r = self.reader
+	var node = Node(NODE_FOREIGNCODE)
+	node.pos = excmd.pos
+	node.ea = excmd.ea
+	node.str = excmd.str
+	var arg = &ForeignCodeArg{language: strings.TrimPrefix(node.ea.cmd.parser, "parse_cmd_")}
+	node.foreign = arg
+	var next = r.tell()
+	r.seek_set(node.ea.argpos.i)
+	r.skip_white()
+	if r.peekn(2) != "<<" {
+		arg.bodypos = r.getpos()
+		arg.lines = []string{r.getn(-1)}
+		r.seek_set(next)
+		return node
+	}
+	r.getn(2)
+	var words = strings.Fields(r.getn(-1))
+	if len(words) > 0 && words[0] == "trim" {
+		arg.trim = true
+		words = words[1:]
+	}
+	arg.marker = "."
+	if len(words) > 0 {
+		arg.marker = words[0]
+	}
+	var text = []string{r.getstr(node.ea.linepos, r.getpos())}
+	r.get()
+	arg.bodypos = r.getpos()
+	for r.peek() != "<EOF>" {
+		var line = r.getn(-1)
+		text = append(text, line)
+		if line == arg.marker || arg.trim && strings.TrimLeft(line, " \t") == arg.marker {
+			break
+		}
+		arg.lines = append(arg.lines, line)
+		r.get()
+	}
+	node.str = strings.Join(text, "\n")
+	return node
+}
@@ -2,19 +2,43 @@ package vimlparser
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
 	"github.com/vim-jp/go-vimlparser/token"
 )
 
 // Parse parses Vim script in reader and returns Node.
 func (p *VimLParser) Parse(reader *StringReader, filename string) ast.Node {
-	return newAstNode(p.parse(reader), filename)
+	return newFile(p.parse_script(reader), reader, filename)
+}
+
+// ParseRecover parses Vim script in reader like Parse, but it continues
+// parsing after errors. It returns the partial Node which contains
+// ast.BadStmt and ast.BadExpr for the broken parts and all errors.
+func (p *VimLParser) ParseRecover(reader *StringReader, filename string) (ast.Node, []*ParseError) {
+	n, errs := p.parse_recover(reader)
+	return newFile(n, reader, filename), errs
 }
 
 // Parse parses Vim script expression.
 func (p *ExprParser) Parse() ast.Expr {
-	return newExprNode(p.parse(), "")
+	n := p.parse()
+	p.reader.set_endpos(n)
+	return newExprNode(n, "")
+}
+
+// NeovimCommands returns the commands which Neovim adds to the builtin
+// commands of Vim and the commands which Neovim removes from them.
+func NeovimCommands() (added, removed []*ast.Cmd) {
+	for _, c := range neovim_additional_commands {
+		added = append(added, newCmd(c))
+	}
+	for _, c := range neovim_removed_commands {
+		removed = append(removed, newCmd(c))
+	}
+	return added, removed
 }
 
 // ----
@@ -35,20 +59,35 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		pos = ast.Pos{Offset: 0, Line: 1, Column: 1, Filename: filename}
 	}
 
+	// endpos is set by set_endpos. It's nil if the node is not converted
+	// through the Parse methods.
+	var end ast.Pos
+	if p := newPos(n.endpos, filename); p != nil {
+		end = *p
+	}
+	// closing bracket
+	var close = end
+	if close.Column > 1 {
+		close.Offset--
+		close.Column--
+	}
+
 	switch n.type_ {
 
 	case NODE_TOPLEVEL:
-		return &ast.File{Start: pos, Body: newBody(*n, filename)}
+		return &ast.File{Start: pos, Body: newBody(*n, filename), Vim9: n.vim9}
 
 	case NODE_COMMENT:
 		return &ast.Comment{
 			Quote: pos,
 			Text:  n.str,
+			Vim9:  n.vim9,
 		}
 
 	case NODE_EXCMD:
 		return &ast.Excmd{
 			Excmd:   pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Command: n.str,
 		}
@@ -63,26 +102,31 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 				Closure: n.attr.closure,
 			}
 		}
+		// end node is nil if the function is not closed. (recover mode)
+		endfunction, _ := newAstNode(n.endfunction, filename).(*ast.EndFunction)
 		return &ast.Function{
 			Func:        pos,
+			EndPos:      end,
 			ExArg:       newExArg(*n.ea, filename),
 			Body:        newBody(*n, filename),
 			Name:        newExprNode(n.left, filename),
 			Params:      newIdents(*n, filename),
 			DefaultArgs: newExprs(n.default_args, filename),
 			Attr:        attr,
-			EndFunction: newAstNode(n.endfunction, filename).(*ast.EndFunction),
+			EndFunction: endfunction,
 		}
 
 	case NODE_ENDFUNCTION:
 		return &ast.EndFunction{
 			EndFunc: pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 		}
 
 	case NODE_DELFUNCTION:
 		return &ast.DelFunction{
 			DelFunc: pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Name:    newExprNode(n.left, filename),
 		}
@@ -90,6 +134,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_RETURN:
 		return &ast.Return{
 			Return: pos,
+			EndPos: end,
 			ExArg:  newExArg(*n.ea, filename),
 			Result: newExprNode(n.left, filename),
 		}
@@ -97,31 +142,35 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_EXCALL:
 		return &ast.ExCall{
 			ExCall:   pos,
+			EndPos:   end,
 			ExArg:    newExArg(*n.ea, filename),
 			FuncCall: newAstNode(n.left, filename).(*ast.CallExpr),
 		}
 
 	case NODE_LET, NODE_CONST:
 		return &ast.Let{
-			Let:   pos,
-			ExArg: newExArg(*n.ea, filename),
-			Op:    n.op,
-			Left:  newExprNode(n.left, filename),
-			List:  newExprs(n.list, filename),
-			Rest:  newExprNode(n.rest, filename),
-			Right: newExprNode(n.right, filename),
+			Let:    pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Op:     n.op,
+			Left:   newExprNode(n.left, filename),
+			List:   newExprs(n.list, filename),
+			Rest:   newExprNode(n.rest, filename),
+			Right:  newExprNode(n.right, filename),
 		}
 
 	case NODE_UNLET:
 		return &ast.UnLet{
-			UnLet: pos,
-			ExArg: newExArg(*n.ea, filename),
-			List:  newExprs(n.list, filename),
+			UnLet:  pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			List:   newExprs(n.list, filename),
 		}
 
 	case NODE_LOCKVAR:
 		return &ast.LockVar{
 			LockVar: pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Depth:   n.depth,
 			List:    newExprs(n.list, filename),
@@ -130,6 +179,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_UNLOCKVAR:
 		return &ast.UnLockVar{
 			UnLockVar: pos,
+			EndPos:    end,
 			ExArg:     newExArg(*n.ea, filename),
 			Depth:     n.depth,
 			List:      newExprs(n.list, filename),
@@ -149,19 +199,22 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		if n.else_ != nil {
 			els = newAstNode(n.else_, filename).(*ast.Else)
 		}
+		endif, _ := newAstNode(n.endif, filename).(*ast.EndIf)
 		return &ast.If{
 			If:        pos,
+			EndPos:    end,
 			ExArg:     newExArg(*n.ea, filename),
 			Body:      newBody(*n, filename),
 			Condition: newExprNode(n.cond, filename),
 			ElseIf:    elifs,
 			Else:      els,
-			EndIf:     newAstNode(n.endif, filename).(*ast.EndIf),
+			EndIf:     endif,
 		}
 
 	case NODE_ELSEIF:
 		return &ast.ElseIf{
 			ElseIf:    pos,
+			EndPos:    end,
 			ExArg:     newExArg(*n.ea, filename),
 			Body:      newBody(*n, filename),
 			Condition: newExprNode(n.cond, filename),
@@ -169,60 +222,70 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 
 	case NODE_ELSE:
 		return &ast.Else{
-			Else:  pos,
-			ExArg: newExArg(*n.ea, filename),
-			Body:  newBody(*n, filename),
+			Else:   pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Body:   newBody(*n, filename),
 		}
 
 	case NODE_ENDIF:
 		return &ast.EndIf{
-			EndIf: pos,
-			ExArg: newExArg(*n.ea, filename),
+			EndIf:  pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
 		}
 
 	case NODE_WHILE:
+		endwhile, _ := newAstNode(n.endwhile, filename).(*ast.EndWhile)
 		return &ast.While{
 			While:     pos,
+			EndPos:    end,
 			ExArg:     newExArg(*n.ea, filename),
 			Body:      newBody(*n, filename),
 			Condition: newExprNode(n.cond, filename),
-			EndWhile:  newAstNode(n.endwhile, filename).(*ast.EndWhile),
+			EndWhile:  endwhile,
 		}
 
 	case NODE_ENDWHILE:
 		return &ast.EndWhile{
 			EndWhile: pos,
+			EndPos:   end,
 			ExArg:    newExArg(*n.ea, filename),
 		}
 
 	case NODE_FOR:
+		endfor, _ := newAstNode(n.endfor, filename).(*ast.EndFor)
 		return &ast.For{
 			For:    pos,
+			EndPos: end,
 			ExArg:  newExArg(*n.ea, filename),
 			Body:   newBody(*n, filename),
 			Left:   newExprNode(n.left, filename),
 			List:   newExprs(n.list, filename),
 			Rest:   newExprNode(n.rest, filename),
 			Right:  newExprNode(n.right, filename),
-			EndFor: newAstNode(n.endfor, filename).(*ast.EndFor),
+			EndFor: endfor,
 		}
 
 	case NODE_ENDFOR:
 		return &ast.EndFor{
 			EndFor: pos,
+			EndPos: end,
 			ExArg:  newExArg(*n.ea, filename),
 		}
 
 	case NODE_CONTINUE:
 		return &ast.Continue{
 			Continue: pos,
+			EndPos:   end,
 			ExArg:    newExArg(*n.ea, filename),
 		}
 
 	case NODE_BREAK:
 		return &ast.Break{
-			Break: pos,
-			ExArg: newExArg(*n.ea, filename),
+			Break:  pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
 		}
 
 	case NODE_TRY:
@@ -239,18 +302,21 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		if n.finally != nil {
 			finally = newAstNode(n.finally, filename).(*ast.Finally)
 		}
+		endtry, _ := newAstNode(n.endtry, filename).(*ast.EndTry)
 		return &ast.Try{
 			Try:     pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Body:    newBody(*n, filename),
 			Catch:   catches,
 			Finally: finally,
-			EndTry:  newAstNode(n.endtry, filename).(*ast.EndTry),
+			EndTry:  endtry,
 		}
 
 	case NODE_CATCH:
 		return &ast.Catch{
 			Catch:   pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Body:    newBody(*n, filename),
 			Pattern: n.pattern,
@@ -259,6 +325,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_FINALLY:
 		return &ast.Finally{
 			Finally: pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Body:    newBody(*n, filename),
 		}
@@ -266,26 +333,30 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_ENDTRY:
 		return &ast.EndTry{
 			EndTry: pos,
+			EndPos: end,
 			ExArg:  newExArg(*n.ea, filename),
 		}
 
 	case NODE_THROW:
 		return &ast.Throw{
-			Throw: pos,
-			ExArg: newExArg(*n.ea, filename),
-			Expr:  newExprNode(n.left, filename),
+			Throw:  pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Expr:   newExprNode(n.left, filename),
 		}
 
 	case NODE_EVAL:
 		return &ast.Eval{
-			Eval:  pos,
-			ExArg: newExArg(*n.ea, filename),
-			Expr:  newExprNode(n.left, filename),
+			Eval:   pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Expr:   newExprNode(n.left, filename),
 		}
 
 	case NODE_ECHO, NODE_ECHON, NODE_ECHOMSG, NODE_ECHOERR:
 		return &ast.EchoCmd{
 			Start:   pos,
+			EndPos:  end,
 			CmdName: n.ea.cmd.name,
 			ExArg:   newExArg(*n.ea, filename),
 			Exprs:   newExprs(n.list, filename),
@@ -294,6 +365,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_ECHOHL:
 		return &ast.Echohl{
 			Echohl: pos,
+			EndPos: end,
 			ExArg:  newExArg(*n.ea, filename),
 			Name:   n.str,
 		}
@@ -301,6 +373,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_EXECUTE:
 		return &ast.Execute{
 			Execute: pos,
+			EndPos:  end,
 			ExArg:   newExArg(*n.ea, filename),
 			Exprs:   newExprs(n.list, filename),
 		}
@@ -321,11 +394,15 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		NODE_MATCHCI, NODE_MATCHCS, NODE_NOMATCH, NODE_NOMATCHCI,
 		NODE_NOMATCHCS, NODE_IS, NODE_ISCI, NODE_ISCS, NODE_ISNOT,
 		NODE_ISNOTCI, NODE_ISNOTCS, NODE_ADD, NODE_SUBTRACT, NODE_CONCAT,
-		NODE_MULTIPLY, NODE_DIVIDE, NODE_REMAINDER:
+		NODE_MULTIPLY, NODE_DIVIDE, NODE_REMAINDER, NODE_FALSY:
+		var op = opToken(n.type_)
+		if n.type_ == NODE_CONCAT && (n.op == ".." || n.dotdot) {
+			op = token.DOTDOT
+		}
 		return &ast.BinaryExpr{
 			Left:  newExprNode(n.left, filename),
 			OpPos: pos,
-			Op:    opToken(n.type_),
+			Op:    op,
 			Right: newExprNode(n.right, filename),
 		}
 
@@ -339,6 +416,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_SUBSCRIPT:
 		return &ast.SubscriptExpr{
 			Lbrack: pos,
+			Rbrack: close,
 			Left:   newExprNode(n.left, filename),
 			Right:  newExprNode(n.right, filename),
 		}
@@ -346,6 +424,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_SLICE:
 		return &ast.SliceExpr{
 			Lbrack: pos,
+			Rbrack: close,
 			X:      newExprNode(n.left, filename),
 			Low:    newExprNode(n.rlist[0], filename),
 			High:   newExprNode(n.rlist[1], filename),
@@ -354,6 +433,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_METHOD:
 		return &ast.MethodExpr{
 			Lparen: pos,
+			Rparen: close,
 			Left:   newExprNode(n.left, filename),
 			Method: newExprNode(n.right.left, filename),
 			Args:   newExprs(n.right.rlist, filename),
@@ -362,6 +442,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_CALL:
 		return &ast.CallExpr{
 			Lparen: pos,
+			Rparen: close,
 			Fun:    newExprNode(n.left, filename),
 			Args:   newExprs(n.rlist, filename),
 		}
@@ -376,18 +457,21 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_NUMBER:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.NUMBER,
 			Value:    n.value.(string),
 		}
 	case NODE_STRING:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.STRING,
 			Value:    n.value.(string),
 		}
 	case NODE_LIST:
 		return &ast.List{
 			Lsquare: pos,
+			Rsquare: close,
 			Values:  newValues(*n, filename),
 		}
 
@@ -402,18 +486,22 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		}
 		return &ast.Dict{
 			Lcurlybrace: pos,
+			Rcurlybrace: close,
 			Entries:     kvs,
+			Literal:     n.literal,
 		}
 
 	case NODE_OPTION:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.OPTION,
 			Value:    n.value.(string),
 		}
 	case NODE_IDENTIFIER:
 		return &ast.Ident{
 			NamePos: pos,
+			EndPos:  end,
 			Name:    n.value.(string),
 		}
 
@@ -431,6 +519,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_ENV:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.ENV,
 			Value:    n.value.(string),
 		}
@@ -438,6 +527,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_REG:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.REG,
 			Value:    n.value.(string),
 		}
@@ -445,6 +535,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_CURLYNAMEPART:
 		return &ast.CurlyNameLit{
 			CurlyNameLit: pos,
+			EndPos:       end,
 			Value:        n.value.(string),
 		}
 
@@ -452,12 +543,14 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		n := n.value.(*VimNode)
 		return &ast.CurlyNameExpr{
 			CurlyNameExpr: pos,
+			Rcurlybrace:   close,
 			Value:         newExprNode(n, filename),
 		}
 
 	case NODE_LAMBDA:
 		return &ast.LambdaExpr{
 			Lcurlybrace: pos,
+			Rcurlybrace: close,
 			Params:      newIdents(*n, filename),
 			Expr:        newExprNode(n.left, filename),
 		}
@@ -465,6 +558,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_BLOB:
 		return &ast.BasicLit{
 			ValuePos: pos,
+			EndPos:   end,
 			Kind:     token.BLOB,
 			Value:    n.value.(string),
 		}
@@ -472,6 +566,7 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 	case NODE_HEREDOC:
 		return &ast.HeredocExpr{
 			OpPos:     pos,
+			EndPos:    end,
 			Flags:     newExprs(n.rlist, filename),
 			EndMarker: n.op,
 			Body:      newExprs(n.body, filename),
@@ -481,9 +576,346 @@ func newAstNode(n *VimNode, filename string) ast.Node {
 		n := n.value.(*VimNode)
 		return &ast.ParenExpr{
 			Lparen: pos,
+			Rparen: close,
 			X:      newExprNode(n, filename),
 		}
 
+	case NODE_DEF:
+		// end node is nil if the function doesn't have body or is not
+		// closed. (recover mode)
+		enddef, _ := newAstNode(n.enddef, filename).(*ast.EndDef)
+		return &ast.Def{
+			Def:    pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Attr:   newVim9Attr(n.vim9attr),
+			Body:   newBody(*n, filename),
+			Name:   newExprNode(n.left, filename),
+			Params: newParams(n.params, filename),
+			Result: newType(n.rtype, filename),
+			EndDef: enddef,
+		}
+
+	case NODE_ENDDEF:
+		return &ast.EndDef{
+			EndDef: pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+		}
+
+	case NODE_PARAM:
+		name, _ := newAstNode(n.left, filename).(*ast.Ident)
+		return &ast.Param{
+			Start:    pos,
+			Name:     name,
+			Type:     newType(n.rtype, filename),
+			Default:  newExprNode(n.right, filename),
+			Variadic: n.op == "...",
+		}
+
+	case NODE_TYPE:
+		return &ast.Type{
+			TypePos: pos,
+			Name:    n.str,
+			EndPos:  end,
+		}
+
+	case NODE_VAR:
+		return &ast.VarDecl{
+			Var:    pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Attr:   newVim9Attr(n.vim9attr),
+			Left:   newExprNode(n.left, filename),
+			List:   newExprs(n.list, filename),
+			Rest:   newExprNode(n.rest, filename),
+			Type:   newType(n.rtype, filename),
+			Right:  newExprNode(n.right, filename),
+		}
+
+	case NODE_ASSIGN:
+		return &ast.Assign{
+			Start:  pos,
+			EndPos: end,
+			ExArg:  newExArg(*n.ea, filename),
+			Op:     n.op,
+			Left:   newExprNode(n.left, filename),
+			List:   newExprs(n.list, filename),
+			Rest:   newExprNode(n.rest, filename),
+			Right:  newExprNode(n.right, filename),
+		}
+
+	case NODE_EXPRSTMT:
+		return &ast.ExprStmt{
+			Start: pos,
+			ExArg: newExArg(*n.ea, filename),
+			X:     newExprNode(n.left, filename),
+		}
+
+	case NODE_IMPORT:
+		as, _ := newAstNode(n.right, filename).(*ast.Ident)
+		return &ast.Import{
+			Import:   pos,
+			EndPos:   end,
+			ExArg:    newExArg(*n.ea, filename),
+			Autoload: n.op == "autoload",
+			Path:     newExprNode(n.left, filename),
+			As:       as,
+		}
+
+	case NODE_CLASS:
+		name, _ := newAstNode(n.left, filename).(*ast.Ident)
+		endclass, _ := newAstNode(n.endclass, filename).(*ast.EndClass)
+		return &ast.Class{
+			Class:      pos,
+			EndPos:     end,
+			ExArg:      newExArg(*n.ea, filename),
+			Attr:       newVim9Attr(n.vim9attr),
+			Name:       name,
+			Extends:    newExprNode(n.right, filename),
+			Implements: newExprs(n.list, filename),
+			Values:     newExprs(n.rlist, filename),
+			Body:       newBody(*n, filename),
+			EndClass:   endclass,
+		}
+
+	case NODE_ENDCLASS:
+		return &ast.EndClass{
+			EndClass: pos,
+			EndPos:   end,
+			ExArg:    newExArg(*n.ea, filename),
+		}
+
+	case NODE_LAMBDA9:
+		var body []ast.Statement
+		if n.left == nil {
+			body = newBody(*n, filename)
+		}
+		return &ast.Vim9LambdaExpr{
+			Lparen: pos,
+			Params: newParams(n.params, filename),
+			Result: newType(n.rtype, filename),
+			Expr:   newExprNode(n.left, filename),
+			Body:   body,
+			EndPos: end,
+		}
+
+	case NODE_CAST:
+		return &ast.CastExpr{
+			Lt:   pos,
+			Type: newType(n.rtype, filename),
+			X:    newExprNode(n.left, filename),
+		}
+
+	case NODE_INTERPOLATED:
+		return &ast.InterpolatedString{
+			ValuePos: pos,
+			Value:    n.value.(string),
+			Exprs:    newExprs(n.list, filename),
+			EndPos:   end,
+		}
+
+	case NODE_AUTOCMD:
+		return &ast.Autocmd{
+			Autocmd:  pos,
+			EndPos:   end,
+			ExArg:    newExArg(*n.ea, filename),
+			Text:     n.str,
+			Group:    n.autocmd.group,
+			Events:   n.autocmd.events,
+			Patterns: n.autocmd.patterns,
+			Once:     n.autocmd.once,
+			Nested:   n.autocmd.nested,
+			Command:  newBody(*n, filename),
+		}
+
+	case NODE_AUGROUP:
+		name := n.value.(string)
+		return &ast.Augroup{
+			Augroup: pos,
+			EndPos:  end,
+			ExArg:   newExArg(*n.ea, filename),
+			Text:    n.str,
+			Name:    name,
+			IsEnd:   strings.EqualFold(name, "END"),
+		}
+
+	case NODE_MAP:
+		m := n.mapping
+		return &ast.Map{
+			Map:     pos,
+			EndPos:  end,
+			ExArg:   newExArg(*n.ea, filename),
+			Text:    n.str,
+			Modes:   m.modes,
+			Noremap: m.noremap,
+			Unmap:   m.unmap,
+			Attr: ast.MapAttr{
+				Buffer:  m.buffer,
+				Nowait:  m.nowait,
+				Silent:  m.silent,
+				Special: m.special,
+				Script:  m.script,
+				Expr:    m.expr,
+				Unique:  m.unique,
+			},
+			Lhs:     m.lhs,
+			Rhs:     m.rhs,
+			RhsExpr: newExprNode(m.rhsexpr, filename),
+			RhsCmds: newBody(VimNode{body: m.cmds}, filename),
+		}
+
+	case NODE_USERCOMMAND:
+		u := n.usercmd
+		var placeholders []*ast.Placeholder
+		for _, p := range u.placeholders {
+			placeholders = append(placeholders, newAstNode(p, filename).(*ast.Placeholder))
+		}
+		return &ast.UserCommand{
+			Command: pos,
+			EndPos:  end,
+			ExArg:   newExArg(*n.ea, filename),
+			Text:    n.str,
+			Attr: ast.UserCommandAttr{
+				Nargs:        u.nargs,
+				Range:        u.range_,
+				Count:        u.count,
+				Complete:     u.complete,
+				CompleteFunc: u.complete_func,
+				Addr:         u.addr,
+				Bang:         u.bang,
+				Bar:          u.bar,
+				Buffer:       u.buffer,
+				Register:     u.register,
+				Keepscript:   u.keepscript,
+			},
+			Name:         u.name,
+			Replacement:  u.rep,
+			Placeholders: placeholders,
+			Body:         newBody(VimNode{body: u.cmds}, filename),
+		}
+
+	case NODE_PLACEHOLDER:
+		return &ast.Placeholder{
+			ValuePos: pos,
+			Name:     n.value.(string),
+			EndPos:   end,
+		}
+
+	case NODE_HIGHLIGHT:
+		h := n.highlight
+		var attrs []*ast.HighlightAttr
+		for _, a := range h.attrs {
+			attrs = append(attrs, &ast.HighlightAttr{
+				Pos:   *newPos(a.pos, filename),
+				Key:   a.key,
+				Value: a.value,
+			})
+		}
+		return &ast.Highlight{
+			Highlight: pos,
+			EndPos:    end,
+			ExArg:     newExArg(*n.ea, filename),
+			Text:      n.str,
+			Default:   h.default_,
+			Clear:     h.clear,
+			Link:      h.link,
+			None:      h.none,
+			Group:     h.group,
+			To:        h.to,
+			Attrs:     attrs,
+		}
+
+	case NODE_SYNTAX:
+		s := n.syntax
+		var patterns []*ast.SyntaxPattern
+		for _, p := range s.patterns {
+			patterns = append(patterns, &ast.SyntaxPattern{
+				Pos:        *newPos(p.pos, filename),
+				Key:        p.key,
+				Pattern:    p.pattern,
+				Delim:      p.delim,
+				Offsets:    p.offsets,
+				MatchGroup: p.matchgroup,
+			})
+		}
+		var options []*ast.SyntaxOption
+		for _, o := range s.options {
+			options = append(options, &ast.SyntaxOption{
+				Pos:   *newPos(o.pos, filename),
+				Name:  o.name,
+				Value: o.value,
+			})
+		}
+		return &ast.Syntax{
+			Syntax:   pos,
+			EndPos:   end,
+			ExArg:    newExArg(*n.ea, filename),
+			Text:     n.str,
+			Sub:      s.sub,
+			Args:     s.args,
+			Group:    s.group,
+			Keywords: s.keywords,
+			Patterns: patterns,
+			Options:  options,
+			File:     s.file,
+		}
+
+	case NODE_SET:
+		var options []*ast.OptionAssign
+		for _, o := range n.options {
+			var full string
+			if opt := builtin.LookupOption(o.name); opt != nil {
+				full = opt.Name
+			} else if o.name == "all" || o.name == "termcap" {
+				full = o.name
+			}
+			options = append(options, &ast.OptionAssign{
+				Pos:    *newPos(o.pos, filename),
+				EndPos: *newPos(o.endpos, filename),
+				Prefix: o.prefix,
+				Name:   o.name,
+				Full:   full,
+				Op:     o.op,
+				Value:  o.value,
+			})
+		}
+		return &ast.Set{
+			Set:     pos,
+			EndPos:  end,
+			ExArg:   newExArg(*n.ea, filename),
+			Text:    n.str,
+			Options: options,
+		}
+
+	case NODE_FOREIGNCODE:
+		f := n.foreign
+		return &ast.ForeignCode{
+			ForeignCode: pos,
+			EndPos:      end,
+			ExArg:       newExArg(*n.ea, filename),
+			Text:        n.str,
+			Language:    f.language,
+			Marker:      f.marker,
+			Trim:        f.trim,
+			Lines:       f.lines,
+			BodyPos:     *newPos(f.bodypos, filename),
+		}
+
+	case NODE_BADSTMT:
+		return &ast.BadStmt{
+			From: pos,
+			To:   *newPos(n.endpos, filename),
+			Text: n.str,
+		}
+
+	case NODE_BADEXPR:
+		return &ast.BadExpr{
+			From: pos,
+			To:   *newPos(n.endpos, filename),
+			Text: n.str,
+		}
+
 	}
 	panic(fmt.Errorf("Unknown node type: %v, node: %v", n.type_, n))
 }
@@ -585,6 +1017,34 @@ func newExprs(xs []*VimNode, filename string) []ast.Expr {
 	return list
 }
 
+func newParams(params []*VimNode, filename string) []*ast.Param {
+	var list []*ast.Param
+	if params != nil {
+		list = make([]*ast.Param, 0, len(params))
+	}
+	for _, node := range params {
+		list = append(list, newAstNode(node, filename).(*ast.Param))
+	}
+	return list
+}
+
+func newType(n *VimNode, filename string) *ast.Type {
+	t, _ := newAstNode(n, filename).(*ast.Type)
+	return t
+}
+
+func newVim9Attr(attr *Vim9Attr) ast.Vim9Attr {
+	if attr == nil {
+		return ast.Vim9Attr{}
+	}
+	return ast.Vim9Attr{
+		Export:   attr.export,
+		Public:   attr.public,
+		Static:   attr.static,
+		Abstract: attr.abstract,
+	}
+}
+
 func newValues(n VimNode, filename string) []ast.Expr {
 	var values []ast.Expr
 	for _, v := range n.value.([]interface{}) {
@@ -678,6 +1138,8 @@ func opToken(nodeType int) token.Token {
 		return token.MINUS
 	case NODE_PLUS:
 		return token.PLUS
+	case NODE_FALSY:
+		return token.FALSY
 	}
 	return token.ILLEGAL
 }
@@ -0,0 +1,185 @@
+package vimlparser
+
+import "strings"
+
+// Node types which only exist in the Go port. They are produced by the
+// error-recovering parser and don't have vim-vimlparser equivalents.
+var NODE_BADSTMT = 300
+var NODE_BADEXPR = 301
+
+// parse_recover parses Vim script like parse(), but it doesn't stop at the
+// first error. When a command cannot be parsed, it records the error, skips
+// to the next command boundary (`|` or end of line) and continues parsing.
+// The broken part is kept in the tree as BADSTMT or BADEXPR node.
+func (self *VimLParser) parse_recover(reader *StringReader) (*VimNode, []*ParseError) {
+	self.reader = reader
+	var errs []*ParseError
+	var toplevel = Node(NODE_TOPLEVEL)
+	toplevel.pos = self.reader.getpos()
+	self.push_context(toplevel)
+	for self.reader.peek() != "<EOF>" {
+		errs = append(errs, self.parse_one_cmd_recover()...)
+	}
+	for len(self.context) > 1 {
+		if err := self.close_context("TOPLEVEL", self.reader.getpos()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	self.pop_context()
+	toplevel.vim9 = self.vim9
+	return toplevel, errs
+}
+
+// parse_one_cmd_recover calls parse_next_cmd and returns the errors, if any,
+// after resynchronizing the reader.
+func (self *VimLParser) parse_one_cmd_recover() (errs []*ParseError) {
+	var start = self.reader.getpos()
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		e, ok := r.(*ParseError)
+		if !ok {
+			panic(r)
+		}
+		errs = append(errs, e)
+		if self.is_missing_end(e) {
+			// :endfunction with unclosed inner block. Close the inner
+			// block implicitly and parse the :endfunction again. So are
+			// :enddef and the end of class.
+			self.pop_context()
+			self.reader.setpos(self.ea.linepos)
+			errs = append(errs, self.parse_one_cmd_recover()...)
+			return
+		}
+		var from = start
+		if self.ea != nil && self.ea.linepos != nil {
+			from = self.ea.linepos
+		}
+		var to = self.skip_to_nextcmd(from, e)
+		if !self.recover_block(to) {
+			var node = Node(NODE_BADSTMT)
+			node.pos = from
+			node.str = self.reader.getstr(from, to)
+			node.endpos = to
+			self.add_node(node)
+		}
+		// consume the separator
+		self.reader.setpos(to)
+		self.reader.get()
+	}()
+	self.parse_next_cmd()
+	return nil
+}
+
+// skip_to_nextcmd returns position of next `|` or end of line after the
+// position where the error e occurred. The reader may have read ahead of the
+// error position, so it restarts from the error position.
+func (self *VimLParser) skip_to_nextcmd(from *pos, e *ParseError) *pos {
+	if e.index >= from.i {
+		self.reader.seek_set(e.index)
+	}
+	for {
+		var c = self.reader.peek()
+		if c == "<EOF>" || c == "<EOL>" || c == "|" {
+			break
+		}
+		self.reader.get()
+	}
+	return self.reader.getpos()
+}
+
+// is_missing_end reports whether e is raised by check_missing_* functions
+// while parsing :endfunction, :enddef or the end of class.
+func (self *VimLParser) is_missing_end(e *ParseError) bool {
+	if self.ea == nil || self.ea.cmd == nil {
+		return false
+	}
+	switch self.ea.cmd.name {
+	case "endfunction", "enddef", "endclass", "endinterface", "endenum":
+	default:
+		return false
+	}
+	for _, prefix := range []string{"E126:", "E171:", "E600:", "E170:", "E1057:"} {
+		if strings.HasPrefix(e.Msg, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// recover_block creates a block node with BADEXPR condition when the
+// beginning of a block (:if, :elseif, :while or :for) fails to parse, so
+// that the following body and end command are attached to it instead of
+// producing more errors. It returns false if nothing is created.
+func (self *VimLParser) recover_block(to *pos) bool {
+	if self.ea == nil || self.ea.cmd == nil || self.ea.argpos == nil {
+		return false
+	}
+	if self.context[0].ea == self.ea {
+		// the block node was already pushed.
+		return false
+	}
+	var bad = Node(NODE_BADEXPR)
+	bad.pos = self.ea.argpos
+	bad.str = self.reader.getstr(self.ea.argpos, to)
+	bad.endpos = to
+	switch self.ea.cmd.name {
+	case "if", "while", "for":
+		var node *VimNode
+		if self.ea.cmd.name == "if" {
+			node = Node(NODE_IF)
+			node.cond = bad
+		} else if self.ea.cmd.name == "while" {
+			node = Node(NODE_WHILE)
+			node.cond = bad
+		} else {
+			node = Node(NODE_FOR)
+			node.right = bad
+		}
+		node.pos = self.ea.cmdpos
+		node.ea = self.ea
+		self.add_node(node)
+		self.push_context(node)
+		return true
+	case "elseif":
+		if self.context[0].type_ == NODE_ELSEIF {
+			self.pop_context()
+		}
+		if self.context[0].type_ != NODE_IF {
+			return false
+		}
+		var node = Node(NODE_ELSEIF)
+		node.pos = self.ea.cmdpos
+		node.ea = self.ea
+		node.cond = bad
+		self.context[0].elseif = append(self.context[0].elseif, node)
+		self.push_context(node)
+		return true
+	}
+	return false
+}
+
+// close_context closes the innermost block and returns the error which is
+// reported by parse() for the unclosed block.
+func (self *VimLParser) close_context(ends string, pos *pos) (err *ParseError) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(*ParseError)
+			if !ok {
+				panic(r)
+			}
+			err = e
+		}
+		self.pop_context()
+	}()
+	self.check_missing_enddef(ends, pos)
+	self.check_missing_endclass(ends, pos)
+	self.check_missing_endfunction(ends, pos)
+	self.check_missing_endif(ends, pos)
+	self.check_missing_endtry(ends, pos)
+	self.check_missing_endwhile(ends, pos)
+	self.check_missing_endfor(ends, pos)
+	return nil
+}
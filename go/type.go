@@ -71,6 +71,27 @@ type VimNode struct {
 
 	pattern string
 	curly   bool
+	literal bool // #{} DICT; set with endpos
+	dotdot  bool // ".." CONCAT; set with endpos
+
+	endpos *pos // end position of BADSTMT and BADEXPR
+
+	// Vim9 script
+	params   []*VimNode
+	rtype    *VimNode
+	enddef   *VimNode
+	endclass *VimNode
+	vim9attr *Vim9Attr
+	vim9     bool // TOPLEVEL of Vim9 script; or `#` COMMENT
+
+	// Ex commands
+	autocmd   *AutocmdArg
+	mapping   *MapArg
+	usercmd   *UserCommandArg
+	highlight *HighlightArg
+	syntax    *SyntaxArg
+	options   []*OptionAssign
+	foreign   *ForeignCodeArg
 }
 
 type FuncAttr struct {
@@ -110,6 +131,10 @@ type VimLParser struct {
 	context            []*VimNode
 	ea                 *ExArg
 	neovim             bool
+	all                bool // commands of both Vim and Neovim
+
+	vim9     bool      // after :vim9script
+	vim9attr *Vim9Attr // modifiers of the declaration being parsed
 }
 
 func NewVimLParser(neovim bool) *VimLParser {
@@ -123,6 +148,32 @@ func (self *VimLParser) __init__(neovim bool) {
 	self.neovim = neovim
 }
 
+// NewVimLParserAll returns the parser which accepts the commands of both Vim
+// and Neovim. find_command finds the commands of Neovim in the cache.
+func NewVimLParserAll() *VimLParser {
+	obj := NewVimLParser(false)
+	obj.all = true
+	for _, c := range neovim_additional_commands {
+		for i := c.minlen; i <= len(c.name); i++ {
+			obj.find_command_cache[c.name[:i]] = c
+		}
+	}
+	return obj
+}
+
+// new_sub_parser returns the parser of the commands in a command, e.g. the
+// rhs of a mapping, with the options of self.
+func (self *VimLParser) new_sub_parser() *VimLParser {
+	var p *VimLParser
+	if self.all {
+		p = NewVimLParserAll()
+	} else {
+		p = NewVimLParser(self.neovim)
+	}
+	p.vim9 = self.is_vim9()
+	return p
+}
+
 func (self *VimLParser) push_context(n *VimNode) {
 	self.context = append([]*VimNode{n}, self.context...)
 }
@@ -178,6 +229,9 @@ type StringReader struct {
 	i   int
 	pos []pos
 	buf []string
+
+	comments   []*VimNode // COMMENT nodes of `"\ ` in line continuations
+	blanklines []int      // line numbers of empty lines
 }
 
 func NewStringReader(lines []string) *StringReader {
@@ -196,6 +250,9 @@ func (self *StringReader) __init__(lines []string) {
 	var lnum = 0
 	var offset = 0
 	for lnum < len(lines) {
+		if strings.Trim(lines[lnum], " \t") == "" {
+			self.blanklines = append(self.blanklines, lnum+1)
+		}
 		var col = 0
 		for _, r := range lines[lnum] {
 			c := string(r)
@@ -204,7 +261,20 @@ func (self *StringReader) __init__(lines []string) {
 			col += len(c)
 			offset += len(c)
 		}
-		for lnum+1 < len(lines) && viml_eqregh(lines[lnum+1], "^\\s*\\\\") {
+		for {
+			// `"\ ` comments can be put between continuation lines.
+			var next = lnum + 1
+			for next < len(lines) && viml_eqregh(lines[next], "^\\s*\"\\\\ ") {
+				next += 1
+			}
+			if next >= len(lines) || !viml_eqregh(lines[next], "^\\s*\\\\") {
+				break
+			}
+			for lnum+1 < next {
+				self.add_comment(lines[lnum+1], lnum+2, offset+1)
+				offset += len(lines[lnum+1]) + 1
+				lnum += 1
+			}
 			var skip = true
 			col = 0
 			for _, r := range lines[lnum+1] {
@@ -215,7 +285,9 @@ func (self *StringReader) __init__(lines []string) {
 					}
 				} else {
 					self.buf = append(self.buf, c)
-					self.pos = append(self.pos, pos{lnum: lnum + 2, col: col + 1})
+					// +1 for EOL of the previous line, which is added to offset
+					// after the loop.
+					self.pos = append(self.pos, pos{lnum: lnum + 2, col: col + 1, offset: offset + 1})
 				}
 				col += len(c)
 				offset += len(c)
@@ -233,6 +305,16 @@ func (self *StringReader) __init__(lines []string) {
 	self.i = 0
 }
 
+// add_comment records `"\ ` comment line in line continuation. lnum is the
+// line number and offset is the offset of the beginning of the line.
+func (self *StringReader) add_comment(line string, lnum int, offset int) {
+	var col = strings.IndexByte(line, '"')
+	var node = Node(NODE_COMMENT)
+	node.pos = &pos{i: len(self.buf), lnum: lnum, col: col + 1, offset: offset + col}
+	node.str = line[col+1:]
+	self.comments = append(self.comments, node)
+}
+
 func (self *StringReader) getpos() *pos {
 	p := self.pos[self.i]
 	p.i = self.i
@@ -322,10 +404,12 @@ func (self *Compiler) compile_parenexpr(n *VimNode) string {
 }
 
 type ParseError struct {
-	Offset int
+	Offset int // byte offset, which is Offset of ast.Pos
 	Line   int
 	Column int
 	Msg    string
+
+	index int // index of the reader
 }
 
 func (e *ParseError) Error() string {
@@ -333,5 +417,5 @@ func (e *ParseError) Error() string {
 }
 
 func Err(msg string, pos *pos) *ParseError {
-	return &ParseError{Offset: pos.i, Line: pos.lnum, Column: pos.col, Msg: msg}
+	return &ParseError{Offset: pos.offset, Line: pos.lnum, Column: pos.col, Msg: msg, index: pos.i}
 }
@@ -0,0 +1,903 @@
+package vimlparser
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Node types of Vim9 script. They only exist in the Go port.
+var NODE_DEF = 302
+var NODE_ENDDEF = 303
+var NODE_PARAM = 304
+var NODE_TYPE = 305
+var NODE_VAR = 306
+var NODE_ASSIGN = 307
+var NODE_EXPRSTMT = 308
+var NODE_IMPORT = 309
+var NODE_CLASS = 310
+var NODE_ENDCLASS = 311
+var NODE_LAMBDA9 = 312
+var NODE_CAST = 313
+var NODE_FALSY = 314
+var NODE_INTERPOLATED = 315
+
+// DEF .ea .left .params .rtype .body .enddef .vim9attr
+// ENDDEF .ea
+// PARAM .left .rtype .right .op
+// TYPE .str
+// VAR .ea .op .left .list .rest .rtype .right .vim9attr
+// ASSIGN .ea .op .left .list .rest .right
+// EXPRSTMT .ea .left
+// IMPORT .ea .op .left .right
+// CLASS .ea .left .right .list .rlist .body .endclass .vim9attr
+// ENDCLASS .ea
+// LAMBDA9 .params .rtype .left .body
+// CAST .rtype .left
+// FALSY .left .right
+// INTERPOLATED .value .list
+
+// Vim9Attr is modifiers of declarations: export, public, static and
+// abstract. pos is the position of the first modifier.
+type Vim9Attr struct {
+	export   bool
+	public   bool
+	static   bool
+	abstract bool
+	pos      *pos
+}
+
+// vim9_commands are commands which are parsed differently in Vim9 script.
+// They must be written in full.
+var vim9_commands = []*Cmd{
+	{flags: "EXTRA|NOTRLCOM", minlen: 8, name: "abstract", parser: "parse_cmd_vim9_modifier"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 5, name: "class", parser: "parse_cmd_class"},
+	{flags: "EXTRA|NOTRLCOM|SBOXOK|CMDWIN", minlen: 5, name: "const", parser: "parse_cmd_var"},
+	{flags: "EXTRA|BANG|SBOXOK|CMDWIN", minlen: 3, name: "def", parser: "parse_cmd_def"},
+	{flags: "EXTRA|NOTRLCOM|SBOXOK|CMDWIN", minlen: 5, name: "defer", parser: "parse_cmd_eval"},
+	{flags: "TRLBAR|CMDWIN", minlen: 6, name: "enddef", parser: "parse_cmd_enddef"},
+	{flags: "TRLBAR", minlen: 8, name: "endclass", parser: "parse_cmd_endclass"},
+	{flags: "TRLBAR", minlen: 7, name: "endenum", parser: "parse_cmd_endclass"},
+	{flags: "TRLBAR", minlen: 12, name: "endinterface", parser: "parse_cmd_endclass"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 4, name: "enum", parser: "parse_cmd_class"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 6, name: "export", parser: "parse_cmd_vim9_modifier"},
+	{flags: "EXTRA|NOTRLCOM|SBOXOK|CMDWIN", minlen: 5, name: "final", parser: "parse_cmd_var"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 6, name: "import", parser: "parse_cmd_import"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 9, name: "interface", parser: "parse_cmd_class"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 6, name: "legacy", parser: "parse_cmd_common"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 6, name: "public", parser: "parse_cmd_vim9_modifier"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 6, name: "static", parser: "parse_cmd_vim9_modifier"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 4, name: "type", parser: "parse_cmd_common"},
+	{flags: "EXTRA|NOTRLCOM|SBOXOK|CMDWIN", minlen: 3, name: "var", parser: "parse_cmd_var"},
+	{flags: "EXTRA|NOTRLCOM", minlen: 7, name: "vim9cmd", parser: "parse_cmd_common"},
+	{flags: "", minlen: 10, name: "vim9script", parser: "parse_cmd_common"},
+}
+
+// vim9_expr_cmds are parsers of builtin commands which take expressions.
+// They are parsed with Vim9ExprParser in Vim9 script.
+var vim9_expr_cmds = map[string]bool{
+	"parse_cmd_call":    true,
+	"parse_cmd_const":   true,
+	"parse_cmd_echo":    true,
+	"parse_cmd_echoerr": true,
+	"parse_cmd_echomsg": true,
+	"parse_cmd_echon":   true,
+	"parse_cmd_elseif":  true,
+	"parse_cmd_eval":    true,
+	"parse_cmd_execute": true,
+	"parse_cmd_for":     true,
+	"parse_cmd_if":      true,
+	"parse_cmd_let":     true,
+	"parse_cmd_return":  true,
+	"parse_cmd_throw":   true,
+	"parse_cmd_while":   true,
+
+	"parse_cmd_lockvar":   true,
+	"parse_cmd_unlet":     true,
+	"parse_cmd_unlockvar": true,
+}
+
+// vim9_command returns the Vim9 command named name or nil.
+func vim9_command(name string) *Cmd {
+	for _, x := range vim9_commands {
+		if x.name == name {
+			return x
+		}
+	}
+	return nil
+}
+
+// parse_script parses Vim script like parse(), but it also parses Vim9
+// script: the rest of the file after :vim9script and :def functions.
+func (self *VimLParser) parse_script(reader *StringReader) *VimNode {
+	self.reader = reader
+	var toplevel = Node(NODE_TOPLEVEL)
+	toplevel.pos = self.reader.getpos()
+	self.push_context(toplevel)
+	for self.reader.peek() != "<EOF>" {
+		self.parse_next_cmd()
+	}
+	self.check_missing_enddef("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endclass("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endfunction("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endif("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endtry("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endwhile("TOPLEVEL", self.reader.getpos())
+	self.check_missing_endfor("TOPLEVEL", self.reader.getpos())
+	self.pop_context()
+	toplevel.vim9 = self.vim9
+	return toplevel
+}
+
+var def_line = regexp.MustCompile(`^[ \t:]*def!?([ \t]|$)`)
+
+// vim9script_line matches :vim9script. It can't be told by ea.cmd because
+// find_command() reads only alphabets: "vim" of "vim9script" is :vimgrep.
+var vim9script_line = regexp.MustCompile(`^[ \t:]*vim9s(c(r(i(pt?)?)?)?)?([ \t"]|$)`)
+
+// parse_next_cmd parses a command. Commands are parsed as Vim9 script in
+// Vim9 script files, :def functions and classes. :def in legacy script is
+// also parsed as Vim9 script.
+func (self *VimLParser) parse_next_cmd() {
+	var parent = self.context[0]
+	var n = len(parent.body)
+	if self.is_vim9() || def_line.MatchString(self.reader.peekline()) {
+		self.parse_vim9_cmd()
+	} else if len(self.context) == 1 && vim9script_line.MatchString(self.reader.peekline()) {
+		self.vim9 = true
+		self.parse_vim9_cmd()
+	} else {
+		self.parse_one_cmd()
+	}
+	self.parse_excmd(parent, n)
+}
+
+// is_vim9 reports whether the current context is Vim9 script.
+func (self *VimLParser) is_vim9() bool {
+	for _, node := range self.context {
+		if node.type_ == NODE_DEF || node.type_ == NODE_CLASS || node.type_ == NODE_LAMBDA9 {
+			return true
+		} else if node.type_ == NODE_FUNCTION {
+			return false
+		}
+	}
+	return self.vim9
+}
+
+func (self *VimLParser) check_missing_enddef(ends string, pos *pos) {
+	if self.context[0].type_ == NODE_DEF {
+		panic(Err(viml_printf("E1057: Missing :enddef:    %s", ends), pos))
+	}
+}
+
+func (self *VimLParser) check_missing_endclass(ends string, pos *pos) {
+	if self.context[0].type_ == NODE_CLASS {
+		panic(Err(viml_printf("Missing :end%s:    %s", self.context[0].ea.cmd.name, ends), pos))
+	}
+}
+
+// parse_vim9_cmd parses a command of Vim9 script. It's parse_one_cmd() of
+// Vim9 script: "#" starts a comment, a range must be preceded by ":" and
+// a command can be an assignment or an expression.
+func (self *VimLParser) parse_vim9_cmd() {
+	self.ea = &ExArg{}
+	self.vim9attr = nil
+	if self.reader.peekn(2) == "#!" {
+		self.parse_hashbang()
+		self.reader.get()
+		return
+	}
+	self.reader.skip_white()
+	var colon = self.reader.peek() == ":"
+	self.reader.skip_white_and_colon()
+	if self.reader.peekn(1) == "" {
+		self.reader.get()
+		return
+	}
+	if self.reader.peekn(1) == "#" {
+		self.parse_vim9_comment()
+		self.reader.get()
+		return
+	}
+	self.ea.linepos = self.reader.getpos()
+	if !colon && self.is_enum_values() {
+		self.parse_enum_values()
+		self.parse_vim9_trail()
+		return
+	}
+	if !colon && self.is_vim9_stmt() {
+		self.parse_vim9_stmt()
+		self.parse_vim9_trail()
+		return
+	}
+	self.parse_command_modifiers()
+	if colon {
+		self.parse_range()
+	}
+	self.reader.skip_white()
+	if !viml_empty(self.ea.modifiers) && self.is_vim9_stmt() {
+		self.parse_vim9_stmt()
+	} else {
+		self.parse_vim9_command()
+	}
+	self.parse_vim9_trail()
+}
+
+func (self *VimLParser) parse_vim9_comment() {
+	var npos = self.reader.getpos()
+	var c = self.reader.get()
+	if c != "#" {
+		panic(Err(viml_printf("unexpected character: %s", c), npos))
+	}
+	var node = Node(NODE_COMMENT)
+	node.pos = npos
+	node.str = self.reader.getn(-1)
+	node.vim9 = true
+	self.add_node(node)
+}
+
+func (self *VimLParser) parse_vim9_trail() {
+	self.reader.skip_white()
+	var c = self.reader.peek()
+	if c == "<EOF>" {
+		// pass
+	} else if c == "<EOL>" {
+		self.reader.get()
+	} else if c == "|" {
+		self.reader.get()
+	} else if c == "#" {
+		self.parse_vim9_comment()
+		self.reader.get()
+	} else {
+		panic(Err(viml_printf("E488: Trailing characters: %s", c), self.reader.getpos()))
+	}
+}
+
+func (self *VimLParser) ends_vim9_cmd(c string) bool {
+	return c == "" || c == "|" || c == "#" || c == "<EOF>" || c == "<EOL>"
+}
+
+// is_vim9_stmt reports whether the command at the current position is an
+// assignment or an expression, which is not preceded by a command name in
+// Vim9 script.
+func (self *VimLParser) is_vim9_stmt() bool {
+	var r = self.reader
+	var pos = r.tell()
+	defer r.seek_set(pos)
+	var c = r.peek()
+	if r.peekn(2) == "++" || r.peekn(2) == "--" {
+		return true
+	} else if c == "[" || c == "'" || c == "\"" || c == "(" || c == "&" || c == "$" || c == "@" || isdigit(c) {
+		return true
+	} else if !isnamec1(c) {
+		return false
+	}
+	r.read_name()
+	c = r.peek()
+	if c == "(" {
+		// function call unless it's a command such as if(cond)
+		r.seek_set(pos)
+		var cmd = self.find_command()
+		return cmd == nil || !vim9_expr_cmds[cmd.parser] || cmd.parser == "parse_cmd_call" || cmd.parser == "parse_cmd_execute"
+	} else if c == "[" || c == "." && iswordc(r.p(1)) || r.peekn(2) == "->" || self.is_method_line_next() {
+		return true
+	}
+	r.skip_white()
+	if self.peek_assign_op() == "" {
+		return false
+	}
+	// :wincmd =
+	r.seek_set(pos)
+	var cmd = self.find_command()
+	return cmd == nil || cmd.parser != "parse_wincmd"
+}
+
+// is_method_line_next reports whether the current line ends and the next
+// line starts with "->" after empty lines and comments, e.g. "list" followed
+// by "->filter(F)". The line is the start of the expression then.
+func (self *VimLParser) is_method_line_next() bool {
+	var r = self.reader
+	var pos = r.tell()
+	defer r.seek_set(pos)
+	var nl = false
+	for {
+		r.skip_white()
+		if r.peek() == "#" {
+			r.getn(-1)
+		}
+		if r.peek() != "<EOL>" {
+			return nl && r.peekn(2) == "->"
+		}
+		r.get()
+		nl = true
+	}
+}
+
+// peek_assign_op returns the assignment operator at the current position.
+// It must be followed by white space as Vim9 script requires.
+func (self *VimLParser) peek_assign_op() string {
+	var r = self.reader
+	for _, op := range []string{"..=", "+=", "-=", "*=", "/=", "%=", "=<<", "="} {
+		if r.peekn(len(op)) != op {
+			continue
+		}
+		var c = r.p(len(op))
+		if iswhite(c) || c == "<EOL>" || c == "<EOF>" {
+			return op
+		}
+		return ""
+	}
+	return ""
+}
+
+// parse_vim9_stmt parses an assignment or an expression statement.
+func (self *VimLParser) parse_vim9_stmt() {
+	var r = self.reader
+	var p = NewVim9ExprParser(r, self)
+	var node = Node(NODE_ASSIGN)
+	node.pos = r.getpos()
+	node.ea = self.ea
+	if r.peekn(2) == "++" || r.peekn(2) == "--" {
+		node.op = r.getn(2)
+		node.left = p.parse_expr8()
+		self.check_vim9_lvalue(node.left)
+		self.add_node(node)
+		return
+	}
+	var start = r.tell()
+	if r.peek() == "[" && self.is_list_assign() {
+		node.list, node.rest = p.parse_lhs_list(false)
+	} else {
+		node.left = p.parse_expr8()
+	}
+	r.skip_white()
+	node.op = self.peek_assign_op()
+	if node.op == "" {
+		r.seek_set(start)
+		var stmt = Node(NODE_EXPRSTMT)
+		stmt.pos = node.pos
+		stmt.ea = self.ea
+		stmt.left = NewVim9ExprParser(r, self).parse_expr1()
+		self.add_node(stmt)
+		return
+	}
+	if node.left != nil {
+		self.check_vim9_lvalue(node.left)
+	}
+	r.getn(len(node.op))
+	if node.op == "=<<" {
+		r.skip_white()
+		node.right = self.parse_heredoc()
+		node.right.pos = node.pos
+		self.add_node(node)
+		return
+	}
+	p.skip_nl_after_op()
+	node.right = p.parse_expr1()
+	self.add_node(node)
+}
+
+// is_list_assign reports whether "[" at the current position starts the
+// list of assignment: [a, b] = expr.
+func (self *VimLParser) is_list_assign() bool {
+	var pos = self.reader.tell()
+	defer self.reader.seek_set(pos)
+	if !self.reader.skip_brackets() {
+		return false
+	}
+	self.reader.skip_white()
+	return self.peek_assign_op() != ""
+}
+
+func (self *VimLParser) check_vim9_lvalue(node *VimNode) {
+	switch node.type_ {
+	case NODE_IDENTIFIER, NODE_SUBSCRIPT, NODE_SLICE, NODE_DOT, NODE_OPTION, NODE_ENV, NODE_REG:
+		return
+	}
+	panic(Err("E15: Invalid expression: invalid lvalue", node.pos))
+}
+
+// is_enum_values reports whether the line is values of enum, which are
+// put before the other members.
+func (self *VimLParser) is_enum_values() bool {
+	var node = self.context[0]
+	if node.type_ != NODE_CLASS || node.ea.cmd.name != "enum" {
+		return false
+	}
+	for _, n := range node.body {
+		if n.type_ != NODE_COMMENT {
+			return false
+		}
+	}
+	var pos = self.reader.tell()
+	defer self.reader.seek_set(pos)
+	var name = self.reader.read_word()
+	return name != "" && vim9_command(name) == nil
+}
+
+// parse_enum_values parses values of enum: Name, Name(args), ...
+func (self *VimLParser) parse_enum_values() {
+	var node = self.context[0]
+	var p = NewVim9ExprParser(self.reader, self)
+	for {
+		node.rlist = append(node.rlist, p.parse_expr8())
+		self.reader.skip_white()
+		if self.reader.peek() != "," {
+			break
+		}
+		self.reader.get()
+		self.reader.skip_white()
+		if self.ends_vim9_cmd(self.reader.peek()) {
+			break
+		}
+	}
+}
+
+// parse_vim9_command parses a command after the modifiers and the range.
+// Commands which are not specific to Vim9 script and don't take
+// expressions are parsed by parse_command().
+func (self *VimLParser) parse_vim9_command() {
+	self.reader.skip_white_and_colon()
+	self.ea.cmdpos = self.reader.getpos()
+	if self.reader.peekn(1) == "" || self.reader.peekn(1) == "#" {
+		if !viml_empty(self.ea.modifiers) || !viml_empty(self.ea.range_) {
+			self.parse_cmd_modifier_range()
+		}
+		return
+	}
+	var cmd = self.find_vim9_command()
+	if cmd == nil {
+		var parent = self.context[0]
+		var n = len(parent.body)
+		self.parse_command()
+		if len(parent.body) == n+1 && parent.body[n].type_ == NODE_EXCMD && parent.body[n].ea == self.ea {
+			self.split_vim9_comment(parent.body[n])
+		}
+		return
+	}
+	self.ea.cmd = cmd
+	if self.reader.peekn(1) == "!" {
+		self.reader.getn(1)
+		self.ea.forceit = true
+	} else {
+		self.ea.forceit = false
+	}
+	if !viml_eqregh(self.ea.cmd.flags, "\\<BANG\\>") && self.ea.forceit {
+		panic(Err("E477: No ! allowed", self.ea.cmdpos))
+	}
+	self.reader.skip_white()
+	self.ea.argpos = self.reader.getpos()
+	self._parse_vim9_command(cmd.parser)
+}
+
+// find_vim9_command returns the command at the current position if it's
+// parsed differently in Vim9 script. Otherwise, it returns nil without
+// moving the reader.
+func (self *VimLParser) find_vim9_command() *Cmd {
+	var pos = self.reader.tell()
+	if cmd := vim9_command(self.reader.read_alnum()); cmd != nil {
+		return cmd
+	}
+	self.reader.seek_set(pos)
+	var cmd = self.find_command()
+	if cmd != nil && vim9_expr_cmds[cmd.parser] {
+		return cmd
+	}
+	self.reader.seek_set(pos)
+	return nil
+}
+
+func (self *VimLParser) _parse_vim9_command(parser string) {
+	switch parser {
+	case "parse_cmd_def":
+		self.parse_cmd_def()
+	case "parse_cmd_enddef":
+		self.parse_cmd_enddef()
+	case "parse_cmd_var", "parse_cmd_const":
+		self.parse_cmd_var()
+	case "parse_cmd_import":
+		self.parse_cmd_import()
+	case "parse_cmd_class":
+		self.parse_cmd_class()
+	case "parse_cmd_endclass":
+		self.parse_cmd_endclass()
+	case "parse_cmd_vim9_modifier":
+		self.parse_cmd_vim9_modifier()
+	case "parse_cmd_common":
+		self.parse_cmd_common()
+	case "parse_cmd_call":
+		self.parse_vim9_cmd_call()
+	case "parse_cmd_return":
+		self.parse_vim9_cmd_return()
+	case "parse_cmd_if":
+		self.parse_vim9_cmd_if()
+	case "parse_cmd_elseif":
+		self.parse_vim9_cmd_elseif()
+	case "parse_cmd_while":
+		self.parse_vim9_cmd_while()
+	case "parse_cmd_for":
+		self.parse_vim9_cmd_for()
+	case "parse_cmd_eval":
+		self.parse_vim9_cmd_expr(NODE_EVAL)
+	case "parse_cmd_throw":
+		self.parse_vim9_cmd_expr(NODE_THROW)
+	case "parse_cmd_echo":
+		self.parse_vim9_cmd_exprlist(NODE_ECHO)
+	case "parse_cmd_echon":
+		self.parse_vim9_cmd_exprlist(NODE_ECHON)
+	case "parse_cmd_echomsg":
+		self.parse_vim9_cmd_exprlist(NODE_ECHOMSG)
+	case "parse_cmd_echoerr":
+		self.parse_vim9_cmd_exprlist(NODE_ECHOERR)
+	case "parse_cmd_execute":
+		self.parse_vim9_cmd_exprlist(NODE_EXECUTE)
+	case "parse_cmd_unlet":
+		self.parse_vim9_cmd_lvals(NODE_UNLET)
+	case "parse_cmd_lockvar":
+		self.parse_vim9_cmd_lvals(NODE_LOCKVAR)
+	case "parse_cmd_unlockvar":
+		self.parse_vim9_cmd_lvals(NODE_UNLOCKVAR)
+	case "parse_cmd_let":
+		panic(Err("E1126: Cannot use :let in Vim9 script", self.ea.cmdpos))
+	default:
+		panic(viml_printf("unknown parser: %s", viml_string(parser)))
+	}
+}
+
+// split_vim9_comment removes `# comment` from the argument of Ex command
+// node and moves the reader to it. `"` doesn't start a comment in Vim9
+// script, so the argument is extended to the end of the command. Commands
+// with NOTRLCOM, e.g. mappings, don't have comments.
+func (self *VimLParser) split_vim9_comment(node *VimNode) {
+	if !viml_eqregh(node.ea.cmd.flags, "\\<TRLBAR\\>") || viml_eqregh(node.ea.cmd.flags, "\\<NOTRLCOM\\>") || node.ea.usefilter {
+		return
+	}
+	var r = self.reader
+	if r.peek() == "\"" {
+		for !self.ends_excmds(r.peek()) || r.peek() == "\"" {
+			r.get()
+		}
+		node.str = r.getstr(node.ea.linepos, r.getpos())
+	}
+	var s = []rune(node.str)
+	for i := 1; i < len(s); i++ {
+		if s[i] == '#' && (s[i-1] == ' ' || s[i-1] == '\t') && (i+1 == len(s) || s[i+1] == ' ' || s[i+1] == '\t') {
+			node.str = strings.TrimRight(string(s[:i]), " \t")
+			r.seek_set(node.ea.linepos.i + i)
+			return
+		}
+	}
+}
+
+// new_vim9_decl returns a new declaration node with the modifiers.
+func (self *VimLParser) new_vim9_decl(type_ int) *VimNode {
+	var node = Node(type_)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.vim9attr = &Vim9Attr{}
+	if self.vim9attr != nil {
+		node.vim9attr = self.vim9attr
+		node.pos = self.vim9attr.pos
+		self.vim9attr = nil
+	}
+	return node
+}
+
+// :export, :public, :static and :abstract
+func (self *VimLParser) parse_cmd_vim9_modifier() {
+	var name = self.ea.cmd.name
+	if self.vim9attr == nil {
+		self.vim9attr = &Vim9Attr{pos: self.ea.cmdpos}
+	}
+	if name == "export" {
+		self.vim9attr.export = true
+	} else if name == "public" {
+		self.vim9attr.public = true
+	} else if name == "static" {
+		self.vim9attr.static = true
+	} else if name == "abstract" {
+		self.vim9attr.abstract = true
+	}
+	self.parse_vim9_command()
+	if self.vim9attr != nil {
+		panic(Err(viml_printf("Invalid command after :%s", name), self.ea.cmdpos))
+	}
+}
+
+// :def[!] {name}([arguments])[: {return-type}]
+func (self *VimLParser) parse_cmd_def() {
+	var pos = self.reader.tell()
+	self.reader.skip_white()
+	// :def, :def /pattern
+	if self.ends_vim9_cmd(self.reader.peek()) || self.reader.peekn(1) == "/" {
+		self.reader.seek_set(pos)
+		self.parse_cmd_common()
+		return
+	}
+	var left = self.parse_lvalue_func()
+	self.reader.skip_white()
+	// :def {name}
+	if self.reader.peekn(1) != "(" {
+		self.reader.seek_set(pos)
+		self.parse_cmd_common()
+		return
+	}
+	var node = self.new_vim9_decl(NODE_DEF)
+	node.left = left
+	self.reader.getn(1)
+	var p = NewVim9ExprParser(self.reader, self)
+	node.params = p.parse_params()
+	if self.reader.peekn(1) == ":" {
+		self.reader.getn(1)
+		self.reader.skip_white()
+		node.rtype = p.parse_type()
+	}
+	self.add_node(node)
+	// abstract methods and methods of interface don't have body.
+	if node.vim9attr.abstract || self.context[0].type_ == NODE_CLASS && self.context[0].ea.cmd.name == "interface" {
+		return
+	}
+	self.push_context(node)
+}
+
+func (self *VimLParser) parse_cmd_enddef() {
+	self.check_missing_endif("ENDDEF", self.ea.cmdpos)
+	self.check_missing_endtry("ENDDEF", self.ea.cmdpos)
+	self.check_missing_endwhile("ENDDEF", self.ea.cmdpos)
+	self.check_missing_endfor("ENDDEF", self.ea.cmdpos)
+	if self.context[0].type_ != NODE_DEF {
+		panic(Err("E193: :enddef not inside a function", self.ea.cmdpos))
+	}
+	var node = Node(NODE_ENDDEF)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	self.context[0].enddef = node
+	self.pop_context()
+}
+
+// :var {name}[: {type}] [= {expr}]
+// :var [{name}, ...; {rest}] = {expr}
+// :var {name} =<< [trim] {marker}
+// :final and :const are the same.
+func (self *VimLParser) parse_cmd_var() {
+	var node = self.new_vim9_decl(NODE_VAR)
+	var p = NewVim9ExprParser(self.reader, self)
+	if self.reader.peekn(1) == "[" {
+		node.list, node.rest = p.parse_lhs_list(true)
+	} else {
+		node.left = p.parse_name()
+		if self.reader.peekn(1) == ":" {
+			self.reader.getn(1)
+			self.reader.skip_white()
+			node.rtype = p.parse_type()
+		}
+	}
+	self.reader.skip_white()
+	if self.reader.peekn(3) == "=<<" {
+		self.reader.getn(3)
+		self.reader.skip_white()
+		node.op = "=<<"
+		node.right = self.parse_heredoc()
+	} else if self.reader.peekn(1) == "=" {
+		self.reader.getn(1)
+		node.op = "="
+		p.skip_nl_after_op()
+		node.right = p.parse_expr1()
+	}
+	self.add_node(node)
+}
+
+// :import [autoload] {path} [as {name}]
+func (self *VimLParser) parse_cmd_import() {
+	var node = self.new_vim9_decl(NODE_IMPORT)
+	var r = self.reader
+	var pos = r.tell()
+	if r.read_alpha() == "autoload" && iswhite(r.peek()) {
+		node.op = "autoload"
+		r.skip_white()
+	} else {
+		r.seek_set(pos)
+	}
+	if r.peek() == "{" {
+		panic(Err(viml_printf("E1047: Syntax error in import: %s", r.peekline()), r.getpos()))
+	}
+	var p = NewVim9ExprParser(r, self)
+	node.left = p.parse_expr1()
+	r.skip_white()
+	pos = r.tell()
+	if r.read_alpha() == "as" && iswhite(r.peek()) {
+		node.right = p.parse_name()
+	} else {
+		r.seek_set(pos)
+	}
+	self.add_node(node)
+}
+
+// :class {name} [extends {base}] [implements {interface}, ...]
+// :interface {name} [extends {interface}]
+// :enum {name} [implements {interface}, ...]
+func (self *VimLParser) parse_cmd_class() {
+	if !self.vim9 {
+		panic(Err("E1316: Class can only be defined in Vim9 script", self.ea.cmdpos))
+	}
+	var node = self.new_vim9_decl(NODE_CLASS)
+	var p = NewVim9ExprParser(self.reader, self)
+	node.left = p.parse_name()
+	for {
+		self.reader.skip_white()
+		var epos = self.reader.getpos()
+		var key = self.reader.read_alpha()
+		if key == "" {
+			break
+		} else if key == "extends" && node.right == nil {
+			node.right = p.parse_expr8()
+		} else if key == "implements" && node.list == nil {
+			for {
+				node.list = append(node.list, p.parse_expr8())
+				self.reader.skip_white()
+				if self.reader.peek() != "," {
+					break
+				}
+				self.reader.get()
+			}
+		} else {
+			panic(Err(viml_printf("unexpected token: %s", key), epos))
+		}
+	}
+	self.add_node(node)
+	self.push_context(node)
+}
+
+// :endclass, :endinterface and :endenum
+func (self *VimLParser) parse_cmd_endclass() {
+	var ends = strings.ToUpper(self.ea.cmd.name)
+	self.check_missing_enddef(ends, self.ea.cmdpos)
+	self.check_missing_endif(ends, self.ea.cmdpos)
+	self.check_missing_endtry(ends, self.ea.cmdpos)
+	self.check_missing_endwhile(ends, self.ea.cmdpos)
+	self.check_missing_endfor(ends, self.ea.cmdpos)
+	var begin = strings.TrimPrefix(self.ea.cmd.name, "end")
+	if self.context[0].type_ != NODE_CLASS || self.context[0].ea.cmd.name != begin {
+		panic(Err(viml_printf(":%s without :%s", self.ea.cmd.name, begin), self.ea.cmdpos))
+	}
+	var node = Node(NODE_ENDCLASS)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	self.context[0].endclass = node
+	self.pop_context()
+}
+
+func (self *VimLParser) parse_vim9_expr() *VimNode {
+	return NewVim9ExprParser(self.reader, self).parse_expr1()
+}
+
+func (self *VimLParser) parse_vim9_exprlist() []*VimNode {
+	var list []*VimNode
+	for {
+		self.reader.skip_white()
+		if self.ends_vim9_cmd(self.reader.peek()) {
+			break
+		}
+		list = append(list, self.parse_vim9_expr())
+	}
+	return list
+}
+
+// parse_vim9_cmd_lvals parses :unlet, :lockvar and :unlockvar. The
+// variables end at "#" comment.
+func (self *VimLParser) parse_vim9_cmd_lvals(type_ int) {
+	var node = Node(type_)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	if type_ != NODE_UNLET && isdigit(self.reader.peekn(1)) {
+		node.depth = viml_str2nr(self.reader.read_digit(), 10)
+	}
+	node.list = self.parse_vim9_exprlist()
+	self.add_node(node)
+}
+
+func (self *VimLParser) parse_vim9_cmd_call() {
+	var node = Node(NODE_EXCALL)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	if self.ends_vim9_cmd(self.reader.peek()) {
+		panic(Err("E471: Argument required", self.reader.getpos()))
+	}
+	node.left = self.parse_vim9_expr()
+	if node.left.type_ != NODE_CALL {
+		panic(Err("Not an function call", node.left.pos))
+	}
+	self.add_node(node)
+}
+
+func (self *VimLParser) parse_vim9_cmd_return() {
+	if self.find_context(NODE_DEF) == -1 && self.find_context(NODE_LAMBDA9) == -1 && self.find_context(NODE_FUNCTION) == -1 {
+		panic(Err("E133: :return not inside a function", self.ea.cmdpos))
+	}
+	var node = Node(NODE_RETURN)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	if !self.ends_vim9_cmd(self.reader.peek()) {
+		node.left = self.parse_vim9_expr()
+	}
+	self.add_node(node)
+}
+
+func (self *VimLParser) parse_vim9_cmd_if() {
+	var node = Node(NODE_IF)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.cond = self.parse_vim9_expr()
+	self.add_node(node)
+	self.push_context(node)
+}
+
+func (self *VimLParser) parse_vim9_cmd_elseif() {
+	if self.context[0].type_ != NODE_IF && self.context[0].type_ != NODE_ELSEIF {
+		panic(Err("E582: :elseif without :if", self.ea.cmdpos))
+	}
+	if self.context[0].type_ != NODE_IF {
+		self.pop_context()
+	}
+	var node = Node(NODE_ELSEIF)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.cond = self.parse_vim9_expr()
+	self.context[0].elseif = append(self.context[0].elseif, node)
+	self.push_context(node)
+}
+
+func (self *VimLParser) parse_vim9_cmd_while() {
+	var node = Node(NODE_WHILE)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.cond = self.parse_vim9_expr()
+	self.add_node(node)
+	self.push_context(node)
+}
+
+// :for {name}[: {type}] in {expr}
+// :for [{name}, ...] in {expr}
+func (self *VimLParser) parse_vim9_cmd_for() {
+	var node = Node(NODE_FOR)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	var p = NewVim9ExprParser(self.reader, self)
+	if self.reader.peekn(1) == "[" {
+		node.list, node.rest = p.parse_lhs_list(true)
+	} else {
+		node.left = p.parse_name()
+		if self.reader.peekn(1) == ":" {
+			// the type is not kept.
+			self.reader.getn(1)
+			self.reader.skip_white()
+			p.parse_type()
+		}
+	}
+	self.reader.skip_white()
+	var epos = self.reader.getpos()
+	if self.reader.read_alpha() != "in" {
+		panic(Err("Missing \"in\" after :for", epos))
+	}
+	node.right = self.parse_vim9_expr()
+	self.add_node(node)
+	self.push_context(node)
+}
+
+// parse_vim9_cmd_expr parses :eval, :defer and :throw.
+func (self *VimLParser) parse_vim9_cmd_expr(type_ int) {
+	var node = Node(type_)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.left = self.parse_vim9_expr()
+	self.add_node(node)
+}
+
+// parse_vim9_cmd_exprlist parses :echo, :echon, :echomsg, :echoerr and
+// :execute.
+func (self *VimLParser) parse_vim9_cmd_exprlist(type_ int) {
+	var node = Node(type_)
+	node.pos = self.ea.cmdpos
+	node.ea = self.ea
+	node.list = self.parse_vim9_exprlist()
+	self.add_node(node)
+}
@@ -0,0 +1,933 @@
+package vimlparser
+
+import "strings"
+
+// Vim9ExprParser parses expressions of Vim9 script. The differences from
+// ExprParser are:
+//
+//   - line breaks are allowed in (), [] and {} and around binary operators
+//   - "#" after white space starts a comment
+//   - "." is only used for members and ".." is string concatenation
+//   - lambdas are written as (args) => expr
+//   - {key: value} has literal keys
+//   - "??" operator, <type> casts and $"" interpolated strings
+//
+// Comments in continuation lines are recorded in the reader.
+type Vim9ExprParser struct {
+	reader    *StringReader
+	tokenizer *ExprTokenizer
+	parser    *VimLParser // parses block lambdas; or nil
+	depth     int         // depth of brackets where line breaks are allowed
+}
+
+func NewVim9ExprParser(reader *StringReader, parser *VimLParser) *Vim9ExprParser {
+	return &Vim9ExprParser{
+		reader:    reader,
+		tokenizer: NewExprTokenizer(reader),
+		parser:    parser,
+	}
+}
+
+func (self *Vim9ExprParser) parse() *VimNode {
+	return self.parse_expr1()
+}
+
+// skip_nl skips white spaces. In brackets, it also skips line breaks,
+// empty lines and comments.
+func (self *Vim9ExprParser) skip_nl() {
+	for {
+		self.reader.skip_white()
+		if self.depth == 0 {
+			return
+		}
+		var c = self.reader.peek()
+		if c == "#" {
+			self.parse_comment()
+		} else if c == "<EOL>" {
+			self.reader.get()
+		} else {
+			return
+		}
+	}
+}
+
+// skip_nl_after_op skips white spaces and moves to the next line if the
+// current line ends. An expression can be continued to the next line after
+// binary operators.
+func (self *Vim9ExprParser) skip_nl_after_op() {
+	self.continue_line(func() bool { return true })
+}
+
+// continue_line moves to the beginning of the next non-empty line if the
+// current line ends and ok returns true at there. Otherwise it keeps the
+// position. Comments in the skipped lines are recorded.
+func (self *Vim9ExprParser) continue_line(ok func() bool) {
+	self.skip_nl()
+	var r = self.reader
+	if r.peek() != "#" && r.peek() != "<EOL>" {
+		return
+	}
+	var pos = r.tell()
+	var ncomments = len(r.comments)
+	for {
+		r.skip_white()
+		var c = r.peek()
+		if c == "#" {
+			self.parse_comment()
+		} else if c == "<EOL>" {
+			r.get()
+		} else {
+			break
+		}
+	}
+	if r.peek() == "<EOF>" || !ok() {
+		r.seek_set(pos)
+		r.comments = r.comments[:ncomments]
+	}
+}
+
+// parse_comment records the comment at the current position to the
+// reader. The comment may be read again after backtracking; it's recorded
+// only once.
+func (self *Vim9ExprParser) parse_comment() {
+	var node = Node(NODE_COMMENT)
+	node.pos = self.reader.getpos()
+	node.vim9 = true
+	self.reader.get()
+	node.str = self.reader.getn(-1)
+	var comments = self.reader.comments
+	if n := len(comments); n > 0 && comments[n-1].pos.i >= node.pos.i {
+		return
+	}
+	self.reader.comments = append(self.reader.comments, node)
+}
+
+// get returns the next token. "#" comment is treated as the end of line.
+func (self *Vim9ExprParser) get() *ExprToken {
+	self.skip_nl()
+	if self.reader.peek() == "#" {
+		return self.tokenizer.token(TOKEN_EOL, "#", self.reader.getpos())
+	}
+	return self.tokenizer.get()
+}
+
+func (self *Vim9ExprParser) peek() *ExprToken {
+	var pos = self.reader.tell()
+	var r = self.get()
+	self.reader.seek_set(pos)
+	return r
+}
+
+// next_is returns a function which reports whether the next token is one of
+// types followed by white space, for continue_line. White space is required
+// after operators, so that "++var" in the next line isn't a continuation.
+func (self *Vim9ExprParser) next_is(types ...int) func() bool {
+	return func() bool {
+		var pos = self.reader.tell()
+		var t = self.tokenizer.get().type_
+		var c = self.reader.peek()
+		self.reader.seek_set(pos)
+		if !iswhite(c) && c != "<EOL>" {
+			return false
+		}
+		for _, x := range types {
+			if t == x {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (self *Vim9ExprParser) expect(c string) {
+	self.skip_nl()
+	if self.reader.peekn(len(c)) != c {
+		panic(Err(viml_printf("missing %s: %s", c, self.reader.peekline()), self.reader.getpos()))
+	}
+	self.reader.getn(len(c))
+}
+
+// expr1:
+//
+//	expr2 ? expr1 : expr1
+//	expr2 ?? expr1
+func (self *Vim9ExprParser) parse_expr1() *VimNode {
+	var left = self.parse_expr2()
+	var pos = self.reader.tell()
+	self.continue_line(self.next_is(TOKEN_QUESTION))
+	self.skip_nl()
+	var npos = self.reader.getpos()
+	if self.reader.peekn(2) == "??" {
+		self.reader.getn(2)
+		var node = Node(NODE_FALSY)
+		node.pos = npos
+		node.left = left
+		self.skip_nl_after_op()
+		node.right = self.parse_expr1()
+		return node
+	}
+	var token = self.get()
+	if token.type_ != TOKEN_QUESTION {
+		self.reader.seek_set(pos)
+		return left
+	}
+	var node = Node(NODE_TERNARY)
+	node.pos = token.pos
+	node.cond = left
+	self.skip_nl_after_op()
+	node.left = self.parse_expr1()
+	self.continue_line(self.next_is(TOKEN_COLON))
+	token = self.get()
+	if token.type_ != TOKEN_COLON {
+		panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+	}
+	self.skip_nl_after_op()
+	node.right = self.parse_expr1()
+	return node
+}
+
+// binary parses left-associative binary operators of types. ops maps token
+// types to node types and next parses the operands.
+func (self *Vim9ExprParser) binary(ops map[int]int, next func() *VimNode) *VimNode {
+	var types []int
+	for t := range ops {
+		types = append(types, t)
+	}
+	var left = next()
+	for {
+		var pos = self.reader.tell()
+		self.continue_line(self.next_is(types...))
+		var token = self.get()
+		var type_, ok = ops[token.type_]
+		if !ok {
+			self.reader.seek_set(pos)
+			return left
+		}
+		var node = Node(type_)
+		node.pos = token.pos
+		node.left = left
+		if type_ == NODE_CONCAT {
+			node.op = ".."
+		}
+		self.skip_nl_after_op()
+		node.right = next()
+		left = node
+	}
+}
+
+var vim9_expr2_ops = map[int]int{TOKEN_OROR: NODE_OR}
+var vim9_expr3_ops = map[int]int{TOKEN_ANDAND: NODE_AND}
+
+// expr4 is not associative; binary parses "a == b == c" as "(a == b) == c"
+// though Vim rejects it.
+var vim9_expr4_ops = map[int]int{
+	TOKEN_EQEQ:      NODE_EQUAL,
+	TOKEN_EQEQCI:    NODE_EQUALCI,
+	TOKEN_EQEQCS:    NODE_EQUALCS,
+	TOKEN_NEQ:       NODE_NEQUAL,
+	TOKEN_NEQCI:     NODE_NEQUALCI,
+	TOKEN_NEQCS:     NODE_NEQUALCS,
+	TOKEN_GT:        NODE_GREATER,
+	TOKEN_GTCI:      NODE_GREATERCI,
+	TOKEN_GTCS:      NODE_GREATERCS,
+	TOKEN_GTEQ:      NODE_GEQUAL,
+	TOKEN_GTEQCI:    NODE_GEQUALCI,
+	TOKEN_GTEQCS:    NODE_GEQUALCS,
+	TOKEN_LT:        NODE_SMALLER,
+	TOKEN_LTCI:      NODE_SMALLERCI,
+	TOKEN_LTCS:      NODE_SMALLERCS,
+	TOKEN_LTEQ:      NODE_SEQUAL,
+	TOKEN_LTEQCI:    NODE_SEQUALCI,
+	TOKEN_LTEQCS:    NODE_SEQUALCS,
+	TOKEN_MATCH:     NODE_MATCH,
+	TOKEN_MATCHCI:   NODE_MATCHCI,
+	TOKEN_MATCHCS:   NODE_MATCHCS,
+	TOKEN_NOMATCH:   NODE_NOMATCH,
+	TOKEN_NOMATCHCI: NODE_NOMATCHCI,
+	TOKEN_NOMATCHCS: NODE_NOMATCHCS,
+	TOKEN_IS:        NODE_IS,
+	TOKEN_ISCI:      NODE_ISCI,
+	TOKEN_ISCS:      NODE_ISCS,
+	TOKEN_ISNOT:     NODE_ISNOT,
+	TOKEN_ISNOTCI:   NODE_ISNOTCI,
+	TOKEN_ISNOTCS:   NODE_ISNOTCS,
+}
+var vim9_expr5_ops = map[int]int{
+	TOKEN_PLUS:   NODE_ADD,
+	TOKEN_MINUS:  NODE_SUBTRACT,
+	TOKEN_DOTDOT: NODE_CONCAT,
+}
+var vim9_expr6_ops = map[int]int{
+	TOKEN_STAR:    NODE_MULTIPLY,
+	TOKEN_SLASH:   NODE_DIVIDE,
+	TOKEN_PERCENT: NODE_REMAINDER,
+}
+
+// expr2: expr3 || expr3 ..
+func (self *Vim9ExprParser) parse_expr2() *VimNode {
+	return self.binary(vim9_expr2_ops, self.parse_expr3)
+}
+
+// expr3: expr4 && expr4 ..
+func (self *Vim9ExprParser) parse_expr3() *VimNode {
+	return self.binary(vim9_expr3_ops, self.parse_expr4)
+}
+
+// expr4: expr5 == expr5, expr5 is expr5...
+func (self *Vim9ExprParser) parse_expr4() *VimNode {
+	return self.binary(vim9_expr4_ops, self.parse_expr5)
+}
+
+// expr5: expr6 + expr6, expr6 - expr6, expr6 .. expr6
+func (self *Vim9ExprParser) parse_expr5() *VimNode {
+	return self.binary(vim9_expr5_ops, self.parse_expr6)
+}
+
+// expr6: expr7 * expr7, expr7 / expr7, expr7 % expr7
+func (self *Vim9ExprParser) parse_expr6() *VimNode {
+	return self.binary(vim9_expr6_ops, self.parse_expr7)
+}
+
+// expr7:
+//
+//	! expr7
+//	- expr7
+//	+ expr7
+//	<type>expr7
+func (self *Vim9ExprParser) parse_expr7() *VimNode {
+	self.skip_nl()
+	var r = self.reader
+	if r.peek() == "<" && isalpha(r.p(1)) {
+		var node = Node(NODE_CAST)
+		node.pos = r.getpos()
+		r.get()
+		node.rtype = self.parse_type()
+		self.expect(">")
+		node.left = self.parse_expr7()
+		return node
+	}
+	var pos = r.tell()
+	var token = self.get()
+	var type_ = -1
+	if token.type_ == TOKEN_NOT {
+		type_ = NODE_NOT
+	} else if token.type_ == TOKEN_MINUS {
+		type_ = NODE_MINUS
+	} else if token.type_ == TOKEN_PLUS {
+		type_ = NODE_PLUS
+	} else {
+		r.seek_set(pos)
+		return self.parse_expr8()
+	}
+	var node = Node(type_)
+	node.pos = token.pos
+	node.left = self.parse_expr7()
+	return node
+}
+
+// expr8:
+//
+//	expr8[expr1]
+//	expr8[expr1 : expr1]
+//	expr8.name
+//	expr8->name(expr1, ...)
+//	expr8->(lambda)(expr1, ...)
+//	expr8(expr1, ...)
+func (self *Vim9ExprParser) parse_expr8() *VimNode {
+	var left = self.parse_expr9()
+	var r = self.reader
+	for {
+		var pos = r.tell()
+		self.continue_line(func() bool {
+			return r.peekn(2) == "->" || r.p(0) == "." && iswordc(r.p(1))
+		})
+		var c = r.peek()
+		if c == "[" {
+			left = self.parse_subscript(left)
+		} else if c == "(" {
+			var node = Node(NODE_CALL)
+			node.pos = r.getpos()
+			node.left = left
+			r.get()
+			node.rlist = self.parse_rlist()
+			left = node
+		} else if c == "." && iswordc(r.p(1)) {
+			var node = Node(NODE_DOT)
+			node.pos = r.getpos()
+			node.left = left
+			r.get()
+			node.right = self.parse_word()
+			left = node
+		} else if r.peekn(2) == "->" {
+			r.getn(2)
+			var method = self.parse_expr9()
+			for r.p(0) == "." && iswordc(r.p(1)) {
+				var node = Node(NODE_DOT)
+				node.pos = r.getpos()
+				node.left = method
+				r.get()
+				node.right = self.parse_word()
+				method = node
+			}
+			if r.peek() != "(" {
+				panic(Err("E107: Missing parentheses: lambda", r.getpos()))
+			}
+			var right = Node(NODE_CALL)
+			right.pos = r.getpos()
+			right.left = method
+			r.get()
+			right.rlist = self.parse_rlist()
+			var node = Node(NODE_METHOD)
+			node.pos = right.pos
+			node.left = left
+			node.right = right
+			left = node
+		} else {
+			r.seek_set(pos)
+			return left
+		}
+	}
+}
+
+// parse_subscript parses [expr1] or [expr1 : expr1] after left.
+func (self *Vim9ExprParser) parse_subscript(left *VimNode) *VimNode {
+	var npos = self.reader.getpos()
+	self.reader.get()
+	self.depth++
+	defer func() { self.depth-- }()
+	var low *VimNode
+	if self.peek().type_ != TOKEN_COLON {
+		low = self.parse_expr1()
+	}
+	if self.peek().type_ != TOKEN_COLON {
+		self.expect("]")
+		var node = Node(NODE_SUBSCRIPT)
+		node.pos = npos
+		node.left = left
+		node.right = low
+		return node
+	}
+	self.get()
+	var node = Node(NODE_SLICE)
+	node.pos = npos
+	node.left = left
+	node.rlist = []*VimNode{low, nil}
+	if self.peek().type_ != TOKEN_SQCLOSE {
+		node.rlist[1] = self.parse_expr1()
+	}
+	self.expect("]")
+	return node
+}
+
+// parse_rlist parses arguments of function call after "(".
+func (self *Vim9ExprParser) parse_rlist() []*VimNode {
+	self.depth++
+	defer func() { self.depth-- }()
+	var rlist = []*VimNode{}
+	for {
+		if self.peek().type_ == TOKEN_PCLOSE {
+			self.get()
+			break
+		}
+		rlist = append(rlist, self.parse_expr1())
+		var token = self.get()
+		if token.type_ == TOKEN_PCLOSE {
+			break
+		} else if token.type_ != TOKEN_COMMA {
+			panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+		}
+	}
+	if len(rlist) > MAX_FUNC_ARGS {
+		panic(Err("E740: Too many arguments for function", self.reader.getpos()))
+	}
+	return rlist
+}
+
+// parse_word parses member name after ".".
+func (self *Vim9ExprParser) parse_word() *VimNode {
+	var node = Node(NODE_IDENTIFIER)
+	node.pos = self.reader.getpos()
+	node.value = self.reader.read_word()
+	return node
+}
+
+// expr9:
+//
+//	number
+//	"string"
+//	'string'
+//	$"string {expr}"
+//	[expr1, ...]
+//	{key: expr1, ...}
+//	{[expr1]: expr1, ...}
+//	(args) => expr1
+//	&option
+//	(expr1)
+//	variable
+//	$VAR
+//	@r
+func (self *Vim9ExprParser) parse_expr9() *VimNode {
+	self.skip_nl()
+	var r = self.reader
+	if r.peek() == "$" && (r.p(1) == "\"" || r.p(1) == "'") {
+		return self.parse_interpolated()
+	}
+	if r.peek() == "(" && self.is_lambda() {
+		return self.parse_lambda()
+	}
+	var pos = r.tell()
+	var token = self.get()
+	var node *VimNode
+	if token.type_ == TOKEN_NUMBER {
+		node = Node(NODE_NUMBER)
+		node.pos = token.pos
+		node.value = token.value
+	} else if token.type_ == TOKEN_BLOB {
+		node = Node(NODE_BLOB)
+		node.pos = token.pos
+		node.value = token.value
+	} else if token.type_ == TOKEN_DQUOTE {
+		r.seek_set(pos)
+		node = Node(NODE_STRING)
+		node.pos = token.pos
+		node.value = "\"" + self.tokenizer.get_dstring() + "\""
+	} else if token.type_ == TOKEN_SQUOTE {
+		r.seek_set(pos)
+		node = Node(NODE_STRING)
+		node.pos = token.pos
+		node.value = "'" + self.tokenizer.get_sstring() + "'"
+	} else if token.type_ == TOKEN_SQOPEN {
+		node = Node(NODE_LIST)
+		node.pos = token.pos
+		node.value = self.parse_list()
+	} else if token.type_ == TOKEN_COPEN {
+		node = Node(NODE_DICT)
+		node.pos = token.pos
+		node.value = self.parse_dict()
+	} else if token.type_ == TOKEN_POPEN {
+		self.depth++
+		node = Node(NODE_PARENEXPR)
+		node.pos = token.pos
+		node.value = self.parse_expr1()
+		self.expect(")")
+		self.depth--
+	} else if token.type_ == TOKEN_OPTION {
+		node = Node(NODE_OPTION)
+		node.pos = token.pos
+		node.value = token.value
+	} else if token.type_ == TOKEN_IDENTIFIER {
+		node = Node(NODE_IDENTIFIER)
+		node.pos = token.pos
+		node.value = token.value
+	} else if token.type_ == TOKEN_ENV {
+		node = Node(NODE_ENV)
+		node.pos = token.pos
+		node.value = token.value
+	} else if token.type_ == TOKEN_REG {
+		node = Node(NODE_REG)
+		node.pos = token.pos
+		node.value = token.value
+	} else {
+		panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+	}
+	return node
+}
+
+// parse_list parses items of list after "[".
+func (self *Vim9ExprParser) parse_list() []interface{} {
+	self.depth++
+	defer func() { self.depth-- }()
+	var items = []interface{}{}
+	for {
+		if self.peek().type_ == TOKEN_SQCLOSE {
+			self.get()
+			break
+		}
+		items = append(items, self.parse_expr1())
+		var token = self.get()
+		if token.type_ == TOKEN_SQCLOSE {
+			break
+		} else if token.type_ != TOKEN_COMMA {
+			panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+		}
+	}
+	return items
+}
+
+// parse_dict parses entries of dictionary after "{". A key is a literal
+// key, [expr1] or a string.
+func (self *Vim9ExprParser) parse_dict() []interface{} {
+	self.depth++
+	defer func() { self.depth-- }()
+	var r = self.reader
+	var entries = []interface{}{}
+	for {
+		if self.peek().type_ == TOKEN_CCLOSE {
+			self.get()
+			break
+		}
+		self.skip_nl()
+		var key *VimNode
+		if r.peek() == "[" {
+			r.get()
+			key = self.parse_expr1()
+			self.expect("]")
+		} else if isalnum(r.peek()) || r.peek() == "_" || r.peek() == "-" {
+			key = Node(NODE_STRING)
+			key.pos = r.getpos()
+			var s = ""
+			for isalnum(r.peek()) || r.peek() == "_" || r.peek() == "-" {
+				s += r.get()
+			}
+			key.value = "'" + s + "'"
+		} else {
+			key = self.parse_expr1()
+		}
+		self.expect(":")
+		var value = self.parse_expr1()
+		entries = append(entries, []interface{}{key, value})
+		var token = self.get()
+		if token.type_ == TOKEN_CCLOSE {
+			break
+		} else if token.type_ != TOKEN_COMMA {
+			panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+		}
+	}
+	return entries
+}
+
+// parse_interpolated parses $"string {expr}" and $'string {expr}'.
+func (self *Vim9ExprParser) parse_interpolated() *VimNode {
+	var r = self.reader
+	var node = Node(NODE_INTERPOLATED)
+	node.pos = r.getpos()
+	node.list = []*VimNode{}
+	r.get()
+	var quote = r.get()
+	for {
+		var c = r.peek()
+		if c == "<EOL>" || c == "<EOF>" {
+			panic(Err("unexpected EOL", r.getpos()))
+		} else if c == quote {
+			r.get()
+			if quote == "'" && r.peek() == "'" {
+				r.get()
+				continue
+			}
+			break
+		} else if c == "\\" && quote == "\"" {
+			r.get()
+			if r.peek() != "<EOL>" && r.peek() != "<EOF>" {
+				r.get()
+			}
+		} else if (c == "{" || c == "}") && r.p(1) == c {
+			r.getn(2)
+		} else if c == "{" {
+			r.get()
+			self.depth++
+			node.list = append(node.list, self.parse_expr1())
+			self.expect("}")
+			self.depth--
+		} else {
+			r.get()
+		}
+	}
+	node.value = r.getstr(node.pos, r.getpos())
+	return node
+}
+
+// is_lambda reports whether "(" at the current position starts a lambda:
+// (args) => or (args): type =>.
+func (self *Vim9ExprParser) is_lambda() bool {
+	var r = self.reader
+	var pos = r.tell()
+	defer r.seek_set(pos)
+	if !r.skip_brackets() {
+		return false
+	}
+	r.skip_white()
+	if r.peek() == ":" && iswhite(r.p(1)) {
+		r.get()
+		r.skip_white()
+		self.read_type()
+		r.skip_white()
+	}
+	return r.peekn(2) == "=>"
+}
+
+// parse_lambda parses (args): type => expr1 and block lambda:
+//
+//	(args): type => {
+//	  statements
+//	}
+func (self *Vim9ExprParser) parse_lambda() *VimNode {
+	var r = self.reader
+	var node = Node(NODE_LAMBDA9)
+	node.pos = r.getpos()
+	r.get()
+	node.params = self.parse_params()
+	r.skip_white()
+	if r.peek() == ":" {
+		r.get()
+		r.skip_white()
+		node.rtype = self.parse_type()
+	}
+	self.expect("=>")
+	self.skip_nl_after_op()
+	if r.peek() == "{" {
+		r.get()
+		self.parse_block(node)
+		return node
+	}
+	node.left = self.parse_expr1()
+	return node
+}
+
+// parse_block parses statements of block lambda node until "}".
+func (self *Vim9ExprParser) parse_block(node *VimNode) {
+	var p = self.parser
+	if p == nil {
+		panic(Err("unexpected token: {", self.reader.getpos()))
+	}
+	var ea = p.ea
+	defer func() { p.ea = ea }()
+	// Only a comment can follow "{".
+	p.parse_vim9_trail()
+	node.body = []*VimNode{}
+	p.push_context(node)
+	defer p.pop_context()
+	for {
+		self.reader.skip_white()
+		var c = self.reader.peek()
+		if c == "}" {
+			break
+		} else if c == "<EOF>" {
+			panic(Err("E1171: Missing } after inline function", self.reader.getpos()))
+		}
+		p.parse_vim9_cmd()
+	}
+	p.check_missing_endif("}", self.reader.getpos())
+	p.check_missing_endtry("}", self.reader.getpos())
+	p.check_missing_endwhile("}", self.reader.getpos())
+	p.check_missing_endfor("}", self.reader.getpos())
+	self.reader.get()
+}
+
+// parse_params parses parameters of :def function or lambda after "(".
+//
+//	name: type = default, ...name: type
+func (self *Vim9ExprParser) parse_params() []*VimNode {
+	var r = self.reader
+	self.depth++
+	defer func() { self.depth-- }()
+	var params = []*VimNode{}
+	var named = map[string]bool{}
+	for {
+		self.skip_nl()
+		if r.peek() == ")" {
+			r.get()
+			break
+		}
+		var param = Node(NODE_PARAM)
+		param.pos = r.getpos()
+		if r.peekn(3) == "..." {
+			r.getn(3)
+			param.op = "..."
+		}
+		var name = Node(NODE_IDENTIFIER)
+		name.pos = r.getpos()
+		var s = ""
+		if r.peekn(5) == "this." {
+			s = r.getn(5)
+		}
+		s += r.read_word()
+		if s == "" || s == "this." {
+			panic(Err(viml_printf("E125: Illegal argument: %s", r.peekline()), name.pos))
+		} else if named[s] {
+			panic(Err(viml_printf("E853: Duplicate argument name: %s", s), name.pos))
+		}
+		named[s] = true
+		name.value = s
+		param.left = name
+		if r.peek() == ":" {
+			r.get()
+			r.skip_white()
+			param.rtype = self.parse_type()
+		}
+		self.skip_nl()
+		if r.peek() == "=" {
+			r.get()
+			param.right = self.parse_expr1()
+		}
+		params = append(params, param)
+		var token = self.get()
+		if token.type_ == TOKEN_PCLOSE {
+			break
+		} else if token.type_ != TOKEN_COMMA || param.op == "..." {
+			panic(Err(viml_printf("unexpected token: %s", token.value), token.pos))
+		}
+	}
+	return params
+}
+
+// parse_type parses a type such as number, list<string> and
+// func(number): bool.
+func (self *Vim9ExprParser) parse_type() *VimNode {
+	var node = Node(NODE_TYPE)
+	node.pos = self.reader.getpos()
+	self.read_type()
+	node.str = self.reader.getstr(node.pos, self.reader.getpos())
+	if node.str == "" {
+		panic(Err(viml_printf("E1010: Type not recognized: %s", self.reader.peekline()), node.pos))
+	}
+	return node
+}
+
+// read_type skips a type.
+func (self *Vim9ExprParser) read_type() {
+	var r = self.reader
+	var name = ""
+	for iswordc(r.peek()) || r.peek() == "." && iswordc(r.p(1)) {
+		name += r.get()
+	}
+	if name == "" {
+		return
+	}
+	if r.peek() == "<" {
+		r.get()
+		for {
+			r.skip_white()
+			self.read_type()
+			r.skip_white()
+			if r.peek() != "," {
+				break
+			}
+			r.get()
+		}
+		if r.peek() == ">" {
+			r.get()
+		}
+	}
+	if name == "func" && r.peek() == "(" {
+		r.get()
+		for {
+			r.skip_white()
+			if r.peek() == ")" {
+				r.get()
+				break
+			}
+			if r.peek() == "?" {
+				r.get()
+			} else if r.peekn(3) == "..." {
+				r.getn(3)
+			}
+			self.read_type()
+			r.skip_white()
+			if r.peek() == "," {
+				r.get()
+			} else if r.peek() != ")" {
+				break
+			}
+		}
+	}
+	if name == "func" && r.peek() == ":" && iswhite(r.p(1)) {
+		r.get()
+		r.skip_white()
+		self.read_type()
+	}
+}
+
+// parse_name parses a variable name of declaration. Only the scope of
+// Vim9 script variables (b:, g:, t:, w:) can be used.
+func (self *Vim9ExprParser) parse_name() *VimNode {
+	var r = self.reader
+	r.skip_white()
+	var node = Node(NODE_IDENTIFIER)
+	node.pos = r.getpos()
+	var s = ""
+	if c := r.peekn(3); len(c) == 3 && strings.IndexByte("bgtw", c[0]) >= 0 && c[1] == ':' && iswordc(c[2:]) {
+		s = r.getn(2)
+	}
+	for iswordc(r.peek()) || r.peek() == "#" {
+		s += r.get()
+	}
+	if s == "" {
+		panic(Err(viml_printf("E475: Invalid argument: %s", r.peekline()), node.pos))
+	}
+	node.value = s
+	return node
+}
+
+// parse_lhs_list parses [a, b; rest]. The items are variable names if names
+// is true or lvalues otherwise.
+func (self *Vim9ExprParser) parse_lhs_list(names bool) ([]*VimNode, *VimNode) {
+	var r = self.reader
+	r.get()
+	self.depth++
+	defer func() { self.depth-- }()
+	var item = func() *VimNode {
+		self.skip_nl()
+		if !names {
+			return self.parse_expr8()
+		}
+		var node = self.parse_name()
+		if r.peek() == ":" {
+			// the type is not kept.
+			r.get()
+			r.skip_white()
+			self.parse_type()
+		}
+		return node
+	}
+	var list = []*VimNode{}
+	var rest *VimNode
+	for {
+		list = append(list, item())
+		self.skip_nl()
+		var c = r.get()
+		if c == "]" {
+			break
+		} else if c == ";" {
+			rest = item()
+			self.expect("]")
+			break
+		} else if c != "," {
+			panic(Err(viml_printf("unexpected character: %s", c), r.getpos()))
+		}
+	}
+	return list, rest
+}
+
+// skip_brackets skips the bracket at the current position to the matching
+// close bracket. It returns false if the bracket is not closed.
+func (self *StringReader) skip_brackets() bool {
+	var stack []string
+	for {
+		var c = self.get()
+		switch c {
+		case "<EOF>":
+			return false
+		case "(", "[", "{":
+			stack = append(stack, map[string]string{"(": ")", "[": "]", "{": "}"}[c])
+		case ")", "]", "}":
+			if len(stack) == 0 || stack[len(stack)-1] != c {
+				return false
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return true
+			}
+		case "'", "\"":
+			for {
+				var d = self.get()
+				if d == "<EOL>" || d == "<EOF>" {
+					return false
+				} else if d == "\\" && c == "\"" {
+					self.get()
+				} else if d == c {
+					break
+				}
+			}
+		}
+	}
+}
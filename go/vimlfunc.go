@@ -50,6 +50,7 @@ var patVim2Go = map[string]string{
 	"^\\h":                     "^[A-Za-z_]",
 	"^\\s":                     "^\\s",
 	"^\\s*\\\\":                "^\\s*\\\\",
+	"^\\s*\"\\\\ ":             "^\\s*\"\\\\ ",
 	"^[ \\t]$":                 "^[ \\t]$",
 	"^[A-Za-z]$":               "^[A-Za-z]$",
 	"^[0-9A-Za-z]$":            "^[0-9A-Za-z]$",
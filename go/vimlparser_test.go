@@ -38,6 +38,8 @@ func TestStringReader___init__(t *testing.T) {
 		{in: []string{"let x = 1"}, buf: "let x = 1<EOL>"},
 		{in: []string{"let x = 1", "let y = x"}, buf: "let x = 1<EOL>let y = x<EOL>"},
 		{in: []string{"let x =", `\ 1`}, buf: "let x = 1<EOL>"},
+		{in: []string{"let x =", `"\ comment`, `\ 1`}, buf: "let x = 1<EOL>"},
+		{in: []string{"let x = 1", `"\ comment`}, buf: `let x = 1<EOL>"\ comment<EOL>`},
 		{in: []string{"あいうえお"}, buf: "あいうえお<EOL>"},
 	}
 	for _, tt := range tests {
@@ -0,0 +1,270 @@
+// Package lint provides a framework of static checks for Vim script.
+//
+// A check is a Rule which creates an ast.Visitor for each file. Rules are
+// registered by ID with Register and Run walks the file with the enabled
+// rules. RunProgram also provides the other files of a loader.Program to
+// rules which check references across files, e.g. calls of autoload
+// functions.
+//
+// Diagnostics can be suppressed with pragma comments.
+//
+//	" vimlint: ignore
+//	function! F()          " no diagnostics in this line
+//	endfunction
+//
+//	echo x == 1 " vimlint: ignore ambiguous-comparison
+//
+// A pragma in its own line suppresses diagnostics in the next line and a
+// pragma after a command suppresses diagnostics in the line. Rule IDs after
+// "ignore" limit the suppressed diagnostics to the rules.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+// Severity represents severity of diagnostics.
+type Severity int
+
+const (
+	Error Severity = iota
+	Warning
+	Info
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Error:
+		return "error"
+	case Warning:
+		return "warning"
+	case Info:
+		return "info"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// Diagnostic represents a problem reported by a rule.
+type Diagnostic struct {
+	Pos      ast.Pos // position of the problem
+	End      ast.Pos // position immediately after the problem
+	Severity Severity
+	Message  string
+	RuleID   string
+}
+
+// String returns a string in the form of "file:line:column: message (rule)".
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%v: %s (%s)", d.Pos, d.Message, d.RuleID)
+}
+
+// Rule is a check which reports diagnostics.
+type Rule struct {
+	ID       string   // unique name of the rule, e.g. "missing-abort"
+	Doc      string   // one line description
+	Severity Severity // severity of diagnostics
+
+	// New returns a visitor which walks a file from *ast.File node and
+	// reports diagnostics to pass.
+	New func(pass *Pass) ast.Visitor
+}
+
+var rules = make(map[string]*Rule)
+
+// Register registers the rule. It panics if a rule with the same ID is
+// already registered.
+func Register(r *Rule) {
+	if _, dup := rules[r.ID]; dup {
+		panic("lint: Register called twice for rule " + r.ID)
+	}
+	rules[r.ID] = r
+}
+
+// Lookup returns the rule registered with the id or nil.
+func Lookup(id string) *Rule {
+	return rules[id]
+}
+
+// Rules returns registered rules sorted by ID.
+func Rules() []*Rule {
+	rs := make([]*Rule, 0, len(rules))
+	for _, r := range rules {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].ID < rs[j].ID })
+	return rs
+}
+
+// Pass provides the file to a rule and collects its diagnostics.
+type Pass struct {
+	File *ast.File
+	Rule *Rule
+
+	// Program is the program which has File for RunProgram; or nil for
+	// Run. Rules which check definitions in other files do nothing
+	// without Program.
+	Program *loader.Program
+
+	// Editors is the editors which run File.
+	Editors builtin.Editor
+
+	diags []Diagnostic
+}
+
+// Report reports a diagnostic for the node.
+func (p *Pass) Report(n ast.Node, format string, args ...interface{}) {
+	p.Reportf(n.Pos(), n.End(), format, args...)
+}
+
+// Reportf reports a diagnostic for the range from pos to end.
+func (p *Pass) Reportf(pos, end ast.Pos, format string, args ...interface{}) {
+	p.diags = append(p.diags, Diagnostic{
+		Pos:      pos,
+		End:      end,
+		Severity: p.Rule.Severity,
+		Message:  fmt.Sprintf(format, args...),
+		RuleID:   p.Rule.ID,
+	})
+}
+
+// Config selects rules to run.
+type Config struct {
+	Enable  []string // IDs of rules to run; all registered rules if empty
+	Disable []string // IDs of rules not to run
+
+	// Editors is the editors which run the files; both Vim and Neovim if
+	// zero. It's for rules which check builtin functions.
+	Editors builtin.Editor
+}
+
+// enabled returns rules to run.
+func (c *Config) enabled() ([]*Rule, error) {
+	if c == nil {
+		return Rules(), nil
+	}
+	for _, id := range append(c.Enable, c.Disable...) {
+		if Lookup(id) == nil {
+			return nil, fmt.Errorf("lint: unknown rule %q", id)
+		}
+	}
+	rs := Rules()
+	if len(c.Enable) > 0 {
+		rs = rs[:0]
+		for _, id := range c.Enable {
+			rs = append(rs, Lookup(id))
+		}
+	}
+	var enabled []*Rule
+	for _, r := range rs {
+		if !contains(c.Disable, r.ID) {
+			enabled = append(enabled, r)
+		}
+	}
+	return enabled, nil
+}
+
+// Run runs the rules selected by cfg for the file and returns diagnostics
+// sorted by position. It runs all registered rules if cfg is nil.
+func Run(f *ast.File, cfg *Config) ([]Diagnostic, error) {
+	return run(nil, f, cfg)
+}
+
+// RunProgram is like Run but rules can refer to the other files of the
+// program, e.g. to check calls of autoload functions. f is a file of prog.
+func RunProgram(prog *loader.Program, f *ast.File, cfg *Config) ([]Diagnostic, error) {
+	return run(prog, f, cfg)
+}
+
+func run(prog *loader.Program, f *ast.File, cfg *Config) ([]Diagnostic, error) {
+	rs, err := cfg.enabled()
+	if err != nil {
+		return nil, err
+	}
+	editors := builtin.Both
+	if cfg != nil && cfg.Editors != 0 {
+		editors = cfg.Editors
+	}
+	pragmas := ignorePragmas(f)
+	var diags []Diagnostic
+	for _, r := range rs {
+		pass := &Pass{File: f, Rule: r, Program: prog, Editors: editors}
+		ast.Walk(r.New(pass), f)
+		for _, d := range pass.diags {
+			if !pragmas.ignore(d) {
+				diags = append(diags, d)
+			}
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool {
+		p, q := diags[i].Pos, diags[j].Pos
+		return p.Line < q.Line || p.Line == q.Line && p.Column < q.Column
+	})
+	return diags, nil
+}
+
+const pragmaPrefix = "vimlint:"
+
+// pragmas maps line numbers to IDs of rules ignored in the line. Empty list
+// means all rules.
+type pragmas map[int][]string
+
+func (p pragmas) ignore(d Diagnostic) bool {
+	ids, ok := p[d.Pos.Line]
+	return ok && (len(ids) == 0 || contains(ids, d.RuleID))
+}
+
+// ignorePragmas returns `" vimlint: ignore` pragmas in f.
+func ignorePragmas(f *ast.File) pragmas {
+	p := make(pragmas)
+	add := func(c *ast.Comment, line int) {
+		text := strings.TrimSpace(c.Text)
+		if !strings.HasPrefix(text, pragmaPrefix) {
+			return
+		}
+		fields := strings.FieldsFunc(text[len(pragmaPrefix):], func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		if len(fields) == 0 || fields[0] != "ignore" {
+			return
+		}
+		if ids, ok := p[line]; ok && len(ids) == 0 {
+			return
+		}
+		if len(fields) == 1 {
+			p[line] = []string{}
+			return
+		}
+		p[line] = append(p[line], fields[1:]...)
+	}
+	// comments in the body are in their own lines.
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Comment:
+			add(n, n.Pos().Line+1)
+		case *ast.CommentGroup:
+			return false
+		}
+		return true
+	})
+	for _, g := range f.Comments {
+		for _, c := range g.List {
+			add(c, c.Pos().Line)
+		}
+	}
+	return p
+}
+
+func contains(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
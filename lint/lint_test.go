@@ -0,0 +1,299 @@
+package lint
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/loader"
+)
+
+func lint(t *testing.T, src string, cfg *Config) []string {
+	t.Helper()
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diags, err := Run(f, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return format(diags)
+}
+
+func format(diags []Diagnostic) []string {
+	var got []string
+	for _, d := range diags {
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d: %s: %s (%s)",
+			d.Pos.Line, d.Pos.Column, d.End.Line, d.End.Column, d.Severity, d.Message, d.RuleID))
+	}
+	return got
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		rule string
+		src  string
+		want []string
+	}{
+		{
+			rule: "missing-abort",
+			src: `function! F() abort
+endfunction
+function! s:g(x) dict
+endfunction
+`,
+			want: []string{"3:1-3:14: warning: function without abort attribute (missing-abort)"},
+		},
+		{
+			rule: "ambiguous-comparison",
+			src: `echo a == b
+echo a ==# b a ==? b a != b
+echo a =~ 'x' a is b
+def F()
+  echo a == b
+enddef
+`,
+			want: []string{
+				"1:8-1:10: warning: == depends on 'ignorecase'; use ==# or ==? (ambiguous-comparison)",
+				"2:24-2:26: warning: != depends on 'ignorecase'; use !=# or !=? (ambiguous-comparison)",
+				"3:8-3:10: warning: =~ depends on 'ignorecase'; use =~# or =~? (ambiguous-comparison)",
+			},
+		},
+		{
+			rule: "undefined-local-variable",
+			src: `function! F(a) abort
+  let x = 1
+  let l:y = 2
+  for [l:i, j] in []
+  endfor
+  echo l:x l:y l:i l:j a:a l:z
+  function! G() abort
+    echo l:x
+  endfunction
+endfunction
+echo l:x
+`,
+			want: []string{
+				"6:28-6:31: error: undefined variable l:z (undefined-local-variable)",
+				"8:10-8:13: error: undefined variable l:x (undefined-local-variable)",
+			},
+		},
+		{
+			rule: "autocmd-outside-augroup",
+			src: `autocmd BufRead * echo 1
+augroup vimrc
+  autocmd!
+  autocmd BufRead,BufNewFile * echo 1
+augroup END
+autocmd vimrc FileType vim echo 1
+autocmd!
+augroup vimrc " with a comment
+  autocmd BufRead * echo 1
+augroup END
+`,
+			want: []string{
+				"1:1-1:25: warning: autocmd outside augroup (autocmd-outside-augroup)",
+				"7:1-7:9: warning: autocmd outside augroup (autocmd-outside-augroup)",
+			},
+		},
+		{
+			rule: "unknown-option",
+			src: `set tabstp=4 ts=4 nowrap t_Co=256 all&
+setlocal nonumbr invlist
+set tabstp=4 " typo
+command -nargs=* CompilerSet setlocal <args> | set <q-args> numbr
+`,
+			want: []string{
+				"1:5-1:13: error: unknown option tabstp (unknown-option)",
+				"2:10-2:17: error: unknown option numbr (unknown-option)",
+				"3:5-3:13: error: unknown option tabstp (unknown-option)",
+				"4:61-4:66: error: unknown option numbr (unknown-option)",
+			},
+		},
+		{
+			rule: "deprecated-option",
+			src:  "set textmode notx fileformat=unix\n",
+			want: []string{
+				"1:5-1:13: warning: textmode is deprecated; use fileformat (deprecated-option)",
+				"1:14-1:18: warning: textmode is deprecated; use fileformat (deprecated-option)",
+			},
+		},
+		{
+			rule: "type-mismatch",
+			src:  "let s:l = [1]\necho s:l + 1 len(42)\n",
+			want: []string{
+				"2:6-2:9: error: E745: Using a List as a Number (type-mismatch)",
+				"2:18-2:20: error: Number is converted to String for len() (type-mismatch)",
+			},
+		},
+		{
+			rule: "unknown-function",
+			src: `call strlenn('a')
+echo F() s:f() foo#bar() 'a'->lenn()
+echo jobstart('ls') nvim_buf_get_lines(0, 0, -1, v:true)
+function! G(fn) abort
+  let fn = a:fn
+  return fn()
+endfunction
+def H()
+  strlenn()
+enddef
+`,
+			want: []string{
+				"1:6-1:13: error: E117: Unknown function: strlenn (unknown-function)",
+				"2:31-2:35: error: E117: Unknown function: lenn (unknown-function)",
+			},
+		},
+		{
+			rule: "argument-count",
+			src: `echo strlen() strlen('a', 'b') 'a'->strlen() 'a'->strlen(1)
+echo printf('%d %d', 1, 2) get([], 0) argv() nvim_call_function('f', [])
+`,
+			want: []string{
+				"1:6-1:12: error: E119: Not enough arguments for function: strlen (argument-count)",
+				"1:15-1:21: error: E118: Too many arguments for function: strlen (argument-count)",
+				"1:51-1:57: error: E118: Too many arguments for function: strlen (argument-count)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := lint(t, tt.src, &Config{Enable: []string{tt.rule}})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\ngot  %q\nwant %q", tt.rule, got, tt.want)
+		}
+	}
+}
+
+func TestRunProgram(t *testing.T) {
+	files := []struct {
+		path string
+		src  string
+	}{
+		{"rtp/plugin/foo.vim", `call foo#bar#baz()
+call foo#bar#qux()
+call foo#nothing#x()
+call foo#local()
+call other#plugin()
+call foo#vim9#Func()
+function! foo#local() abort
+endfunction
+`},
+		{"rtp/autoload/foo/bar.vim", `function! foo#bar#baz() abort
+endfunction
+function! foo#qux() abort
+endfunction
+function! s:private() abort
+endfunction
+`},
+		{"rtp/autoload/foo/vim9.vim", `vim9script
+export def Func()
+enddef
+`},
+	}
+	prog := &loader.Program{}
+	for _, f := range files {
+		node, err := vimlparser.ParseFile(strings.NewReader(f.src), f.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		prog.Files = append(prog.Files, &loader.File{Path: f.path, AST: node})
+	}
+	cfg := &Config{Enable: []string{"undefined-autoload-function", "autoload-function-name"}}
+	want := [][]string{
+		{
+			"2:6-2:17: error: undefined function foo#bar#qux: not defined in autoload/foo/bar.vim (undefined-autoload-function)",
+			"3:6-3:19: error: undefined function foo#nothing#x: autoload/foo/nothing.vim is not found (undefined-autoload-function)",
+		},
+		{
+			"3:11-3:18: error: function foo#qux should be named foo#bar#qux in this script (autoload-function-name)",
+		},
+		nil,
+	}
+	for i, f := range prog.Files {
+		diags, err := RunProgram(prog, f.AST, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := format(diags); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("%s:\ngot  %q\nwant %q", f.Path, got, want[i])
+		}
+	}
+
+	// Run doesn't know the other files.
+	if got := lint(t, "call foo#bar#qux()\n", cfg); got != nil {
+		t.Errorf("Run: got %q", got)
+	}
+}
+
+func TestConfig_editors(t *testing.T) {
+	src := "call jobstart('ls')\ncall job_start('ls')\n"
+	tests := []struct {
+		editors builtin.Editor
+		want    []string
+	}{
+		{0, nil},
+		{builtin.Vim, []string{"1:6-1:14: error: jobstart() is not available in Vim (unknown-function)"}},
+		{builtin.Neovim, []string{"2:6-2:15: error: job_start() is not available in Neovim (unknown-function)"}},
+	}
+	for _, tt := range tests {
+		got := lint(t, src, &Config{Enable: []string{"unknown-function"}, Editors: tt.editors})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%v:\ngot  %q\nwant %q", tt.editors, got, tt.want)
+		}
+	}
+}
+
+func TestRun_pragma(t *testing.T) {
+	src := `" vimlint: ignore
+function! F()
+endfunction
+" vimlint: ignore ambiguous-comparison
+function! G()
+  let x = 1 == 2 " vimlint: ignore
+endfunction
+let x = 1 == 2 " vimlint: ignore missing-abort
+`
+	got := lint(t, src, nil)
+	want := []string{
+		"5:1-5:12: warning: function without abort attribute (missing-abort)",
+		"8:11-8:13: warning: == depends on 'ignorecase'; use ==# or ==? (ambiguous-comparison)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	src := "function! F()\n  echo 1 == 2\nendfunction\n"
+	got := lint(t, src, &Config{Disable: []string{"missing-abort"}})
+	if len(got) != 1 || !strings.HasSuffix(got[0], "(ambiguous-comparison)") {
+		t.Errorf("Disable: got %q", got)
+	}
+	if _, err := Run(nil, &Config{Enable: []string{"no-such-rule"}}); err == nil {
+		t.Error("unknown rule: want error")
+	}
+	var ids []string
+	for _, r := range Rules() {
+		ids = append(ids, r.ID)
+	}
+	want := []string{
+		"ambiguous-comparison",
+		"argument-count",
+		"autocmd-outside-augroup",
+		"autoload-function-name",
+		"deprecated-option",
+		"missing-abort",
+		"type-mismatch",
+		"undefined-autoload-function",
+		"undefined-local-variable",
+		"unknown-function",
+		"unknown-option",
+	}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Rules() = %q, want %q", ids, want)
+	}
+}
@@ -0,0 +1,402 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/loader"
+	"github.com/vim-jp/go-vimlparser/scope"
+	"github.com/vim-jp/go-vimlparser/token"
+	"github.com/vim-jp/go-vimlparser/types"
+)
+
+func init() {
+	Register(&Rule{
+		ID:       "missing-abort",
+		Doc:      "functions should have abort attribute to stop at the first error",
+		Severity: Warning,
+		New:      func(pass *Pass) ast.Visitor { return &missingAbort{pass} },
+	})
+	Register(&Rule{
+		ID:       "ambiguous-comparison",
+		Doc:      "comparison operators should have # or ? not to depend on 'ignorecase'",
+		Severity: Warning,
+		New:      func(pass *Pass) ast.Visitor { return &ambiguousComparison{pass} },
+	})
+	Register(&Rule{
+		ID:       "undefined-local-variable",
+		Doc:      "l: variables should be defined in the function",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &undefinedLocalVariable{pass} },
+	})
+	Register(&Rule{
+		ID:       "autocmd-outside-augroup",
+		Doc:      "autocmds should be defined in augroup not to be duplicated by reloading",
+		Severity: Warning,
+		New:      func(pass *Pass) ast.Visitor { return &autocmdOutsideAugroup{pass: pass} },
+	})
+	Register(&Rule{
+		ID:       "unknown-option",
+		Doc:      "options of :set should be builtin options of Vim or Neovim",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &unknownOption{pass} },
+	})
+	Register(&Rule{
+		ID:       "deprecated-option",
+		Doc:      "deprecated options should be replaced with the new ones",
+		Severity: Warning,
+		New:      func(pass *Pass) ast.Visitor { return &deprecatedOption{pass} },
+	})
+	Register(&Rule{
+		ID:       "undefined-autoload-function",
+		Doc:      "autoload functions should be defined in their autoload scripts",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &undefinedAutoloadFunction{pass} },
+	})
+	Register(&Rule{
+		ID:       "autoload-function-name",
+		Doc:      "functions in autoload scripts should be named after the script path",
+		Severity: Error,
+		New:      newAutoloadFunctionName,
+	})
+	Register(&Rule{
+		ID:       "type-mismatch",
+		Doc:      "operations should be on values of valid types",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &typeMismatch{pass} },
+	})
+	Register(&Rule{
+		ID:       "unknown-function",
+		Doc:      "called functions without scope should be builtin functions of the editors",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &unknownFunction{pass: pass} },
+	})
+	Register(&Rule{
+		ID:       "argument-count",
+		Doc:      "builtin functions should be called with valid number of arguments",
+		Severity: Error,
+		New:      func(pass *Pass) ast.Visitor { return &argumentCount{pass: pass} },
+	})
+}
+
+type missingAbort struct {
+	pass *Pass
+}
+
+func (v *missingAbort) Visit(n ast.Node) ast.Visitor {
+	if f, ok := n.(*ast.Function); ok && !f.Attr.Abort {
+		v.pass.Reportf(f.Pos(), f.Name.End(), "function without abort attribute")
+	}
+	return v
+}
+
+type ambiguousComparison struct {
+	pass *Pass
+}
+
+// ambiguousOps are comparison operators whose behavior depends on
+// 'ignorecase' for strings.
+var ambiguousOps = map[token.Token]bool{
+	token.EQEQ:    true,
+	token.NEQ:     true,
+	token.GT:      true,
+	token.GTEQ:    true,
+	token.LT:      true,
+	token.LTEQ:    true,
+	token.MATCH:   true,
+	token.NOMATCH: true,
+}
+
+func (v *ambiguousComparison) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.File:
+		if n.Vim9 {
+			return nil
+		}
+	case *ast.Def:
+		// Vim9 script doesn't use 'ignorecase' for comparison.
+		return nil
+	case *ast.BinaryExpr:
+		if ambiguousOps[n.Op] {
+			op := n.Op.String()
+			end := n.OpPos
+			end.Offset += len(op)
+			end.Column += len(op)
+			v.pass.Reportf(n.OpPos, end, "%s depends on 'ignorecase'; use %s# or %s?", op, op, op)
+		}
+	}
+	return v
+}
+
+type undefinedLocalVariable struct {
+	pass *Pass
+}
+
+func (v *undefinedLocalVariable) Visit(n ast.Node) ast.Visitor {
+	f, ok := n.(*ast.Function)
+	if !ok {
+		return v
+	}
+	defined := make(map[string]bool)
+	var refs []*ast.Ident
+	keys := make(map[*ast.Ident]bool) // identifiers of dictionary keys
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Function:
+			// nested functions have their own scope.
+			return n == f
+		case *ast.Let:
+			defineVars(defined, n.Left, n.List, n.Rest)
+		case *ast.For:
+			defineVars(defined, n.Left, n.List, n.Rest)
+		case *ast.DotExpr:
+			keys[n.Right] = true
+		case *ast.Ident:
+			if strings.HasPrefix(n.Name, "l:") && len(n.Name) > 2 && !keys[n] {
+				refs = append(refs, n)
+			}
+		}
+		return true
+	})
+	for _, id := range refs {
+		if !defined[localName(id.Name)] {
+			v.pass.Report(id, "undefined variable %s", id.Name)
+		}
+	}
+	return v
+}
+
+// defineVars adds local variables assigned by :let or :for to defined.
+func defineVars(defined map[string]bool, left ast.Expr, list []ast.Expr, rest ast.Expr) {
+	for _, x := range append([]ast.Expr{left, rest}, list...) {
+		if id, ok := x.(*ast.Ident); ok {
+			if name := localName(id.Name); name != "" {
+				defined[name] = true
+			}
+		}
+	}
+}
+
+// localName returns the name of local variable without "l:" or empty string
+// if name is not a local variable.
+func localName(name string) string {
+	if strings.HasPrefix(name, "l:") {
+		return name[2:]
+	}
+	if strings.Contains(name, ":") {
+		return ""
+	}
+	return name
+}
+
+type autocmdOutsideAugroup struct {
+	pass    *Pass
+	augroup bool // in augroup
+}
+
+func (v *autocmdOutsideAugroup) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.Augroup:
+		if !n.ExArg.Forceit && n.Name != "" {
+			v.augroup = !n.IsEnd
+		}
+	case *ast.Autocmd:
+		// :autocmd {group} {event} ... is fine.
+		if !v.augroup && n.Group == "" {
+			v.pass.Report(n, "autocmd outside augroup")
+		}
+	}
+	return v
+}
+
+type unknownOption struct {
+	pass *Pass
+}
+
+func (v *unknownOption) Visit(n ast.Node) ast.Visitor {
+	if s, ok := n.(*ast.Set); ok {
+		for _, o := range s.Options {
+			if o.Name != "" && o.Full == "" {
+				v.pass.Reportf(o.Pos, o.EndPos, "unknown option %s", o.Name)
+			}
+		}
+	}
+	return v
+}
+
+type deprecatedOption struct {
+	pass *Pass
+}
+
+func (v *deprecatedOption) Visit(n ast.Node) ast.Visitor {
+	if s, ok := n.(*ast.Set); ok {
+		for _, o := range s.Options {
+			if opt := builtin.LookupOption(o.Full); opt != nil && opt.Deprecated != "" {
+				v.pass.Reportf(o.Pos, o.EndPos, "%s is deprecated; use %s", opt.Name, opt.Deprecated)
+			}
+		}
+	}
+	return v
+}
+
+type undefinedAutoloadFunction struct {
+	pass *Pass
+}
+
+// Visit reports calls of autoload functions which are not defined in the
+// program. A call is reported only if the program has the autoload script
+// of the function or another script of the same plugin, e.g.
+// autoload/foo.vim for foo#bar#baz(), because the function may be defined
+// by a plugin which is not in the program.
+func (v *undefinedAutoloadFunction) Visit(n ast.Node) ast.Visitor {
+	prog := v.pass.Program
+	if prog == nil {
+		return nil
+	}
+	c, ok := n.(*ast.CallExpr)
+	if !ok {
+		return v
+	}
+	id, ok := c.Fun.(*ast.Ident)
+	if !ok {
+		return v
+	}
+	name := strings.TrimPrefix(id.Name, "g:")
+	if !strings.Contains(name, "#") || strings.Contains(name, ":") || prog.ResolveAutoload(name) != nil {
+		return v
+	}
+	path := loader.AutoloadPath(name)
+	if s := prog.AutoloadScript(name); s != nil {
+		if s.AST != nil {
+			v.pass.Report(id, "undefined function %s: not defined in %s", name, path)
+		}
+	} else if prog.HasAutoloadScripts(name[:strings.Index(name, "#")+1]) {
+		v.pass.Report(id, "undefined function %s: %s is not found", name, path)
+	}
+	return v
+}
+
+type autoloadFunctionName struct {
+	pass   *Pass
+	prefix string // autoload prefix of the file; or empty
+}
+
+func newAutoloadFunctionName(pass *Pass) ast.Visitor {
+	prefix := loader.AutoloadPrefix(pass.File.Start.Filename)
+	if pass.Program != nil {
+		if f := pass.Program.Lookup(pass.File.Start.Filename); f != nil {
+			prefix = f.AutoloadPrefix()
+		}
+	}
+	return &autoloadFunctionName{pass: pass, prefix: prefix}
+}
+
+func (v *autoloadFunctionName) Visit(n ast.Node) ast.Visitor {
+	if v.prefix == "" {
+		return nil
+	}
+	f, ok := n.(*ast.Function)
+	if !ok {
+		return v
+	}
+	id, ok := f.Name.(*ast.Ident)
+	if !ok {
+		return v
+	}
+	name := strings.TrimPrefix(id.Name, "g:")
+	if i := strings.LastIndex(name, "#"); i >= 0 && name[:i+1] != v.prefix {
+		v.pass.Report(id, "function %s should be named %s%s in this script", name, v.prefix, name[i+1:])
+	}
+	return v
+}
+
+type typeMismatch struct {
+	pass *Pass
+}
+
+func (v *typeMismatch) Visit(n ast.Node) ast.Visitor {
+	if f, ok := n.(*ast.File); ok {
+		for _, e := range types.Check(f).Errors {
+			v.pass.Reportf(e.Pos, e.End, "%s", e.Msg)
+		}
+	}
+	return nil
+}
+
+// builtinCall is a call of a function whose name looks like a builtin
+// function, i.e. it starts with lower case letter without scope and "#".
+type builtinCall struct {
+	name  *ast.Ident
+	nargs int // number of the arguments including the base of methods
+}
+
+// builtinCalls returns calls of builtin functions in f. Funcref variables
+// with such names are not builtin functions. Vim9 script and :def functions
+// are skipped since the local variables are not resolved.
+func builtinCalls(f *ast.File) []builtinCall {
+	if f.Vim9 {
+		return nil
+	}
+	info := scope.Resolve(f)
+	var calls []builtinCall
+	add := func(x ast.Expr, nargs int) {
+		id, ok := x.(*ast.Ident)
+		if !ok || id.Name == "" || id.Name[0] < 'a' || 'z' < id.Name[0] ||
+			strings.ContainsAny(id.Name, ":#") || info.Uses[id] != nil {
+			return
+		}
+		calls = append(calls, builtinCall{id, nargs})
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Def:
+			return false
+		case *ast.CallExpr:
+			add(n.Fun, len(n.Args))
+		case *ast.MethodExpr:
+			add(n.Method, len(n.Args)+1)
+		}
+		return true
+	})
+	return calls
+}
+
+type unknownFunction struct {
+	pass *Pass
+}
+
+func (v *unknownFunction) Visit(n ast.Node) ast.Visitor {
+	if f, ok := n.(*ast.File); ok {
+		for _, c := range builtinCalls(f) {
+			fn := builtin.LookupFunction(c.name.Name)
+			switch {
+			case fn == nil:
+				v.pass.Report(c.name, "E117: Unknown function: %s", c.name.Name)
+			case fn.Editors&v.pass.Editors == 0:
+				v.pass.Report(c.name, "%s() is not available in %s", fn.Name, v.pass.Editors)
+			}
+		}
+	}
+	return nil
+}
+
+type argumentCount struct {
+	pass *Pass
+}
+
+func (v *argumentCount) Visit(n ast.Node) ast.Visitor {
+	if f, ok := n.(*ast.File); ok {
+		for _, c := range builtinCalls(f) {
+			fn := builtin.LookupFunction(c.name.Name)
+			switch {
+			case fn == nil:
+			case c.nargs < fn.MinArgs:
+				v.pass.Report(c.name, "E119: Not enough arguments for function: %s", fn.Name)
+			case fn.MaxArgs >= 0 && c.nargs > fn.MaxArgs:
+				v.pass.Report(c.name, "E118: Too many arguments for function: %s", fn.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,196 @@
+package loader
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// Definition is a definition of a function.
+type Definition struct {
+	File *File
+	Stmt ast.Statement // *ast.Function or *ast.Def
+	Name ast.Expr      // name of the function
+}
+
+// index is files, definitions of autoload functions and autoload scripts of
+// a program.
+type index struct {
+	files   map[string]*File         // by cleaned path
+	defs    map[string][]*Definition // by function name without "g:"
+	scripts map[string][]*File       // by AutoloadPrefix
+}
+
+// AutoloadPath returns the path of the script which defines the autoload
+// function or variable name relative to a runtime directory, e.g.
+// "autoload/foo/bar.vim" for foo#bar#baz. It returns empty string if name is
+// not an autoload name. The path is slash-separated.
+func AutoloadPath(name string) string {
+	name = strings.TrimPrefix(name, "g:")
+	i := strings.LastIndex(name, "#")
+	if i <= 0 {
+		return ""
+	}
+	return "autoload/" + strings.Replace(name[:i], "#", "/", -1) + ".vim"
+}
+
+// AutoloadPrefix returns the prefix of autoload names defined by the script
+// of path, e.g. "foo#bar#" for ~/.vim/autoload/foo/bar.vim. It returns empty
+// string if path is not in an autoload directory.
+func AutoloadPrefix(path string) string {
+	parts := strings.Split(filepath.ToSlash(filepath.Clean(path)), "/")
+	for i := len(parts) - 2; i >= 0; i-- {
+		if parts[i] == "autoload" {
+			return namesPrefix(parts[i+1:])
+		}
+	}
+	return ""
+}
+
+// AutoloadPrefix returns the prefix of autoload names defined by f like
+// AutoloadPrefix. If Root is set, only the autoload directory in Root is
+// an autoload directory, e.g. "autoload/bar.vim" for Root ~/autoload/foo
+// isn't.
+func (f *File) AutoloadPrefix() string {
+	if f.Root == "" {
+		return AutoloadPrefix(f.Path)
+	}
+	rel, err := filepath.Rel(f.Root, f.Path)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < 2 || parts[0] != "autoload" {
+		return ""
+	}
+	return namesPrefix(parts[1:])
+}
+
+// namesPrefix returns the prefix of autoload names for the path in an
+// autoload directory, e.g. "foo#bar#" for ["foo", "bar.vim"].
+func namesPrefix(names []string) string {
+	last := names[len(names)-1]
+	if !strings.HasSuffix(last, ".vim") || last == ".vim" {
+		return ""
+	}
+	names[len(names)-1] = strings.TrimSuffix(last, ".vim")
+	return strings.Join(names, "#") + "#"
+}
+
+// autoloadPrefix returns the prefix of the autoload name, e.g. "foo#bar#"
+// for foo#bar#baz.
+func autoloadPrefix(name string) string {
+	return name[:strings.LastIndex(name, "#")+1]
+}
+
+// AutoloadScript returns the script which Vim sources to define the autoload
+// function name, i.e. the first file in an autoload directory whose
+// AutoloadPrefix matches name. It returns nil if the program has no such
+// file.
+func (p *Program) AutoloadScript(name string) *File {
+	name = strings.TrimPrefix(name, "g:")
+	if !strings.Contains(name, "#") {
+		return nil
+	}
+	if files := p.index().scripts[autoloadPrefix(name)]; len(files) > 0 {
+		return files[0]
+	}
+	return nil
+}
+
+// HasAutoloadScripts reports whether the program has an autoload script
+// whose names start with prefix, e.g. autoload/foo.vim and
+// autoload/foo/bar.vim for "foo#".
+func (p *Program) HasAutoloadScripts(prefix string) bool {
+	for s := range p.index().scripts {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Definitions returns the definitions of the function name in the order of
+// files. "g:" of name is ignored.
+func (p *Program) Definitions(name string) []*Definition {
+	return p.index().defs[strings.TrimPrefix(name, "g:")]
+}
+
+// ResolveAutoload returns the definition of the autoload function name which
+// a call of the function runs: the definition in AutoloadScript(name), or
+// the first definition in the other files if the script doesn't define it
+// because Vim doesn't source the script for a function which is already
+// defined. It returns nil if the function is not defined.
+func (p *Program) ResolveAutoload(name string) *Definition {
+	defs := p.Definitions(name)
+	if s := p.AutoloadScript(name); s != nil {
+		for _, d := range defs {
+			if d.File == s {
+				return d
+			}
+		}
+	}
+	for _, d := range defs {
+		if d.File.AutoloadPrefix() != autoloadPrefix(name) {
+			return d
+		}
+	}
+	return nil
+}
+
+// index returns the index of p, which is built on first use.
+func (p *Program) index() *index {
+	p.once.Do(func() {
+		p.idx = &index{
+			files:   make(map[string]*File),
+			defs:    make(map[string][]*Definition),
+			scripts: make(map[string][]*File),
+		}
+		for _, f := range p.Files {
+			p.idx.add(f)
+		}
+	})
+	return p.idx
+}
+
+func (x *index) add(f *File) {
+	path := filepath.Clean(f.Path)
+	if x.files[path] == nil {
+		x.files[path] = f
+	}
+	prefix := f.AutoloadPrefix()
+	if prefix != "" {
+		x.scripts[prefix] = append(x.scripts[prefix], f)
+	}
+	if f.AST == nil {
+		return
+	}
+	ast.Inspect(f.AST, func(n ast.Node) bool {
+		var name ast.Expr
+		var export bool
+		switch n := n.(type) {
+		case *ast.Function:
+			name = n.Name
+		case *ast.Def:
+			name = n.Name
+			export = n.Attr.Export
+		default:
+			return true
+		}
+		id, ok := name.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		s := strings.TrimPrefix(id.Name, "g:")
+		if export && f.AST.Vim9 && prefix != "" && !strings.Contains(s, "#") {
+			// "export def Baz()" in Vim9 script autoload/foo/bar.vim
+			// defines foo#bar#Baz for legacy script.
+			s = prefix + s
+		}
+		if strings.Contains(s, "#") {
+			x.defs[s] = append(x.defs[s], &Definition{File: f, Stmt: n.(ast.Statement), Name: name})
+		}
+		return true
+	})
+}
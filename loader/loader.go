@@ -0,0 +1,177 @@
+// Package loader loads Vim script files of runtime directories and parses
+// them in parallel.
+//
+// A runtime directory is a directory in 'runtimepath', e.g. ~/.vim and the
+// root directory of a plugin, which has plugin/, autoload/, ftplugin/ and
+// after/ directories.
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// DefaultDirs is directories of a runtime directory loaded by Load. after/
+// is loaded recursively as a runtime directory.
+var DefaultDirs = []string{
+	"after",
+	"autoload",
+	"colors",
+	"compiler",
+	"ftdetect",
+	"ftplugin",
+	"indent",
+	"plugin",
+	"syntax",
+}
+
+// Config is configuration of Load and LoadFiles.
+type Config struct {
+	ParseOption *vimlparser.ParseOption // option of vimlparser.ParseFile; or nil
+	Dirs        []string                // directories to load; DefaultDirs if nil
+
+	// Jobs is the maximum number of files parsed at once. It's
+	// runtime.GOMAXPROCS(0) if Jobs <= 0.
+	Jobs int
+}
+
+// Program is parsed files.
+type Program struct {
+	Files []*File
+
+	once sync.Once
+	idx  *index
+}
+
+// File is a parsed file.
+type File struct {
+	Path string    // path of the file
+	Root string    // runtime directory of the file; or empty for LoadFiles
+	AST  *ast.File // parsed file; or nil. It's partial if Err != nil in recover mode.
+	Err  error     // error of reading or parsing the file; or nil
+}
+
+// Lookup returns the file of path; or nil.
+func (p *Program) Lookup(path string) *File {
+	return p.index().files[filepath.Clean(path)]
+}
+
+// Errors returns errors of all files in order. Each error of
+// vimlparser.ErrorList in recover mode is an element.
+func (p *Program) Errors() []error {
+	var errs []error
+	for _, f := range p.Files {
+		switch err := f.Err.(type) {
+		case nil:
+		case vimlparser.ErrorList:
+			for _, e := range err {
+				errs = append(errs, e)
+			}
+		default:
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Load parses .vim files in the directories of runtime directories roots.
+// Files are sorted by path in each runtime directory. It returns an error if
+// a runtime directory can't be read.
+func Load(roots []string, cfg *Config) (*Program, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	dirs := cfg.Dirs
+	if dirs == nil {
+		dirs = DefaultDirs
+	}
+	var files []*File
+	for _, root := range roots {
+		if _, err := os.Stat(root); err != nil {
+			return nil, err
+		}
+		var paths []string
+		for _, dir := range dirs {
+			err := filepath.Walk(filepath.Join(root, dir), func(path string, f os.FileInfo, err error) error {
+				if os.IsNotExist(err) {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				if IsVimFile(f) {
+					paths = append(paths, path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			files = append(files, &File{Path: path, Root: root})
+		}
+	}
+	parseFiles(files, cfg)
+	return &Program{Files: files}, nil
+}
+
+// LoadFiles parses files of paths. Files are in the order of paths.
+func LoadFiles(paths []string, cfg *Config) *Program {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	files := make([]*File, len(paths))
+	for i, path := range paths {
+		files[i] = &File{Path: path}
+	}
+	parseFiles(files, cfg)
+	return &Program{Files: files}
+}
+
+// IsVimFile reports whether f is a Vim script file, i.e. a .vim file which
+// is not hidden.
+func IsVimFile(f os.FileInfo) bool {
+	name := f.Name()
+	return !f.IsDir() && !strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".vim")
+}
+
+// parseFiles parses files with cfg.Jobs goroutines.
+func parseFiles(files []*File, cfg *Config) {
+	jobs := cfg.Jobs
+	if jobs <= 0 {
+		jobs = runtime.GOMAXPROCS(0)
+	}
+	ch := make(chan *File)
+	var wg sync.WaitGroup
+	for i := 0; i < jobs && i < len(files); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range ch {
+				f.AST, f.Err = parseFile(f.Path, cfg.ParseOption)
+			}
+		}()
+	}
+	for _, f := range files {
+		ch <- f
+	}
+	close(ch)
+	wg.Wait()
+}
+
+func parseFile(path string, opt *vimlparser.ParseOption) (*ast.File, error) {
+	r, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return vimlparser.ParseFile(r, path, opt)
+}
@@ -0,0 +1,179 @@
+package loader
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "loader")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, src := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"plugin/foo.vim":           "command! Foo call foo#bar#baz()\n",
+		"autoload/foo/bar.vim":     "function! foo#bar#baz() abort\nendfunction\n",
+		"autoload/foo.vim":         "echo (\n",
+		"ftplugin/vim.vim":         "setlocal ts=2\n",
+		"after/ftplugin/vim.vim":   "setlocal sw=2\n",
+		"after/plugin/.hidden.vim": "echo 1\n",
+		"doc/foo.txt":              "*foo.txt*\n",
+		"test/foo.vim":             "echo 1\n",
+	})
+	defer os.RemoveAll(dir)
+
+	prog, err := Load([]string{dir}, &Config{Jobs: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range prog.Files {
+		rel, _ := filepath.Rel(dir, f.Path)
+		got = append(got, filepath.ToSlash(rel))
+		if f.Root != dir {
+			t.Errorf("%s: Root = %q, want %q", rel, f.Root, dir)
+		}
+	}
+	want := []string{
+		"after/ftplugin/vim.vim",
+		"autoload/foo.vim",
+		"autoload/foo/bar.vim",
+		"ftplugin/vim.vim",
+		"plugin/foo.vim",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("files = %q, want %q", got, want)
+	}
+	if f := prog.Lookup(filepath.Join(dir, "autoload/foo/bar.vim")); f == nil || f.AST == nil || f.Err != nil {
+		t.Errorf("Lookup() = %#v", f)
+	}
+	if f := prog.Lookup(dir + "/autoload/foo/../foo/bar.vim"); f == nil || filepath.Clean(f.Path) != filepath.Join(dir, "autoload/foo/bar.vim") {
+		t.Errorf("Lookup() = %#v, want autoload/foo/bar.vim", f)
+	}
+	if f := prog.Lookup(filepath.Join(dir, "autoload/foo/baz.vim")); f != nil {
+		t.Errorf("Lookup() = %#v, want nil", f)
+	}
+	if errs := prog.Errors(); len(errs) != 1 {
+		t.Errorf("Errors() = %v", errs)
+	}
+
+	if _, err := Load([]string{filepath.Join(dir, "nonexistent")}, nil); err == nil {
+		t.Error("Load(nonexistent) succeeded")
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	files := map[string]string{}
+	var paths []string
+	for i := 0; i < 50; i++ {
+		name := string(rune('a'+i%26)) + string(rune('a'+i/26)) + ".vim"
+		files[name] = "let x = [\n"
+		paths = append(paths, name)
+	}
+	dir := writeFiles(t, files)
+	defer os.RemoveAll(dir)
+	for i := range paths {
+		paths[i] = filepath.Join(dir, paths[i])
+	}
+	paths = append(paths, filepath.Join(dir, "nonexistent.vim"))
+
+	prog := LoadFiles(paths, &Config{ParseOption: &vimlparser.ParseOption{Recover: true}})
+	for i, f := range prog.Files {
+		if f.Path != paths[i] {
+			t.Fatalf("Files[%d] = %s, want %s", i, f.Path, paths[i])
+		}
+	}
+	for _, f := range prog.Files[:50] {
+		if _, ok := f.Err.(vimlparser.ErrorList); !ok || f.AST == nil {
+			t.Errorf("%s: AST = %v, Err = %v", f.Path, f.AST, f.Err)
+		}
+	}
+	if f := prog.Files[50]; !os.IsNotExist(f.Err) {
+		t.Errorf("%s: Err = %v", f.Path, f.Err)
+	}
+}
+
+func TestAutoload(t *testing.T) {
+	for _, tt := range []struct{ name, path string }{
+		{"foo#bar#baz", "autoload/foo/bar.vim"},
+		{"g:foo#bar", "autoload/foo.vim"},
+		{"Foo", ""},
+		{"#foo", ""},
+	} {
+		if got := AutoloadPath(tt.name); got != tt.path {
+			t.Errorf("AutoloadPath(%q) = %q, want %q", tt.name, got, tt.path)
+		}
+	}
+	for _, tt := range []struct{ path, prefix string }{
+		{"/home/vim/.vim/autoload/foo/bar.vim", "foo#bar#"},
+		{"autoload/foo.vim", "foo#"},
+		{"plugin/foo.vim", ""},
+		{"autoload/foo.txt", ""},
+		{"autoload/.vim", ""},
+	} {
+		if got := AutoloadPrefix(filepath.FromSlash(tt.path)); got != tt.prefix {
+			t.Errorf("AutoloadPrefix(%q) = %q, want %q", tt.path, got, tt.prefix)
+		}
+	}
+	for _, tt := range []struct{ root, path, prefix string }{
+		{"/home/vim/.vim", "/home/vim/.vim/autoload/foo/bar.vim", "foo#bar#"},
+		{"/src/autoload/foo", "/src/autoload/foo/plugin/foo.vim", ""},
+		{"/src/autoload/foo", "/src/autoload/foo/autoload/autoload/bar.vim", "autoload#bar#"},
+		{"", "/src/autoload/foo/plugin/foo.vim", "foo#plugin#foo#"},
+	} {
+		f := &File{Path: filepath.FromSlash(tt.path), Root: filepath.FromSlash(tt.root)}
+		if got := f.AutoloadPrefix(); got != tt.prefix {
+			t.Errorf("File{%q, %q}.AutoloadPrefix() = %q, want %q", tt.path, tt.root, got, tt.prefix)
+		}
+	}
+
+	dir := writeFiles(t, map[string]string{
+		"a/autoload/foo/bar.vim": "function! foo#bar#baz() abort\nendfunction\n",
+		"b/autoload/foo/bar.vim": "function! foo#bar#baz() abort\nendfunction\nfunction! foo#bar#qux() abort\nendfunction\n",
+		"b/plugin/foo.vim":       "function! foo#bar#quux() abort\nendfunction\n",
+	})
+	defer os.RemoveAll(dir)
+	prog, err := Load([]string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script := prog.AutoloadScript("foo#bar#baz")
+	if script == nil || script.Path != filepath.Join(dir, "a/autoload/foo/bar.vim") {
+		t.Fatalf("AutoloadScript() = %v", script)
+	}
+	if d := prog.ResolveAutoload("foo#bar#baz"); d == nil || d.File != script || d.Stmt.Pos().Line != 1 {
+		t.Errorf("ResolveAutoload(foo#bar#baz) = %v", d)
+	}
+	// b/autoload/foo/bar.vim is not sourced.
+	if d := prog.ResolveAutoload("foo#bar#qux"); d != nil {
+		t.Errorf("ResolveAutoload(foo#bar#qux) = %v, want nil", d)
+	}
+	if d := prog.ResolveAutoload("g:foo#bar#quux"); d == nil || filepath.Base(d.File.Path) != "foo.vim" {
+		t.Errorf("ResolveAutoload(foo#bar#quux) = %v", d)
+	}
+	if n := len(prog.Definitions("foo#bar#baz")); n != 2 {
+		t.Errorf("Definitions(foo#bar#baz) = %d definitions, want 2", n)
+	}
+	if !prog.HasAutoloadScripts("foo#") || prog.HasAutoloadScripts("bar#") {
+		t.Error("HasAutoloadScripts() is wrong")
+	}
+}
@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// isContinuationComment reports whether c is `"\ ` comment which can be put
+// between continuation lines.
+func isContinuationComment(c *ast.Comment) bool {
+	return strings.HasPrefix(c.Text, `\ `)
+}
+
+// before reports whether p is before q. Offset is not available in line
+// continuations, so it compares line and column.
+func before(p, q ast.Pos) bool {
+	if p.Line != q.Line {
+		return p.Line < q.Line
+	}
+	return p.Column < q.Column
+}
+
+// commentBefore reports whether there is a comment to print before pos.
+func (p *printer) commentBefore(pos ast.Pos) bool {
+	return p.cindex < len(p.comments) && before(p.comments[p.cindex].Pos(), pos)
+}
+
+// continuationComments prints `"\ ` comments before pos in their own
+// continuation lines. In Vim9 script, `#` comments are printed as well.
+func (p *printer) continuationComments(pos ast.Pos) {
+	for p.commentBefore(pos) && (isContinuationComment(p.comments[p.cindex]) || p.vim9 && p.comments[p.cindex].Vim9) {
+		p.printWhite(newline)
+		p.writeIndent()
+		p.writeString(p.Config.ContinuationIndent)
+		p.comment(p.comments[p.cindex])
+		p.cindex++
+	}
+}
+
+// flushComments prints comments before pos at the beginning of a line.
+// Trailing comments are appended to the previous line and the others are
+// printed in their own lines.
+func (p *printer) flushComments(pos ast.Pos) {
+	var own []*ast.Comment
+	for ; p.commentBefore(pos); p.cindex++ {
+		c := p.comments[p.cindex]
+		if isContinuationComment(c) || len(p.output) == 0 {
+			own = append(own, c)
+			continue
+		}
+		// Drop the newline and the trailing white spaces, which Excmd.Command
+		// keeps, so that the space before the comment doesn't grow.
+		p.output = bytes.TrimRight(p.output[:len(p.output)-1], " \t")
+		p.printWhite(blank)
+		p.comment(c)
+		p.printWhite(newline)
+	}
+	for _, c := range own {
+		p.writeIndent()
+		p.comment(c)
+		p.printWhite(newline)
+	}
+}
+
+// trailingComments prints comments before pos at the end of the current
+// line. They are the comments after the first line of a heredoc, which
+// cannot be moved after the end marker.
+func (p *printer) trailingComments(pos ast.Pos) {
+	for ; p.commentBefore(pos); p.cindex++ {
+		p.printWhite(blank)
+		p.comment(p.comments[p.cindex])
+	}
+}
+
+// comment prints `"` or `#` of Vim9 script followed by the comment text.
+func (p *printer) comment(c *ast.Comment) {
+	if c.Vim9 {
+		p.token(token.SHARP)
+	} else {
+		p.token(token.DQUOTE)
+	}
+	p.writeString(c.Text)
+}
+
+// blankLine reports whether there are empty lines between the consecutive
+// statements prev and s.
+func (p *printer) blankLine(prev, s ast.Statement) bool {
+	// Lines between statements are empty lines, because comments are also
+	// statements and continuation lines cannot be empty.
+	line := s.Pos().Line
+	return line > prev.Pos().Line && p.blanklines[line-1]
+}
@@ -0,0 +1,179 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+func (p *printer) autocmd(n *ast.Autocmd) error {
+	p.command(n.ExArg)
+	if n.Group != "" {
+		p.printWhite(blank)
+		p.writeString(n.Group)
+	}
+	if len(n.Events) > 0 {
+		p.printWhite(blank)
+		p.writeString(strings.Join(n.Events, ","))
+	}
+	if len(n.Patterns) > 0 {
+		p.printWhite(blank)
+		p.writeString(strings.Join(n.Patterns, ","))
+	}
+	if n.Once {
+		p.writeString(" ++once")
+	}
+	if n.Nested && p.vim9 {
+		p.writeString(" ++nested")
+	} else if n.Nested {
+		// "nested" works in older Vim than "++nested".
+		p.writeString(" nested")
+	}
+	if len(n.Command) == 0 {
+		return nil
+	}
+	p.printWhite(blank)
+	return p.inlineStmtList(n.Command)
+}
+
+// inlineStmtList prints statements in the current line separated by "|",
+// e.g. the command of :autocmd. Block statements are printed in one line.
+func (p *printer) inlineStmtList(list []ast.Statement) error {
+	sub := &printer{Config: p.Config, vim9: p.vim9}
+	sub.Config.LineWidth = 0
+	if err := sub.stmtList(list); err != nil {
+		return err
+	}
+	lines := bytes.Split(bytes.TrimRight(sub.output, "\n"), []byte("\n"))
+	for i, line := range lines {
+		if i > 0 {
+			p.writeString(" | ")
+		}
+		p.writeString(string(bytes.TrimSpace(line)))
+	}
+	return nil
+}
+
+func (p *printer) augroup(n *ast.Augroup) {
+	p.command(n.ExArg)
+	if n.Name != "" {
+		p.printWhite(blank)
+		p.writeString(n.Name)
+	}
+}
+
+// mapping prints the keys as written. Special arguments are printed in the
+// order of the help.
+func (p *printer) mapping(n *ast.Map) {
+	p.command(n.ExArg)
+	for _, a := range []struct {
+		ok   bool
+		name string
+	}{
+		{n.Attr.Buffer, "<buffer>"},
+		{n.Attr.Nowait, "<nowait>"},
+		{n.Attr.Silent, "<silent>"},
+		{n.Attr.Special, "<special>"},
+		{n.Attr.Script, "<script>"},
+		{n.Attr.Expr, "<expr>"},
+		{n.Attr.Unique, "<unique>"},
+	} {
+		if a.ok {
+			p.printWhite(blank)
+			p.writeString(a.name)
+		}
+	}
+	if n.Lhs != "" {
+		p.printWhite(blank)
+		p.writeString(n.Lhs)
+	}
+	if n.Rhs != "" {
+		p.printWhite(blank)
+		p.writeString(n.Rhs)
+	}
+}
+
+// userCommand prints the replacement as written. Attributes are printed in
+// the order of the help.
+func (p *printer) userCommand(n *ast.UserCommand) {
+	p.command(n.ExArg)
+	a := n.Attr
+	complete := a.Complete
+	if a.CompleteFunc != "" {
+		complete += "," + a.CompleteFunc
+	}
+	for _, attr := range []struct {
+		ok   bool
+		text string
+	}{
+		{a.Nargs != "", "-nargs=" + a.Nargs},
+		{complete != "", "-complete=" + complete},
+		{a.Range == ".", "-range"},
+		{a.Range != "" && a.Range != ".", "-range=" + a.Range},
+		{a.Count == "0", "-count"},
+		{a.Count != "" && a.Count != "0", "-count=" + a.Count},
+		{a.Addr != "", "-addr=" + a.Addr},
+		{a.Bang, "-bang"},
+		{a.Bar, "-bar"},
+		{a.Register, "-register"},
+		{a.Buffer, "-buffer"},
+		{a.Keepscript, "-keepscript"},
+	} {
+		if attr.ok {
+			p.printWhite(blank)
+			p.writeString(attr.text)
+		}
+	}
+	if n.Name != "" {
+		p.printWhite(blank)
+		p.writeString(n.Name)
+	}
+	if n.Replacement != "" {
+		p.printWhite(blank)
+		p.writeString(n.Replacement)
+	}
+}
+
+func (p *printer) highlight(n *ast.Highlight) {
+	p.command(n.ExArg)
+	words := []string{}
+	if n.Clear {
+		words = append(words, "clear")
+	}
+	if n.Default {
+		words = append(words, "default")
+	}
+	if n.Link {
+		words = append(words, "link")
+	}
+	words = append(words, n.Group, n.To)
+	if n.None {
+		words = append(words, "NONE")
+	}
+	for _, a := range n.Attrs {
+		words = append(words, a.Key+"="+a.Value)
+	}
+	for _, w := range words {
+		if w != "" {
+			p.printWhite(blank)
+			p.writeString(w)
+		}
+	}
+}
+
+// syntax prints the command as written because the order of arguments
+// matters, e.g. matchgroup of :syntax region.
+func (p *printer) syntax(n *ast.Syntax) {
+	// Text contains modifiers and range.
+	p.writeString(n.Text)
+}
+
+// set prints the values as written. ":" is printed as "=".
+func (p *printer) set(n *ast.Set) {
+	p.command(n.ExArg)
+	for _, o := range n.Options {
+		p.printWhite(blank)
+		p.writeString(o.Prefix + o.Name + o.Op + o.Value)
+	}
+}
@@ -13,7 +13,8 @@ func (p *printer) expr(expr ast.Expr) {
 
 func (p *printer) expr1(expr ast.Expr, prec1 int) {
 	switch x := expr.(type) {
-	// case *ast.TernaryExpr:
+	case *ast.TernaryExpr:
+		p.ternaryExpr(x, prec1)
 	case *ast.BinaryExpr:
 		p.binaryExpr(x, prec1)
 	case *ast.UnaryExpr:
@@ -23,19 +24,97 @@ func (p *printer) expr1(expr ast.Expr, prec1 int) {
 		p.token(token.SQOPEN)
 		p.expr(x.Right)
 		p.token(token.SQCLOSE)
-	// case *ast.SliceExpr:
-	// case *ast.CallExpr:
-	// case *ast.DotExpr:
-	// case *ast.List:
-	// case *ast.Dict:
-	// case *ast.CurlyName:
-	// case *ast.CurlyNameLit:
-	// case *ast.CurlyNameExpr:
+	case *ast.SliceExpr:
+		// Always put spaces around ":" to avoid ambiguity with scoped
+		// variable. e.g. x[s:y] is subscript, not slice.
+		p.expr1(x.X, opprec(x))
+		p.token(token.SQOPEN)
+		if x.Low != nil {
+			p.expr(x.Low)
+			p.printWhite(blank)
+		}
+		p.token(token.COLON)
+		if x.High != nil {
+			p.printWhite(blank)
+			p.expr(x.High)
+		}
+		p.token(token.SQCLOSE)
+	case *ast.MethodExpr:
+		p.expr1(x.Left, opprec(x))
+		p.token(token.ARROW)
+		p.expr1(x.Method, opprec(x))
+		p.args(x.Args)
+	case *ast.CallExpr:
+		p.expr1(x.Fun, opprec(x))
+		p.args(x.Args)
+	case *ast.DotExpr:
+		p.expr1(x.Left, opprec(x))
+		p.token(token.DOT)
+		p.expr(x.Right)
+	case *ast.List:
+		if !p.hasComment(x.Values) && p.fits(func(p *printer) { p.list(x) }) {
+			p.list(x)
+			return
+		}
+		p.token(token.SQOPEN)
+		for _, v := range x.Values {
+			p.continuationComments(v.Pos())
+			p.continueLine()
+			p.expr(v)
+			p.token(token.COMMA)
+		}
+		p.continueLine()
+		p.token(token.SQCLOSE)
+	case *ast.Dict:
+		var values []ast.Expr
+		for _, e := range x.Entries {
+			values = append(values, e.Value)
+		}
+		if !p.hasComment(values) && p.fits(func(p *printer) { p.dict(x) }) {
+			p.dict(x)
+			return
+		}
+		p.token(token.COPEN)
+		for _, e := range x.Entries {
+			p.continuationComments(e.Key.Pos())
+			p.continueLine()
+			p.keyValue(e)
+			p.token(token.COMMA)
+		}
+		p.continueLine()
+		p.token(token.CCLOSE)
+	case *ast.CurlyName:
+		for _, part := range x.Parts {
+			p.expr(part)
+		}
+	case *ast.CurlyNameLit:
+		p.writeString(x.Value)
+	case *ast.CurlyNameExpr:
+		p.token(token.COPEN)
+		p.expr(x.Value)
+		p.token(token.CCLOSE)
 	case *ast.BasicLit:
 		p.writeString(x.Value)
 	case *ast.Ident:
 		p.writeString(x.Name)
-	// case *ast.LambdaExpr:
+	case *ast.Placeholder:
+		p.writeString("<" + x.Name + ">")
+	case *ast.LambdaExpr:
+		p.token(token.COPEN)
+		for i, param := range x.Params {
+			if i > 0 {
+				p.token(token.COMMA)
+				p.printWhite(blank)
+			}
+			p.expr(param)
+		}
+		if len(x.Params) > 0 {
+			p.printWhite(blank)
+		}
+		p.token(token.ARROW)
+		p.printWhite(blank)
+		p.expr(x.Expr)
+		p.token(token.CCLOSE)
 	case *ast.ParenExpr:
 		if _, hasParens := x.X.(*ast.ParenExpr); hasParens {
 			p.expr(x.X)
@@ -44,11 +123,126 @@ func (p *printer) expr1(expr ast.Expr, prec1 int) {
 			p.expr(x.X)
 			p.token(token.PCLOSE)
 		}
+	case *ast.HeredocExpr:
+		p.heredocExpr(x)
+	case *ast.Vim9LambdaExpr:
+		p.vim9Lambda(x)
+	case *ast.CastExpr:
+		p.token(token.LT)
+		p.writeString(x.Type.Name)
+		p.token(token.GT)
+		p.expr1(x.X, opprec(x))
+	case *ast.InterpolatedString:
+		p.writeString(x.Value)
+	case *ast.BadExpr:
+		p.writeString(x.Text)
 	default:
 		panic(fmt.Errorf("unsupported expr type %T", x))
 	}
 }
 
+func (p *printer) list(x *ast.List) {
+	p.token(token.SQOPEN)
+	p.exprList(x.Values)
+	p.token(token.SQCLOSE)
+}
+
+func (p *printer) dict(x *ast.Dict) {
+	p.token(token.COPEN)
+	for i, e := range x.Entries {
+		if i > 0 {
+			p.token(token.COMMA)
+			p.printWhite(blank)
+		}
+		p.keyValue(e)
+	}
+	p.token(token.CCLOSE)
+}
+
+func (p *printer) keyValue(e ast.KeyValue) {
+	if p.vim9 && !isLit(e.Key) {
+		// Vim9 script requires [] around the expression key.
+		p.token(token.SQOPEN)
+		p.expr(e.Key)
+		p.token(token.SQCLOSE)
+	} else {
+		p.expr(e.Key)
+	}
+	if !p.vim9 && !isLit(e.Key) {
+		// {x: 1} is parsed as scoped variable "x:".
+		p.printWhite(blank)
+	}
+	p.token(token.COLON)
+	p.printWhite(blank)
+	p.expr(e.Value)
+}
+
+// args prints function arguments with parentheses.
+func (p *printer) args(args []ast.Expr) {
+	flat := func(p *printer) {
+		p.token(token.POPEN)
+		p.exprList(args)
+		p.token(token.PCLOSE)
+	}
+	if !p.hasComment(args) && p.fits(flat) {
+		flat(p)
+		return
+	}
+	p.token(token.POPEN)
+	for i, a := range args {
+		if i > 0 {
+			p.token(token.COMMA)
+		}
+		p.continuationComments(a.Pos())
+		p.continueLine()
+		p.expr(a)
+	}
+	p.token(token.PCLOSE)
+}
+
+// hasComment reports whether there are comments to print before the last
+// element of list. Such comments are in line continuations of list, so list
+// must be split into lines.
+func (p *printer) hasComment(list []ast.Expr) bool {
+	return len(list) > 0 && p.commentBefore(list[len(list)-1].Pos())
+}
+
+// isLit reports whether x is a string or number literal.
+func isLit(x ast.Expr) bool {
+	lit, ok := x.(*ast.BasicLit)
+	return ok && (lit.Kind == token.STRING || lit.Kind == token.NUMBER)
+}
+
+func (p *printer) exprList(list []ast.Expr) {
+	for i, x := range list {
+		if i > 0 {
+			p.token(token.COMMA)
+			p.printWhite(blank)
+		}
+		p.expr(x)
+	}
+}
+
+func (p *printer) ternaryExpr(x *ast.TernaryExpr, prec1 int) {
+	prec := opprec(x)
+	if prec < prec1 {
+		// parenthesis needed
+		p.token(token.POPEN)
+		p.expr(x)
+		p.token(token.PCLOSE)
+		return
+	}
+	p.expr1(x.Condition, prec+1)
+	p.printWhite(blank)
+	p.token(token.QUESTION)
+	p.printWhite(blank)
+	p.expr1(x.Left, prec)
+	p.printWhite(blank)
+	p.token(token.COLON)
+	p.printWhite(blank)
+	p.expr1(x.Right, prec)
+}
+
 func (p *printer) binaryExpr(x *ast.BinaryExpr, prec1 int) {
 	prec := opprec(x)
 	if prec < prec1 {
@@ -62,12 +256,27 @@ func (p *printer) binaryExpr(x *ast.BinaryExpr, prec1 int) {
 	}
 	// TODO(haya14busa): handle line break.
 	p.expr1(x.Left, prec)
-	p.printWhite(blank)
+	// Vim9 script requires white spaces around operators.
+	compact := p.Config.CompactOperators && !p.vim9 && x.Op != token.DOT && !isWordOp(x.Op)
+	if !compact {
+		p.printWhite(blank)
+	}
 	p.token(x.Op)
-	p.printWhite(blank)
+	if !compact {
+		p.printWhite(blank)
+	}
 	p.expr1(x.Right, prec+1)
 }
 
+// isWordOp reports whether op is "is" or "isnot" operator.
+func isWordOp(op token.Token) bool {
+	switch op {
+	case token.IS, token.ISCI, token.ISCS, token.ISNOT, token.ISNOTCI, token.ISNOTCS:
+		return true
+	}
+	return false
+}
+
 func (p *printer) unaryExpr(x *ast.UnaryExpr, prec1 int) {
 	prec := opprec(x)
 	if prec < prec1 {
@@ -83,3 +292,24 @@ func (p *printer) unaryExpr(x *ast.UnaryExpr, prec1 int) {
 	p.token(x.Op)
 	p.expr1(x.X, prec)
 }
+
+// heredocExpr prints heredoc after "=<<". Body lines and the end marker are
+// printed as is without indentation.
+func (p *printer) heredocExpr(x *ast.HeredocExpr) {
+	for _, f := range x.Flags {
+		p.expr(f)
+		p.printWhite(blank)
+	}
+	p.writeString(x.EndMarker)
+	next := x.End()
+	if len(x.Body) > 0 {
+		next = x.Body[0].Pos()
+	}
+	p.trailingComments(next)
+	for _, line := range x.Body {
+		p.printWhite(newline)
+		p.expr(line)
+	}
+	p.printWhite(newline)
+	p.writeString(x.EndMarker)
+}
@@ -30,6 +30,16 @@ func TestFprint_expr(t *testing.T) {
 		{in: `(((x+(1))))`, want: `(x + (1))`},          // ParenExpr
 		{in: `x+1==14 ||-1`, want: `x + 1 == 14 || -1`}, // BinaryExpr
 		{in: `x[ y ]`, want: `x[y]`},                    // SubscriptExpr
+		{in: `x[1:-1]`, want: `x[1 : -1]`},              // SliceExpr
+		{in: `F( 1,2 )`, want: `F(1, 2)`},               // CallExpr
+		{in: `x->F( 1 )`, want: `x->F(1)`},              // MethodExpr
+		{in: `x.y.z`, want: `x.y.z`},                    // DotExpr
+		{in: `a?1:c?2:3`, want: `a ? 1 : c ? 2 : 3`},    // TernaryExpr
+		{in: `[1,[2]]`, want: `[1, [2]]`},               // List
+		{in: `{'a':{}}`, want: `{'a': {}}`},             // Dict
+		{in: `a{b}c{1+2}`, want: `a{b}c{1 + 2}`},        // CurlyName
+		{in: `{x->x*2}`, want: `{x -> x * 2}`},          // LambdaExpr
+		{in: `x=~'a'`, want: `x =~ 'a'`},                // BinaryExpr
 	}
 
 	for _, tt := range tests {
@@ -10,10 +10,12 @@ import (
 // opprec returns operator precedence. See also go/gocompiler.vim
 func opprec(op ast.Expr) int {
 	switch n := op.(type) {
-	case *ast.TernaryExpr, *ast.ParenExpr:
+	case *ast.TernaryExpr, *ast.ParenExpr, *ast.Vim9LambdaExpr:
 		return 1
 	case *ast.BinaryExpr:
 		switch n.Op {
+		case token.FALSY:
+			return 1
 		case token.OROR:
 			return 2
 		case token.ANDAND:
@@ -36,6 +38,8 @@ func opprec(op ast.Expr) int {
 			token.LTEQ,
 			token.LTEQCI,
 			token.LTEQCS,
+			token.MATCH,
+			token.MATCHCI,
 			token.MATCHCS,
 			token.NOMATCH,
 			token.NOMATCHCI,
@@ -47,7 +51,7 @@ func opprec(op ast.Expr) int {
 			token.ISNOTCI,
 			token.ISNOTCS:
 			return 4
-		case token.PLUS, token.MINUS, token.DOT:
+		case token.PLUS, token.MINUS, token.DOT, token.DOTDOT:
 			return 5
 		case token.STAR, token.SLASH, token.PERCENT:
 			return 6
@@ -61,11 +65,14 @@ func opprec(op ast.Expr) int {
 		default:
 			panic(fmt.Errorf("unexpected token of UnaryExpr: %v", n.Op))
 		}
+	case *ast.CastExpr:
+		return 7
 	case *ast.SubscriptExpr, *ast.SliceExpr, *ast.CallExpr, *ast.DotExpr, *ast.MethodExpr:
 		return 8
-	case *ast.BasicLit, *ast.Ident, *ast.List, *ast.Dict, *ast.CurlyName, *ast.HeredocExpr:
+	case *ast.BasicLit, *ast.Ident, *ast.List, *ast.Dict, *ast.CurlyName, *ast.HeredocExpr,
+		*ast.LambdaExpr, *ast.InterpolatedString, *ast.Placeholder, *ast.BadExpr:
 		return 9
-	case *ast.CurlyNameExpr, *ast.CurlyNameLit, *ast.LambdaExpr:
+	case *ast.CurlyNameExpr, *ast.CurlyNameLit:
 		panic(fmt.Errorf("precedence is undefined for expr: %T", n))
 	default:
 		panic(fmt.Errorf("unexpected expr: %T", n))
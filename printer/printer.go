@@ -1,14 +1,14 @@
 // Package printer implements printing of AST nodes.
 //
 // ref: go/printer
-//
-// This is WIP package. DO NOT USE.
 package printer
 
 import (
-	"errors"
+	"bytes"
 	"fmt"
 	"io"
+	"math"
+	"strings"
 
 	"github.com/vim-jp/go-vimlparser/ast"
 	"github.com/vim-jp/go-vimlparser/token"
@@ -17,11 +17,33 @@ import (
 type whiteSpace byte
 
 const (
-	blank = whiteSpace(' ')
+	blank   = whiteSpace(' ')
+	newline = whiteSpace('\n')
 )
 
+// DefaultIndent is the indentation used when Config.Indent is empty.
+const DefaultIndent = "  "
+
 // A Config node controls the output of Fprint.
-type Config struct{}
+type Config struct {
+	Indent string // string used for one level of indentation; default: DefaultIndent
+
+	// CompactOperators prints binary operators without surrounding blanks.
+	// Blanks around "." and "is"/"isnot" operators are kept because they
+	// are needed to parse the code in the same way.
+	CompactOperators bool
+
+	// LineWidth is the maximum width of lines. Lists, dictionaries and
+	// function arguments which don't fit in the line are split into
+	// continuation lines. Zero means no limit.
+	LineWidth int
+
+	// ContinuationIndent is the string inserted before "\" of continuation
+	// lines in addition to the indentation of the statement.
+	// default: three times Indent, which is the default of Vim's indent
+	// script (g:vim_indent_cont).
+	ContinuationIndent string
+}
 
 // Fprint "pretty-prints" an AST node to output for a given configuration cfg.
 func Fprint(output io.Writer, node ast.Node, cfg *Config) error {
@@ -41,12 +63,25 @@ type printer struct {
 
 	// Current state
 	output []byte // raw printer result
+	indent int    // current indentation
+	vim9   bool   // printing Vim9 script
+
+	// Comments and blank lines which are not part of the tree (ast.File)
+	comments   []*ast.Comment // comments in source order
+	cindex     int            // index of the next comment to print
+	blanklines map[int]bool   // line numbers of empty lines
 }
 
 func (p *printer) init(cfg *Config) {
 	if cfg != nil {
 		p.Config = *cfg
 	}
+	if p.Config.Indent == "" {
+		p.Config.Indent = DefaultIndent
+	}
+	if p.Config.ContinuationIndent == "" {
+		p.Config.ContinuationIndent = strings.Repeat(p.Config.Indent, 3)
+	}
 }
 
 func (p *printer) writeString(s string) {
@@ -61,6 +96,40 @@ func (p *printer) printWhite(x whiteSpace) {
 	p.output = append(p.output, byte(x))
 }
 
+// writeIndent writes indentation for the current level.
+func (p *printer) writeIndent() {
+	p.writeString(strings.Repeat(p.Config.Indent, p.indent))
+}
+
+// continueLine breaks the line and starts a continuation line.
+func (p *printer) continueLine() {
+	p.printWhite(newline)
+	p.writeIndent()
+	p.writeString(p.Config.ContinuationIndent)
+	if !p.vim9 {
+		// Vim9 script doesn't need backslash in brackets.
+		p.writeString(`\ `)
+	}
+}
+
+// column returns the width of the current line.
+func (p *printer) column() int {
+	return len(p.output) - (bytes.LastIndexByte(p.output, '\n') + 1)
+}
+
+// fits reports whether f prints text which fits in the current line.
+// It doesn't write anything to the output.
+func (p *printer) fits(f func(p *printer)) bool {
+	if p.Config.LineWidth <= 0 {
+		return true
+	}
+	sub := &printer{Config: p.Config, vim9: p.vim9}
+	sub.Config.LineWidth = 0
+	f(sub)
+	return bytes.IndexByte(sub.output, '\n') == -1 &&
+		p.column()+len(sub.output) <= p.Config.LineWidth
+}
+
 func (p *printer) printNode(node ast.Node) error {
 	switch n := node.(type) {
 	case *ast.File:
@@ -76,9 +145,17 @@ func (p *printer) printNode(node ast.Node) error {
 }
 
 func (p *printer) file(f *ast.File) error {
-	return errors.New("Not implemented: printer.file")
-}
-
-func (p *printer) stmt(node ast.Statement) error {
-	return errors.New("Not implemented: printer.stmt")
+	for _, g := range f.Comments {
+		p.comments = append(p.comments, g.List...)
+	}
+	p.vim9 = f.Vim9
+	p.blanklines = make(map[int]bool, len(f.BlankLines))
+	for _, l := range f.BlankLines {
+		p.blanklines[l] = true
+	}
+	if err := p.stmtList(f.Body); err != nil {
+		return err
+	}
+	p.flushComments(ast.Pos{Line: math.MaxInt32})
+	return nil
 }
@@ -2,21 +2,432 @@ package printer
 
 import (
 	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/compiler"
 )
 
 func TestFprint_file(t *testing.T) {
-	src := `let _ = 1`
-	r := strings.NewReader(src)
-	node, err := vimlparser.ParseFile(r, "", nil)
+	tests := []struct {
+		in   string
+		want string
+		cfg  *Config
+	}{
+		{in: `let _ = 1`, want: "let _ = 1\n"},
+		{in: `let [a,b;c]=[1,2,3]`, want: "let [a, b; c] = [1, 2, 3]\n"},
+		{in: `const x={'a':1,1:2,x :3}`, want: "const x = {'a': 1, 1: 2, x : 3}\n"},
+		{in: "let x =<< trim END\n  a\n  b\nEND", want: "let x =<< trim END\n  a\n  b\nEND\n"},
+		{in: `silent! call F(x,y)`, want: "silent! call F(x, y)\n"},
+		{in: `echo  x[1:] x[:2] x[y : z] x->F(1)`, want: "echo x[1 :] x[: 2] x[y : z] x->F(1)\n"},
+		{in: `echo {a,b->a+b} {->1} c?1:0`, want: "echo {a, b -> a + b} {-> 1} c ? 1 : 0\n"},
+		{in: `unlet! a b | lockvar 2 c`, want: "unlet! a b\nlockvar 2 c\n"},
+		{in: `  nnoremap <silent> x :<C-u>call F()<CR>`, want: "nnoremap <silent> x :<C-u>call F()<CR>\n"},
+		{in: `nn <buffer><silent>  x  <Cmd>echo 1<CR>`, want: "nnoremap <buffer> <silent> x <Cmd>echo 1<CR>\n"},
+		{in: `com! -bang -nargs=1 -complete=custom,F  Foo  call F(<q-args>)`, want: "command! -nargs=1 -complete=custom,F -bang Foo call F(<q-args>)\n"},
+		{in: `hi def  link  Foo Bar | hi  Baz guifg=#ffffff  gui=bold`, want: "highlight default link Foo Bar\nhighlight Baz guifg=#ffffff gui=bold\n"},
+		{in: `setl  ts:4  nowrap   path+=**`, want: "setlocal ts=4 nowrap path+=**\n"},
+		{in: "aug vimrc\nau! BufRead *.{c,h} nested if 1|echo 1|endif\naug END", want: "augroup vimrc\nautocmd! BufRead *.{c,h} nested if 1 | echo 1 | endif\naugroup END\n"},
+		{
+			in: `function! s:F(a, b = 1, ...) abort dict
+if a:a
+return 1
+elseif a:b
+" comment
+else
+while 1 | break | endwhile
+endif
+for [x, y] in items({})
+echo x y
+endfor
+try
+throw 'x'
+catch /^x/
+finally
+endtry
+endfunction`,
+			want: `function! s:F(a, b = 1, ...) abort dict
+  if a:a
+    return 1
+  elseif a:b
+    " comment
+  else
+    while 1
+      break
+    endwhile
+  endif
+  for [x, y] in items({})
+    echo x y
+  endfor
+  try
+    throw 'x'
+  catch /^x/
+  finally
+  endtry
+endfunction
+`,
+		},
+		{
+			in:   "if 1\necho 1\nendif",
+			want: "if 1\n\techo 1\nendif\n",
+			cfg:  &Config{Indent: "\t"},
+		},
+	}
+
+	for _, tt := range tests {
+		node, err := vimlparser.ParseFile(strings.NewReader(tt.in), "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := Fprint(buf, node, tt.cfg); err != nil {
+			t.Errorf("Fprint(%q) returns unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("Fprint(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+// TestFprint_file_roundtrip checks that printed code is parsed into the same
+// AST as the original one.
+func TestFprint_file_roundtrip(t *testing.T) {
+	match, err := filepath.Glob("../test/test_*.vim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	match = append(match, "../autoload/vimlparser.vim")
+	match = append(match, "../go/gocompiler.vim")
+	for _, filename := range match {
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opt := &vimlparser.ParseOption{Neovim: strings.Contains(filename, "test_neo")}
+		f, err := vimlparser.ParseFile(bytes.NewReader(src), "", opt)
+		if err != nil {
+			// test for errors.
+			continue
+		}
+		// Note: compiler may modify the given node, so compile a new node.
+		want := new(bytes.Buffer)
+		f0, _ := vimlparser.ParseFile(bytes.NewReader(src), "", opt)
+		if err := compiler.Compile(want, f0); err != nil {
+			t.Fatal(err)
+		}
+		for _, cfg := range roundtripConfigs {
+			buf := new(bytes.Buffer)
+			if err := Fprint(buf, f, cfg); err != nil {
+				t.Errorf("%s: %v", filename, err)
+				continue
+			}
+			f2, err := vimlparser.ParseFile(bytes.NewReader(buf.Bytes()), "", opt)
+			if err != nil {
+				t.Errorf("%s: printed code cannot be parsed with %+v: %v", filename, cfg, err)
+				continue
+			}
+			got := new(bytes.Buffer)
+			if err := compiler.Compile(got, f2); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("%s: printed code is parsed differently with %+v", filename, cfg)
+			}
+		}
+	}
+}
+
+// corpusFiles returns the files for tests of printing real code: the tests of
+// the parser, the parser itself and, if VIMLPARSER_CORPUS is set, .vim files
+// in that directory, e.g. $VIMRUNTIME.
+func corpusFiles(t *testing.T) []string {
+	t.Helper()
+	match, err := filepath.Glob("../test/test_*.vim")
+	if err != nil {
+		t.Fatal(err)
+	}
+	match = append(match, "../autoload/vimlparser.vim", "../go/gocompiler.vim")
+	if dir := os.Getenv("VIMLPARSER_CORPUS"); dir != "" {
+		filepath.Walk(dir, func(path string, f os.FileInfo, err error) error {
+			if err == nil && !f.IsDir() && strings.HasSuffix(path, ".vim") {
+				match = append(match, path)
+			}
+			return nil
+		})
+	}
+	return match
+}
+
+// TestFprint_file_idempotent tests that printed code is parsed and printed
+// to the same code, including comments.
+func TestFprint_file_idempotent(t *testing.T) {
+	for _, filename := range corpusFiles(t) {
+		src, err := ioutil.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opt := &vimlparser.ParseOption{Neovim: strings.Contains(filename, "test_neo")}
+		f, err := vimlparser.ParseFile(bytes.NewReader(src), "", opt)
+		if err != nil {
+			// test for errors, or code which the parser doesn't support.
+			continue
+		}
+		first := new(bytes.Buffer)
+		if err := Fprint(first, f, nil); err != nil {
+			t.Errorf("%s: %v", filename, err)
+			continue
+		}
+		f2, err := vimlparser.ParseFile(bytes.NewReader(first.Bytes()), "", opt)
+		if err != nil {
+			t.Errorf("%s: printed code cannot be parsed: %v", filename, err)
+			continue
+		}
+		second := new(bytes.Buffer)
+		if err := Fprint(second, f2, nil); err != nil {
+			t.Errorf("%s: %v", filename, err)
+			continue
+		}
+		if got, want := second.String(), first.String(); got != want {
+			lines1, lines2 := strings.Split(want, "\n"), strings.Split(got, "\n")
+			for i := range lines1 {
+				if i >= len(lines2) || lines1[i] != lines2[i] {
+					t.Errorf("%s:%d: printed code changes when printed again:\n%s", filename, i+1, lines1[i])
+					break
+				}
+			}
+		}
+	}
+}
+
+// TestFprint_recover tests that broken code parsed in error-recovering mode is
+// printed as is.
+func TestFprint_recover(t *testing.T) {
+	src := `for in x
+echo 1
+endfor
+for 1 in [1]
+endfor
+if 1 +
+endif
+hoge  fuga
+`
+	want := `for in x
+  echo 1
+endfor
+for 1 in [1]
+endfor
+if 1 +
+endif
+hoge  fuga
+`
+	node, err := vimlparser.ParseFile(strings.NewReader(src), "", &vimlparser.ParseOption{Recover: true})
+	if err == nil {
+		t.Fatal("ParseFile() returns no errors")
+	}
+	buf := new(bytes.Buffer)
+	if err := Fprint(buf, node, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+var roundtripConfigs = []*Config{
+	nil,
+	{CompactOperators: true},
+	{LineWidth: 20},
+}
+
+func TestFprint_config(t *testing.T) {
+	src := `let x = [1, {'key': 'value', 'k2': F(aaa, bbb)}, a isnot b, x . y, x + 1]`
+	tests := []struct {
+		cfg  *Config
+		want string
+	}{
+		{
+			cfg:  &Config{CompactOperators: true},
+			want: "let x = [1, {'key': 'value', 'k2': F(aaa, bbb)}, a isnot b, x . y, x+1]\n",
+		},
+		{
+			cfg: &Config{LineWidth: 50},
+			want: `let x = [
+      \ 1,
+      \ {'key': 'value', 'k2': F(aaa, bbb)},
+      \ a isnot b,
+      \ x . y,
+      \ x + 1,
+      \ ]
+`,
+		},
+		{
+			cfg: &Config{LineWidth: 30, ContinuationIndent: "  "},
+			want: `let x = [
+  \ 1,
+  \ {
+  \ 'key': 'value',
+  \ 'k2': F(aaa, bbb),
+  \ },
+  \ a isnot b,
+  \ x . y,
+  \ x + 1,
+  \ ]
+`,
+		},
+	}
+	for _, tt := range tests {
+		node, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := new(bytes.Buffer)
+		if err := Fprint(buf, node, tt.cfg); err != nil {
+			t.Fatal(err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("Fprint(%+v) = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestFprint_comments(t *testing.T) {
+	src := `" header
+
+
+let x = 1   " trailing
+let y = [
+      \ 1,
+      "\ one
+      "\ more
+      \ 2]
+if x " cond
+echo 1 | " after bar
+call F(1,
+"\ arg
+\ 2)
+endif " end
+let h =<< trim END " {{{1
+  a
+END
+silent! foldopen!    " unfold
+`
+	want := `" header
+
+let x = 1 " trailing
+let y = [
+      \ 1,
+      "\ one
+      "\ more
+      \ 2,
+      \ ]
+if x " cond
+  echo 1
+  " after bar
+  call F(
+        \ 1,
+        "\ arg
+        \ 2)
+endif " end
+let h =<< trim END " {{{1
+  a
+END
+silent! foldopen! " unfold
+`
+	node, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 	buf := new(bytes.Buffer)
-	if err := Fprint(buf, node, nil); err == nil {
-		t.Error("want error")
+	if err := Fprint(buf, node, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFprint_vim9(t *testing.T) {
+	src := `vim9script
+# header
+import autoload 'foo.vim' as foo
+export const MAX = 10
+var d = {key: 1, [MAX]: 2}
+var l = [
+  1,
+  # one
+  2]
+export def Add(x: number, y = 1, ...rest: list<any>): number
+count += 1
+++count
+var F = (a, b): number => a + b
+var G = (v) => {
+return v * 2
+}
+if x > 0 && y ?? 0
+echo $"x = {x}" .. 'ok'
+endif
+set ts=4 # comment
+:%!xxd
+unlet g:x # comment
+return <number>foo.Get()
+enddef
+abstract class Shape extends Base implements I1, I2
+public static var total = 0
+def new(this.name)
+enddef
+endclass
+enum Color
+Red, Green
+endenum
+`
+	want := `vim9script
+# header
+import autoload 'foo.vim' as foo
+export const MAX = 10
+var d = {'key': 1, [MAX]: 2}
+var l = [
+      1,
+      # one
+      2,
+      ]
+export def Add(x: number, y = 1, ...rest: list<any>): number
+  count += 1
+  ++count
+  var F = (a, b): number => a + b
+  var G = (v) => {
+    return v * 2
+  }
+  if x > 0 && y ?? 0
+    echo $"x = {x}" .. 'ok'
+  endif
+  set ts=4 # comment
+  :%!xxd
+  unlet g:x # comment
+  return <number>foo.Get()
+enddef
+abstract class Shape extends Base implements I1, I2
+  public static var total = 0
+  def new(this.name)
+  enddef
+endclass
+enum Color
+  Red, Green
+endenum
+`
+	node, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	if err := Fprint(buf, node, &Config{CompactOperators: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
 	}
 }
@@ -0,0 +1,415 @@
+package printer
+
+import (
+	"fmt"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+func (p *printer) stmtList(list []ast.Statement) error {
+	for i, s := range list {
+		p.flushComments(s.Pos())
+		if i > 0 && p.blankLine(list[i-1], s) {
+			p.printWhite(newline)
+		}
+		if err := p.stmt(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// block prints body of a block statement with one more indentation.
+func (p *printer) block(body []ast.Statement) error {
+	p.indent++
+	defer func() { p.indent-- }()
+	return p.stmtList(body)
+}
+
+// stmt prints a statement followed by a newline.
+func (p *printer) stmt(node ast.Statement) error {
+	p.flushComments(node.Pos())
+	p.writeIndent()
+	switch n := node.(type) {
+	case *ast.Comment:
+		p.comment(n)
+	case *ast.Excmd:
+		// Command contains modifiers and range.
+		p.rangeColon(n.ExArg)
+		p.writeString(n.Command)
+	case *ast.Autocmd:
+		if err := p.autocmd(n); err != nil {
+			return err
+		}
+	case *ast.Augroup:
+		p.augroup(n)
+	case *ast.Map:
+		p.mapping(n)
+	case *ast.UserCommand:
+		p.userCommand(n)
+	case *ast.Highlight:
+		p.highlight(n)
+	case *ast.Syntax:
+		p.syntax(n)
+	case *ast.Set:
+		p.set(n)
+	case *ast.ForeignCode:
+		// The indent of the heredoc is a part of the code.
+		p.writeString(n.Text)
+	case *ast.BadStmt:
+		p.writeString(n.Text)
+	case *ast.Function:
+		return p.function(n)
+	case *ast.DelFunction:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.expr(n.Name)
+	case *ast.Return:
+		p.command(n.ExArg)
+		if n.Result != nil {
+			p.printWhite(blank)
+			p.expr(n.Result)
+		}
+	case *ast.ExCall:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.expr(n.FuncCall)
+	case *ast.Let:
+		p.let(n)
+	case *ast.UnLet:
+		p.command(n.ExArg)
+		p.spaceExprList(n.List)
+	case *ast.LockVar:
+		p.command(n.ExArg)
+		if n.Depth > 0 {
+			p.writeString(fmt.Sprintf(" %d", n.Depth))
+		}
+		p.spaceExprList(n.List)
+	case *ast.UnLockVar:
+		p.command(n.ExArg)
+		if n.Depth > 0 {
+			p.writeString(fmt.Sprintf(" %d", n.Depth))
+		}
+		p.spaceExprList(n.List)
+	case *ast.If:
+		return p.ifStmt(n)
+	case *ast.While:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.expr(n.Condition)
+		p.printWhite(newline)
+		if err := p.block(n.Body); err != nil {
+			return err
+		}
+		if n.EndWhile != nil {
+			return p.stmt(n.EndWhile)
+		}
+		return nil
+	case *ast.For:
+		return p.forStmt(n)
+	case *ast.Try:
+		return p.tryStmt(n)
+	case *ast.Throw:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.expr(n.Expr)
+	case *ast.Eval:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.expr(n.Expr)
+	case *ast.EchoCmd:
+		p.command(n.ExArg)
+		p.spaceExprList(n.Exprs)
+	case *ast.Echohl:
+		p.command(n.ExArg)
+		p.printWhite(blank)
+		p.writeString(n.Name)
+	case *ast.Execute:
+		p.command(n.ExArg)
+		p.spaceExprList(n.Exprs)
+	case *ast.EndFunction:
+		p.command(n.ExArg)
+	case *ast.EndIf:
+		p.command(n.ExArg)
+	case *ast.EndWhile:
+		p.command(n.ExArg)
+	case *ast.EndFor:
+		p.command(n.ExArg)
+	case *ast.EndTry:
+		p.command(n.ExArg)
+	case *ast.Continue:
+		p.command(n.ExArg)
+	case *ast.Break:
+		p.command(n.ExArg)
+	case *ast.Def:
+		return p.def(n)
+	case *ast.EndDef:
+		p.command(n.ExArg)
+	case *ast.VarDecl:
+		p.varDecl(n)
+	case *ast.Assign:
+		p.assign(n)
+	case *ast.ExprStmt:
+		p.command(n.ExArg)
+		p.expr(n.X)
+	case *ast.Import:
+		p.importStmt(n)
+	case *ast.Class:
+		return p.class(n)
+	case *ast.EndClass:
+		p.command(n.ExArg)
+	default:
+		return fmt.Errorf("go-vimlparser/printer: unsupported statement type %T", node)
+	}
+	p.printWhite(newline)
+	return nil
+}
+
+// command prints command modifiers, range and command name with "!" if any.
+func (p *printer) command(ea ast.ExArg) {
+	p.rangeColon(ea)
+	for _, m := range ea.Modifiers {
+		p.modifier(m)
+		p.printWhite(blank)
+	}
+	for _, r := range ea.Range {
+		p.writeString(fmt.Sprint(r))
+	}
+	if ea.Cmd != nil {
+		p.writeString(ea.Cmd.Name)
+	}
+	if ea.Forceit {
+		p.token(token.NOT)
+	}
+}
+
+// rangeColon prints ":" before the command with a range in Vim9 script,
+// e.g. ":%!cmd". The range must be preceded by ":" there.
+func (p *printer) rangeColon(ea ast.ExArg) {
+	if p.vim9 && len(ea.Range) > 0 {
+		p.token(token.COLON)
+	}
+}
+
+func (p *printer) modifier(m interface{}) {
+	mod, ok := m.(map[string]interface{})
+	if !ok {
+		return
+	}
+	name, _ := mod["name"].(string)
+	if count, ok := mod["count"].(int); ok && !(name == "verbose" && count == 1) {
+		p.writeString(fmt.Sprint(count))
+	}
+	p.writeString(name)
+	if bang, _ := mod["bang"].(int); bang == 1 {
+		p.token(token.NOT)
+	}
+}
+
+// spaceExprList prints expressions separated by a blank with a leading blank.
+func (p *printer) spaceExprList(list []ast.Expr) {
+	for _, x := range list {
+		p.printWhite(blank)
+		p.expr(x)
+	}
+}
+
+func (p *printer) function(n *ast.Function) error {
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.expr(n.Name)
+	p.token(token.POPEN)
+	params := n.Params
+	variadic := len(params) > 0 && params[len(params)-1].Name == token.DOTDOTDOT.String()
+	if variadic {
+		params = params[:len(params)-1]
+	}
+	// default arguments belong to the last parameters.
+	ndefault := len(params) - len(n.DefaultArgs)
+	for i, param := range params {
+		if i > 0 {
+			p.token(token.COMMA)
+			p.printWhite(blank)
+		}
+		p.expr(param)
+		if i >= ndefault {
+			p.printWhite(blank)
+			p.token(token.EQ)
+			p.printWhite(blank)
+			p.expr(n.DefaultArgs[i-ndefault])
+		}
+	}
+	if variadic {
+		if len(params) > 0 {
+			p.token(token.COMMA)
+			p.printWhite(blank)
+		}
+		p.token(token.DOTDOTDOT)
+	}
+	p.token(token.PCLOSE)
+	for _, attr := range []struct {
+		enabled bool
+		name    string
+	}{
+		{n.Attr.Range, "range"},
+		{n.Attr.Abort, "abort"},
+		{n.Attr.Dict, "dict"},
+		{n.Attr.Closure, "closure"},
+	} {
+		if attr.enabled {
+			p.printWhite(blank)
+			p.writeString(attr.name)
+		}
+	}
+	p.printWhite(newline)
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	if n.EndFunction != nil {
+		return p.stmt(n.EndFunction)
+	}
+	return nil
+}
+
+// lhs prints left hand side of :let and :for.
+func (p *printer) lhs(left ast.Expr, list []ast.Expr, rest ast.Expr) {
+	if left != nil {
+		p.expr(left)
+		return
+	}
+	p.token(token.SQOPEN)
+	p.exprList(list)
+	if rest != nil {
+		p.token(token.SEMICOLON)
+		p.printWhite(blank)
+		p.expr(rest)
+	}
+	p.token(token.SQCLOSE)
+}
+
+func (p *printer) let(n *ast.Let) {
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.lhs(n.Left, n.List, n.Rest)
+	p.printWhite(blank)
+	p.writeString(n.Op)
+	p.printWhite(blank)
+	p.expr(n.Right)
+}
+
+func (p *printer) ifStmt(n *ast.If) error {
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.expr(n.Condition)
+	p.printWhite(newline)
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	for _, elseif := range n.ElseIf {
+		p.flushComments(elseif.Pos())
+		p.writeIndent()
+		p.command(elseif.ExArg)
+		p.printWhite(blank)
+		p.expr(elseif.Condition)
+		p.printWhite(newline)
+		if err := p.block(elseif.Body); err != nil {
+			return err
+		}
+	}
+	if n.Else != nil {
+		p.flushComments(n.Else.Pos())
+		p.writeIndent()
+		p.command(n.Else.ExArg)
+		p.printWhite(newline)
+		if err := p.block(n.Else.Body); err != nil {
+			return err
+		}
+	}
+	if n.EndIf != nil {
+		return p.stmt(n.EndIf)
+	}
+	return nil
+}
+
+func (p *printer) forStmt(n *ast.For) error {
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	if n.Left == nil && n.List == nil {
+		// Right is *ast.BadExpr of the whole arguments if the parser
+		// recovers from errors.
+		p.expr(n.Right)
+	} else {
+		p.lhs(n.Left, n.List, n.Rest)
+		p.writeString(" in ")
+		p.expr(n.Right)
+	}
+	p.printWhite(newline)
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	if n.EndFor != nil {
+		return p.stmt(n.EndFor)
+	}
+	return nil
+}
+
+func (p *printer) tryStmt(n *ast.Try) error {
+	p.command(n.ExArg)
+	p.printWhite(newline)
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	for _, c := range n.Catch {
+		p.flushComments(c.Pos())
+		p.writeIndent()
+		p.command(c.ExArg)
+		if c.Pattern != "" {
+			d := patternDelimiter(c.Pattern)
+			p.printWhite(blank)
+			p.writeString(d + c.Pattern + d)
+		}
+		p.printWhite(newline)
+		if err := p.block(c.Body); err != nil {
+			return err
+		}
+	}
+	if n.Finally != nil {
+		p.flushComments(n.Finally.Pos())
+		p.writeIndent()
+		p.command(n.Finally.ExArg)
+		p.printWhite(newline)
+		if err := p.block(n.Finally.Body); err != nil {
+			return err
+		}
+	}
+	if n.EndTry != nil {
+		return p.stmt(n.EndTry)
+	}
+	return nil
+}
+
+// patternDelimiter returns delimiter which can be used for the pattern.
+// The parser drops the original delimiter of :catch pattern, so use "/"
+// unless the pattern contains unescaped "/".
+func patternDelimiter(pattern string) string {
+	for _, d := range []string{"/", "#", "!", "@", ","} {
+		if !hasUnescaped(pattern, d[0]) {
+			return d
+		}
+	}
+	return "/"
+}
+
+func hasUnescaped(s string, c byte) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == c {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package printer
+
+import (
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// vim9Attr prints modifiers of Vim9 declaration followed by a blank.
+func (p *printer) vim9Attr(attr ast.Vim9Attr) {
+	for _, a := range []struct {
+		enabled bool
+		name    string
+	}{
+		{attr.Export, "export"},
+		{attr.Abstract, "abstract"},
+		{attr.Public, "public"},
+		{attr.Static, "static"},
+	} {
+		if a.enabled {
+			p.writeString(a.name)
+			p.printWhite(blank)
+		}
+	}
+}
+
+// typeAnnotation prints ": type" if t is not nil.
+func (p *printer) typeAnnotation(t *ast.Type) {
+	if t == nil {
+		return
+	}
+	p.token(token.COLON)
+	p.printWhite(blank)
+	p.writeString(t.Name)
+}
+
+// params prints parameters of :def function or Vim9 lambda with
+// parentheses.
+func (p *printer) params(params []*ast.Param) {
+	p.token(token.POPEN)
+	for i, param := range params {
+		if i > 0 {
+			p.token(token.COMMA)
+			p.printWhite(blank)
+		}
+		if param.Variadic {
+			p.token(token.DOTDOTDOT)
+		}
+		p.expr(param.Name)
+		p.typeAnnotation(param.Type)
+		if param.Default != nil {
+			p.printWhite(blank)
+			p.token(token.EQ)
+			p.printWhite(blank)
+			p.expr(param.Default)
+		}
+	}
+	p.token(token.PCLOSE)
+}
+
+func (p *printer) def(n *ast.Def) error {
+	vim9 := p.vim9
+	p.vim9 = true
+	defer func() { p.vim9 = vim9 }()
+	p.vim9Attr(n.Attr)
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.expr(n.Name)
+	p.params(n.Params)
+	p.typeAnnotation(n.Result)
+	p.printWhite(newline)
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	if n.EndDef != nil {
+		return p.stmt(n.EndDef)
+	}
+	return nil
+}
+
+func (p *printer) varDecl(n *ast.VarDecl) {
+	p.vim9Attr(n.Attr)
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.lhs(n.Left, n.List, n.Rest)
+	p.typeAnnotation(n.Type)
+	if n.Right == nil {
+		return
+	}
+	p.printWhite(blank)
+	if _, ok := n.Right.(*ast.HeredocExpr); ok {
+		p.writeString("=<<")
+	} else {
+		p.token(token.EQ)
+	}
+	p.printWhite(blank)
+	p.expr(n.Right)
+}
+
+func (p *printer) assign(n *ast.Assign) {
+	p.command(n.ExArg)
+	if n.Right == nil {
+		// ++var and --var
+		p.writeString(n.Op)
+		p.expr(n.Left)
+		return
+	}
+	p.lhs(n.Left, n.List, n.Rest)
+	p.printWhite(blank)
+	p.writeString(n.Op)
+	p.printWhite(blank)
+	p.expr(n.Right)
+}
+
+func (p *printer) importStmt(n *ast.Import) {
+	p.command(n.ExArg)
+	if n.Autoload {
+		p.writeString(" autoload")
+	}
+	p.printWhite(blank)
+	p.expr(n.Path)
+	if n.As != nil {
+		p.writeString(" as ")
+		p.expr(n.As)
+	}
+}
+
+func (p *printer) class(n *ast.Class) error {
+	vim9 := p.vim9
+	p.vim9 = true
+	defer func() { p.vim9 = vim9 }()
+	p.vim9Attr(n.Attr)
+	p.command(n.ExArg)
+	p.printWhite(blank)
+	p.expr(n.Name)
+	if n.Extends != nil {
+		p.writeString(" extends ")
+		p.expr(n.Extends)
+	}
+	if len(n.Implements) > 0 {
+		p.writeString(" implements ")
+		p.exprList(n.Implements)
+	}
+	p.printWhite(newline)
+	if len(n.Values) > 0 {
+		p.indent++
+		p.flushComments(n.Values[0].Pos())
+		p.writeIndent()
+		p.exprList(n.Values)
+		p.printWhite(newline)
+		p.indent--
+	}
+	if err := p.block(n.Body); err != nil {
+		return err
+	}
+	if n.EndClass != nil {
+		return p.stmt(n.EndClass)
+	}
+	return nil
+}
+
+func (p *printer) vim9Lambda(x *ast.Vim9LambdaExpr) {
+	p.params(x.Params)
+	p.typeAnnotation(x.Result)
+	p.printWhite(blank)
+	p.writeString("=>")
+	p.printWhite(blank)
+	if x.Expr != nil {
+		p.expr(x.Expr)
+		return
+	}
+	p.token(token.COPEN)
+	p.printWhite(newline)
+	// errors are only for unsupported statements, which the parser doesn't
+	// produce.
+	_ = p.block(x.Body)
+	p.writeIndent()
+	p.token(token.CCLOSE)
+}
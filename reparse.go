@@ -0,0 +1,285 @@
+package vimlparser
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// TextEdit is a change of the source, e.g. by typing in an editor. Start and
+// End are the range of the replaced text in the source before the change.
+// Only Line and Column of them are used.
+type TextEdit struct {
+	Start   ast.Pos
+	End     ast.Pos
+	NewText string
+}
+
+// Reparse parses src, which is the source of old changed by edits. Edits
+// are applied in order, so the positions of an edit are of the source after
+// the previous edits as LSP.
+//
+// Reparse parses only the lines from the top-level statement before the
+// first changed line to the top-level statement after the last changed
+// line, and reuses the other top-level statements of old including
+// function definitions. Their positions are updated in place, so old must
+// not be used after Reparse. Reparse parses the whole src like ParseFile
+// when it can't tell that the changed lines are parsed in the same way, e.g.
+// an :if without :endif is added, or old is Vim9 script.
+func Reparse(old *ast.File, src []byte, edits []TextEdit, opt *ParseOption) (*ast.File, error) {
+	if f := reparse(old, src, edits, opt); f != nil {
+		return f, nil
+	}
+	filename := ""
+	if old != nil {
+		filename = old.Start.Filename
+	}
+	return ParseFile(bytes.NewReader(src), filename, opt)
+}
+
+// reparse returns nil if src should be parsed entirely.
+func reparse(old *ast.File, src []byte, edits []TextEdit, opt *ParseOption) *ast.File {
+	if old == nil || old.Vim9 || len(edits) == 0 {
+		return nil
+	}
+	m := NewSourceMap(src)
+	lo, hi, delta, ok := changedLines(edits)
+	if !ok || hi > len(m.lines)+1 {
+		return nil
+	}
+	body := old.Body
+
+	// Top-level statements before the changed lines are reused unless the
+	// next line is continued to them.
+	k := 0
+	for k < len(body) && body[k].End().Line < lo {
+		k++
+	}
+	for ; k > 0; k-- {
+		if k < len(body) && body[k].Pos().Line <= body[k-1].End().Line {
+			continue // the next statement is in the same line after "|"
+		}
+		if !m.continued(body[k-1].End().Line + 1) {
+			break
+		}
+	}
+	if endsParsing(body[:k]) {
+		return nil // the changed lines aren't parsed
+	}
+	start := 1
+	if k > 0 {
+		start = body[k-1].End().Line + 1
+	}
+
+	// Top-level statements after the changed lines are reused.
+	j := k
+	for j < len(body) && body[j].Pos().Line <= hi-delta {
+		j++
+	}
+	for j > k && j < len(body) && body[j].Pos().Line <= body[j-1].End().Line {
+		j++
+	}
+	end := len(m.lines) // in src
+	if j < len(body) {
+		end = body[j].Pos().Line - 1 + delta
+	}
+
+	var lines []string
+	for i := start; i <= end && i <= len(m.lines); i++ {
+		lines = append(lines, m.lines[i-1].text)
+	}
+	var ropt ParseOption
+	if opt != nil {
+		ropt = ParseOption{Neovim: opt.Neovim, AllCommands: opt.AllCommands}
+	}
+	filename := old.Start.Filename
+	region, err := ParseFile(strings.NewReader(strings.Join(lines, "\n")), filename, &ropt)
+	if err != nil || region.Vim9 || j < len(body) && !closedRegion(region) {
+		return nil
+	}
+
+	var before, after []*ast.CommentGroup
+	for _, g := range old.Comments {
+		if l := g.Pos().Line; l < start {
+			before = append(before, g)
+		} else if l > end-delta {
+			after = append(after, g)
+		}
+	}
+	if j < len(body) {
+		p := body[j].Pos()
+		line := p.Line + delta
+		if line < 1 || line > len(m.lines) {
+			return nil
+		}
+		offset := m.lines[line-1].parsed + p.Column - 1 - p.Offset
+		if delta != 0 || offset != 0 {
+			for _, s := range body[j:] {
+				shiftPos(s, delta, offset)
+			}
+			for _, g := range after {
+				shiftPos(g, delta, offset)
+			}
+		}
+	}
+	if start <= len(m.lines) {
+		shiftPos(region, start-1, m.lines[start-1].parsed)
+	}
+
+	f := &ast.File{Start: old.Start}
+	f.Body = append(f.Body, body[:k]...)
+	f.Body = append(f.Body, region.Body...)
+	f.Body = append(f.Body, body[j:]...)
+	f.Comments = append(f.Comments, before...)
+	f.Comments = append(f.Comments, region.Comments...)
+	f.Comments = append(f.Comments, after...)
+	for i, l := range m.lines {
+		if strings.Trim(l.text, " \t") == "" {
+			f.BlankLines = append(f.BlankLines, i+1)
+		}
+	}
+	return f
+}
+
+// changedLines returns the range of changed lines in the source after the
+// edits and the difference of the number of lines.
+func changedLines(edits []TextEdit) (lo, hi, delta int, ok bool) {
+	for i, e := range edits {
+		s, t := e.Start.Line, e.End.Line
+		if s < 1 || t < s {
+			return 0, 0, 0, false
+		}
+		n := strings.Count(e.NewText, "\n")
+		d := n - (t - s)
+		if i == 0 {
+			lo, hi = s, s+n
+		} else {
+			switch {
+			case hi < s:
+			case hi > t:
+				hi += d
+			default:
+				hi = s + n
+			}
+			if hi < s+n {
+				hi = s + n
+			}
+			if lo > s {
+				lo = s
+			}
+		}
+		delta += d
+	}
+	return lo, hi, delta, true
+}
+
+var continuationLine = regexp.MustCompile(`^\s*\\`)
+var continuationComment = regexp.MustCompile(`^\s*"\\ `)
+
+// continued reports whether the line is continued to the previous line.
+func (m *SourceMap) continued(line int) bool {
+	for ; line <= len(m.lines); line++ {
+		text := m.lines[line-1].text
+		if !continuationComment.MatchString(text) {
+			return continuationLine.MatchString(text)
+		}
+	}
+	return false
+}
+
+// closedRegion reports whether the commands of f don't continue to the next
+// line of f, e.g. :finish, :loadkeymap, :lua << EOF without "EOF" and
+// :append without ".".
+func closedRegion(f *ast.File) bool {
+	if endsParsing(f.Body) {
+		return false
+	}
+	if len(f.Body) == 0 {
+		return true
+	}
+	switch n := f.Body[len(f.Body)-1].(type) {
+	case *ast.ForeignCode:
+		if n.Marker == "" {
+			return true
+		}
+		last := n.Text[strings.LastIndexByte(n.Text, '\n')+1:]
+		return last == n.Marker || n.Trim && strings.TrimLeft(last, " \t") == n.Marker
+	case *ast.Excmd:
+		i := strings.LastIndexByte(n.Command, '\n')
+		if i < 0 {
+			return true
+		}
+		name := excmdName(n)
+		return (name == "append" || name == "insert") && n.Command[i+1:] == "."
+	}
+	return true
+}
+
+// endsParsing reports whether the top-level statements have :finish or
+// :loadkeymap, after which the rest of the file isn't parsed as commands.
+func endsParsing(body []ast.Statement) bool {
+	for _, s := range body {
+		if c, ok := s.(*ast.Excmd); ok && (excmdName(c) == "finish" || excmdName(c) == "loadkeymap") {
+			return true
+		}
+	}
+	return false
+}
+
+// excmdName returns the name of the command of n, or "" if n only has a
+// range or modifiers, e.g. ":5".
+func excmdName(n *ast.Excmd) string {
+	if n.ExArg.Cmd == nil {
+		return ""
+	}
+	return n.Cmd().Name
+}
+
+var posType = reflect.TypeOf(ast.Pos{})
+
+// shiftPos adds line and offset to Line and Offset of all positions in n.
+func shiftPos(n interface{}, line, offset int) {
+	type key struct {
+		p uintptr
+		t reflect.Type
+	}
+	visited := make(map[key]bool)
+	var shift func(v reflect.Value)
+	shift = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Ptr:
+			k := key{v.Pointer(), v.Type()}
+			if v.IsNil() || visited[k] {
+				return
+			}
+			visited[k] = true
+			shift(v.Elem())
+		case reflect.Interface:
+			if !v.IsNil() {
+				shift(v.Elem())
+			}
+		case reflect.Struct:
+			if v.Type() == posType {
+				if v.CanSet() {
+					p := v.Addr().Interface().(*ast.Pos)
+					if p.Line > 0 {
+						p.Line += line
+						p.Offset += offset
+					}
+				}
+				return
+			}
+			for i := 0; i < v.NumField(); i++ {
+				shift(v.Field(i))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < v.Len(); i++ {
+				shift(v.Index(i))
+			}
+		}
+	}
+	shift(reflect.ValueOf(n))
+}
@@ -0,0 +1,320 @@
+package scope
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// vimCompatVars are v: variables which can be used without "v:".
+var vimCompatVars = map[string]bool{
+	"count":        true,
+	"errmsg":       true,
+	"shell_error":  true,
+	"this_session": true,
+	"version":      true,
+}
+
+// Resolve builds scopes of the file and resolves names in it.
+//
+// It resolves names in two passes: the first pass defines functions and
+// variables assigned by :let and :for and parameters, and the second pass
+// resolves the other names, so that a name can be used before its
+// definition.
+func Resolve(f *ast.File) *Info {
+	r := &resolver{
+		info: &Info{
+			Scopes:   make(map[ast.Node]*Scope),
+			Defs:     make(map[ast.Expr]*Symbol),
+			Uses:     make(map[ast.Expr]*Symbol),
+			Captures: make(map[ast.Node][]*Symbol),
+		},
+		keys:    make(map[ast.Expr]bool),
+		callees: make(map[ast.Expr]bool),
+	}
+	file := newScope(f, nil)
+	r.info.Scopes[f] = file
+	ast.Walk(&declarer{r, file}, f)
+	ast.Walk(&referrer{r, file}, f)
+	return r.info
+}
+
+type resolver struct {
+	info    *Info
+	keys    map[ast.Expr]bool // identifiers of dictionary keys: x.{key}
+	callees map[ast.Expr]bool // names of called functions
+}
+
+func (r *resolver) def(x ast.Expr, sym *Symbol) {
+	sym.Defs = append(sym.Defs, x)
+	r.info.Defs[x] = sym
+}
+
+func (r *resolver) use(x ast.Expr, sym *Symbol) {
+	sym.Refs = append(sym.Refs, x)
+	r.info.Uses[x] = sym
+}
+
+// declarer is the visitor of the first pass.
+type declarer struct {
+	r     *resolver
+	scope *Scope
+}
+
+func (d *declarer) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.Function:
+		if name, ok := funcName(n.Name); ok {
+			kind, name := funcKind(name)
+			d.r.def(n.Name, d.scope.file().define(name, kind, true))
+		}
+		return d.declareParams(n, n.Params)
+
+	case *ast.LambdaExpr:
+		return d.declareParams(n, n.Params)
+
+	case *ast.Let:
+		d.declareVars(n.Left, n.List, n.Rest)
+
+	case *ast.For:
+		d.declareVars(n.Left, n.List, n.Rest)
+	}
+	return d
+}
+
+// declareParams creates the scope of the function or lambda n and defines
+// its parameters as a: variables.
+func (d *declarer) declareParams(n ast.Node, params []*ast.Ident) ast.Visitor {
+	s := newScope(n, d.scope)
+	d.r.info.Scopes[n] = s
+	for _, p := range params {
+		if p.Name == "..." {
+			s.variadic = true
+			continue
+		}
+		d.r.def(p, s.define("a:"+p.Name, Arg, false))
+	}
+	return &declarer{d.r, s}
+}
+
+// declareVars defines variables assigned by :let or :for.
+func (d *declarer) declareVars(left ast.Expr, list []ast.Expr, rest ast.Expr) {
+	for _, x := range append([]ast.Expr{left, rest}, list...) {
+		id, ok := x.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		kind, name := kindOf(id.Name)
+		s := d.scope.file()
+		switch kind {
+		case Arg:
+			// a: variables are read-only.
+			continue
+		case Local:
+			if d.scope.IsFile() {
+				if strings.HasPrefix(name, "l:") {
+					continue
+				}
+				kind, name = Global, "g:"+name
+				break
+			}
+			s = d.scope
+			if !strings.HasPrefix(name, "l:") {
+				name = "l:" + name
+			}
+		}
+		d.r.def(id, s.define(name, kind, false))
+	}
+}
+
+// inFunction reports whether s is in a function. Lambdas at the top level
+// are not in functions.
+func (s *Scope) inFunction() bool {
+	for ; !s.IsFile(); s = s.Parent {
+		if _, ok := s.Node.(*ast.Function); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// referrer is the visitor of the second pass.
+type referrer struct {
+	r     *resolver
+	scope *Scope
+}
+
+func (v *referrer) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.Function, *ast.LambdaExpr:
+		return &referrer{v.r, v.r.info.Scopes[n]}
+
+	case *ast.DotExpr:
+		v.r.keys[n.Right] = true
+
+	case *ast.CallExpr:
+		v.r.callees[n.Fun] = true
+
+	case *ast.MethodExpr:
+		v.r.callees[n.Method] = true
+
+	case *ast.DelFunction:
+		v.r.callees[n.Name] = true
+
+	case *ast.CurlyName:
+		if v.r.info.Defs[n] != nil {
+			return nil
+		}
+		if name, ok := funcName(n); ok && v.r.callees[n] {
+			v.resolveFunc(n, name)
+			return nil
+		}
+
+	case *ast.Ident:
+		if v.r.info.Defs[n] != nil || v.r.keys[n] || n.Name == "..." {
+			// definitions, dictionary keys and variadic parameters
+			break
+		}
+		if v.r.callees[n] {
+			// funcref variable or function
+			if sym := v.lookup(n.Name, false); sym != nil {
+				v.r.use(n, sym)
+				break
+			}
+			v.resolveFunc(n, n.Name)
+			break
+		}
+		if sym := v.lookup(n.Name, true); sym != nil {
+			v.r.use(n, sym)
+			break
+		}
+		v.r.info.Unresolved = append(v.r.info.Unresolved, n)
+	}
+	return v
+}
+
+// resolveFunc resolves the name x of the called function. Builtin functions
+// are not resolved.
+func (v *referrer) resolveFunc(x ast.Expr, name string) {
+	if isBuiltinFunc(name) {
+		return
+	}
+	kind, name := funcKind(name)
+	file := v.scope.file()
+	if kind == Script && file.Funcs[name] == nil {
+		v.r.info.Unresolved = append(v.r.info.Unresolved, x)
+		return
+	}
+	// global and autoload functions may be defined in other files.
+	v.r.use(x, file.define(name, kind, true))
+}
+
+// lookup returns the variable name. If global is true, it creates variables
+// other than local and arguments, which may be defined in other files.
+// Otherwise it returns defined variables only.
+func (v *referrer) lookup(name string, global bool) *Symbol {
+	kind, name := kindOf(name)
+	file := v.scope.file()
+	switch kind {
+	case Arg:
+		return v.lookupLocal(name)
+	case Local:
+		if sym := v.lookupLocal(name); sym != nil {
+			return sym
+		}
+		if !v.scope.inFunction() {
+			if strings.HasPrefix(name, "l:") {
+				return nil
+			}
+			kind, name = Global, "g:"+name
+			break
+		}
+		if vimCompatVars[name] {
+			kind, name = Vim, "v:"+name
+			break
+		}
+		return nil
+	}
+	if sym := file.Vars[name]; sym != nil || !global {
+		return sym
+	}
+	return file.define(name, kind, false)
+}
+
+// lookupLocal returns the local variable or the argument name in the
+// function scope. Lambdas and closures also look up the enclosing functions
+// and record captured variables.
+func (v *referrer) lookupLocal(name string) *Symbol {
+	plain := !strings.Contains(name, ":")
+	for s := v.scope; !s.IsFile(); s = s.Parent {
+		_, lambda := s.Node.(*ast.LambdaExpr)
+		var keys []string
+		switch {
+		case plain:
+			keys = []string{"l:" + name}
+			if lambda {
+				// lambda parameters can be used without "a:".
+				keys = append(keys, "a:"+name)
+			}
+		default:
+			keys = []string{name}
+		}
+		for _, k := range keys {
+			if sym := s.Vars[k]; sym != nil {
+				if s != v.scope {
+					v.capture(sym)
+				}
+				return sym
+			}
+		}
+		if sym := s.implicit(name); sym != nil {
+			return sym
+		}
+		if strings.HasPrefix(name, "a:") && !lambda || !s.isClosure() {
+			// a: variables of closure functions are their own ones.
+			break
+		}
+	}
+	return nil
+}
+
+// implicit returns the implicit variable name of the function or lambda
+// scope s if exists: "a:0", "a:000" and "a:1"... for variadic functions,
+// "a:firstline" and "a:lastline" for functions and "self".
+func (s *Scope) implicit(name string) *Symbol {
+	_, fn := s.Node.(*ast.Function)
+	switch {
+	case name == "self" || name == "l:self":
+		if !fn {
+			return nil
+		}
+		return s.define("l:self", Local, false)
+	case name == "a:firstline" || name == "a:lastline":
+		if !fn {
+			return nil
+		}
+	case name == "a:0" || name == "a:000":
+		if !s.variadic {
+			return nil
+		}
+	case strings.HasPrefix(name, "a:"):
+		if n, err := strconv.Atoi(name[2:]); !s.variadic || err != nil || n < 1 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return s.define(name, Arg, false)
+}
+
+// capture records that the current lambda or closure uses sym.
+func (v *referrer) capture(sym *Symbol) {
+	n := v.scope.Node
+	for _, s := range v.r.info.Captures[n] {
+		if s == sym {
+			return
+		}
+	}
+	v.r.info.Captures[n] = append(v.r.info.Captures[n], sym)
+}
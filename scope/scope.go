@@ -0,0 +1,239 @@
+// Package scope resolves variables and functions of Vim script.
+//
+// Resolve walks an *ast.File and builds scopes for the file, functions and
+// lambdas. Each identifier which names a variable or a user-defined function
+// is resolved to a Symbol, so that one can find definitions and references of
+// it, e.g. to find unused variables, undefined references or to rename them.
+//
+// The resolution is static and doesn't depend on the control flow. A local
+// variable is defined in the whole function if it's assigned by :let or :for
+// anywhere in the function.
+package scope
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// Kind represents the kind of symbols, i.e. scope of variables or functions.
+type Kind int
+
+const (
+	Global   Kind = iota // g: variables and global functions
+	Script               // s: variables and functions
+	Buffer               // b: variables
+	Window               // w: variables
+	Tab                  // t: variables
+	Vim                  // v: variables
+	Arg                  // a: variables; function and lambda parameters
+	Local                // l: variables
+	Autoload             // autoload variables and functions, e.g. foo#bar
+)
+
+var kindNames = [...]string{
+	Global:   "global",
+	Script:   "script",
+	Buffer:   "buffer",
+	Window:   "window",
+	Tab:      "tab",
+	Vim:      "vim",
+	Arg:      "arg",
+	Local:    "local",
+	Autoload: "autoload",
+}
+
+func (k Kind) String() string {
+	if 0 <= k && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Symbol is a variable or a function.
+type Symbol struct {
+	// Name is the normalized name with scope prefix, e.g. "g:x" for `x`
+	// at the top level, "l:x" for `x` in a function and "s:F" for `<SID>F`.
+	// Names of global and autoload functions don't have prefix, e.g. "F"
+	// for `g:F`.
+	Name  string
+	Kind  Kind
+	Func  bool   // function, not variable
+	Scope *Scope // scope which defines the symbol
+
+	// Definitions and references. They are *ast.Ident or *ast.CurlyName
+	// for function names such as `<SID>F`. Defs is empty for implicit
+	// variables such as a:000 and symbols defined in other files.
+	Defs []ast.Expr
+	Refs []ast.Expr
+}
+
+func (s *Symbol) String() string {
+	if s.Func {
+		return s.Name + "()"
+	}
+	return s.Name
+}
+
+// Scope is a scope of the file, a function or a lambda.
+type Scope struct {
+	Node     ast.Node // *ast.File, *ast.Function or *ast.LambdaExpr
+	Parent   *Scope   // enclosing scope; or nil for the file scope
+	Children []*Scope
+
+	// Symbols defined in the scope by normalized names. The file scope has
+	// all variables other than a: and l: and all functions.
+	Vars  map[string]*Symbol
+	Funcs map[string]*Symbol
+
+	variadic bool // function or lambda has "..." parameter
+}
+
+func newScope(node ast.Node, parent *Scope) *Scope {
+	s := &Scope{
+		Node:   node,
+		Parent: parent,
+		Vars:   make(map[string]*Symbol),
+		Funcs:  make(map[string]*Symbol),
+	}
+	if parent != nil {
+		parent.Children = append(parent.Children, s)
+	}
+	return s
+}
+
+// IsFile reports whether s is the file scope.
+func (s *Scope) IsFile() bool { return s.Parent == nil }
+
+// isClosure reports whether s can access variables of the enclosing function.
+func (s *Scope) isClosure() bool {
+	switch n := s.Node.(type) {
+	case *ast.LambdaExpr:
+		return true
+	case *ast.Function:
+		return n.Attr.Closure
+	}
+	return false
+}
+
+// file returns the file scope.
+func (s *Scope) file() *Scope {
+	for s.Parent != nil {
+		s = s.Parent
+	}
+	return s
+}
+
+// define returns the variable named name in s, creating it if needed.
+func (s *Scope) define(name string, kind Kind, fn bool) *Symbol {
+	table := s.Vars
+	if fn {
+		table = s.Funcs
+	}
+	sym, ok := table[name]
+	if !ok {
+		sym = &Symbol{Name: name, Kind: kind, Func: fn, Scope: s}
+		table[name] = sym
+	}
+	return sym
+}
+
+// Info holds the result of Resolve.
+type Info struct {
+	Scopes map[ast.Node]*Scope // scopes of *ast.File, *ast.Function and *ast.LambdaExpr
+
+	Defs map[ast.Expr]*Symbol // names which define symbols
+	Uses map[ast.Expr]*Symbol // names which refer to symbols
+
+	// Captures maps lambdas and closure functions to the variables of
+	// enclosing functions they use.
+	Captures map[ast.Node][]*Symbol
+
+	// Unresolved is the list of references to undefined local variables,
+	// arguments and script-local functions.
+	Unresolved []ast.Expr
+}
+
+// ObjectOf returns the symbol which the name x defines or refers to, or nil.
+func (info *Info) ObjectOf(x ast.Expr) *Symbol {
+	if s := info.Defs[x]; s != nil {
+		return s
+	}
+	return info.Uses[x]
+}
+
+// kindOf returns the kind and the normalized name of the variable name. The
+// name without scope prefix is local in functions and global at the top
+// level; it's returned as it is with Local kind.
+func kindOf(name string) (Kind, string) {
+	if len(name) > 2 && name[1] == ':' {
+		switch name[0] {
+		case 'g':
+			if strings.Contains(name, "#") {
+				return Autoload, name[2:]
+			}
+			return Global, name
+		case 's':
+			return Script, name
+		case 'b':
+			return Buffer, name
+		case 'w':
+			return Window, name
+		case 't':
+			return Tab, name
+		case 'v':
+			return Vim, name
+		case 'a':
+			return Arg, name
+		case 'l':
+			return Local, name
+		}
+	}
+	if strings.Contains(name, "#") {
+		return Autoload, name
+	}
+	return Local, name
+}
+
+// funcKind returns the kind and the normalized name of the function name.
+func funcKind(name string) (Kind, string) {
+	if len(name) > 5 && strings.EqualFold(name[:5], "<SID>") {
+		return Script, "s:" + name[5:]
+	}
+	name = strings.TrimPrefix(name, "g:")
+	switch {
+	case strings.HasPrefix(name, "s:"):
+		return Script, name
+	case strings.Contains(name, "#"):
+		return Autoload, name
+	}
+	return Global, name
+}
+
+// isBuiltinFunc reports whether the function name is a builtin function,
+// i.e. it starts with lower case letter without scope and "#".
+func isBuiltinFunc(name string) bool {
+	return name != "" && 'a' <= name[0] && name[0] <= 'z' &&
+		!strings.ContainsAny(name, ":#")
+}
+
+// funcName returns the name of the function if x is an identifier or a curly
+// braces name without expressions such as `<SID>F`.
+func funcName(x ast.Expr) (string, bool) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		return x.Name, true
+	case *ast.CurlyName:
+		var name string
+		for _, p := range x.Parts {
+			lit, ok := p.(*ast.CurlyNameLit)
+			if !ok {
+				return "", false
+			}
+			name += lit.Value
+		}
+		return name, true
+	}
+	return "", false
+}
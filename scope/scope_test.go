@@ -0,0 +1,189 @@
+package scope
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+const src = `let s:count = 0
+let total = 0
+
+function! s:inc(n, ...) abort
+  let i = a:n + a:0 + len(a:000)
+  for [k, l:v] in items({})
+    let s:count += l:i + k + v + count
+  endfor
+  return {x -> x + i + a:n + s:count + g:total}
+endfunction
+
+function! Outer() abort
+  let y = 1
+  function! Inner() closure
+    return y + undefined
+  endfunction
+  return funcref('Inner')
+endfunction
+
+function! s:obj.method() dict
+  return self.name . l:nolocal . a:1
+endfunction
+
+call s:inc(1)
+call <SID>inc(2)
+call s:missing()
+call foo#bar(total, b:var, {z -> z + total})
+`
+
+// symbolOf returns the symbol name and kind of x.
+func symbolOf(info *Info, x ast.Expr) string {
+	s := info.ObjectOf(x)
+	if s == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%s(%s)", s, s.Kind)
+}
+
+func resolve(t *testing.T) (*ast.File, *Info) {
+	t.Helper()
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, Resolve(f)
+}
+
+func TestResolve(t *testing.T) {
+	f, info := resolve(t)
+
+	// name at line:column -> symbol
+	got := make(map[string]string)
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.Ident, *ast.CurlyName:
+			got[n.Pos().String()] = symbolOf(info, n.(ast.Expr))
+		}
+		return true
+	})
+	want := map[string]string{
+		"1:5":   "s:count(script)",
+		"2:5":   "g:total(global)",
+		"4:11":  "s:inc()(script)",
+		"4:17":  "a:n(arg)",
+		"4:20":  "<nil>", // variadic parameter
+		"5:7":   "l:i(local)",
+		"5:11":  "a:n(arg)",
+		"5:17":  "a:0(arg)",
+		"5:23":  "<nil>", // len is builtin
+		"5:27":  "a:000(arg)",
+		"6:8":   "l:k(local)",
+		"6:11":  "l:v(local)",
+		"6:19":  "<nil>",
+		"7:9":   "s:count(script)",
+		"7:20":  "l:i(local)",
+		"7:26":  "l:k(local)",
+		"7:30":  "l:v(local)",
+		"7:34":  "v:count(vim)",
+		"9:11":  "a:x(arg)",
+		"9:16":  "a:x(arg)",
+		"9:20":  "l:i(local)",
+		"9:24":  "a:n(arg)",
+		"9:30":  "s:count(script)",
+		"9:40":  "g:total(global)",
+		"12:11": "Outer()(global)",
+		"13:7":  "l:y(local)",
+		"14:13": "Inner()(global)",
+		"15:12": "l:y(local)",
+		"15:16": "<nil>",
+		"17:10": "<nil>",
+		"20:11": "s:obj(script)",
+		"20:17": "<nil>", // dictionary key
+		"21:10": "l:self(local)",
+		"21:15": "<nil>",
+		"21:22": "<nil>",
+		"21:34": "<nil>",
+		"24:6":  "s:inc()(script)",
+		"25:6":  "s:inc()(script)",
+		"26:6":  "<nil>",
+		"27:6":  "foo#bar()(autoload)",
+		"27:14": "g:total(global)",
+		"27:21": "b:var(buffer)",
+		"27:29": "a:z(arg)",
+		"27:34": "a:z(arg)",
+		"27:38": "g:total(global)",
+	}
+	for pos, w := range want {
+		if got[pos] != w {
+			t.Errorf("%s: got %s, want %s", pos, got[pos], w)
+		}
+	}
+	for pos := range got {
+		if _, ok := want[pos]; !ok {
+			t.Errorf("%s: unexpected name %s", pos, got[pos])
+		}
+	}
+
+	var unresolved []string
+	for _, x := range info.Unresolved {
+		unresolved = append(unresolved, x.Pos().String())
+	}
+	// undefined, l:nolocal, a:1 in non-variadic function, s:missing
+	if want := []string{"15:16", "21:22", "21:34", "26:6"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("Unresolved = %v, want %v", unresolved, want)
+	}
+}
+
+func TestResolve_symbols(t *testing.T) {
+	f, info := resolve(t)
+	file := info.Scopes[f]
+	if !file.IsFile() || len(file.Children) != 4 {
+		t.Fatalf("file scope: %d children", len(file.Children))
+	}
+
+	count := file.Vars["s:count"]
+	if count == nil || len(count.Defs) != 2 || len(count.Refs) != 1 {
+		t.Errorf("s:count = %+v", count)
+	}
+	total := file.Vars["g:total"]
+	if total == nil || len(total.Defs) != 1 || len(total.Refs) != 3 {
+		t.Errorf("g:total = %+v", total)
+	}
+	inc := file.Funcs["s:inc"]
+	if inc == nil || len(inc.Defs) != 1 || len(inc.Refs) != 2 {
+		t.Errorf("s:inc() = %+v", inc)
+	}
+	if _, ok := inc.Refs[1].(*ast.CurlyName); !ok {
+		t.Errorf("<SID>inc = %T, want *ast.CurlyName", inc.Refs[1])
+	}
+
+	var funcs []string
+	for name := range file.Funcs {
+		funcs = append(funcs, name)
+	}
+	sort.Strings(funcs)
+	if want := []string{"Inner", "Outer", "foo#bar", "s:inc"}; !reflect.DeepEqual(funcs, want) {
+		t.Errorf("functions = %v, want %v", funcs, want)
+	}
+
+	// captures
+	got := make(map[string][]string)
+	for n, syms := range info.Captures {
+		var names []string
+		for _, s := range syms {
+			names = append(names, s.Name)
+		}
+		got[n.Pos().String()] = names
+	}
+	want := map[string][]string{
+		"9:10": {"l:i", "a:n"},
+		"14:3": {"l:y"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Captures = %v, want %v", got, want)
+	}
+}
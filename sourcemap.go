@@ -0,0 +1,129 @@
+package vimlparser
+
+import (
+	"sort"
+	"unicode/utf16"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// SourceMap converts positions of the parser to positions in the source.
+//
+// Line and Column of ast.Pos and ErrVimlParser are of the source even in
+// continuation lines, but Offset counts a newline as one byte because the
+// parser reads lines without newline characters, so it differs from the
+// byte offset in the source with CRLF. Columns are byte counts; a tab is one
+// byte and a multibyte character is its UTF-8 length.
+type SourceMap struct {
+	lines []sourceLine
+	size  int // size of the source
+}
+
+type sourceLine struct {
+	text   string // without newline characters
+	offset int    // byte offset in the source
+	parsed int    // offset counted by the parser
+}
+
+// Position is a position in the source.
+type Position struct {
+	Offset      int // byte offset, starting at 0
+	Line        int // line number, starting at 1
+	Column      int // column number, starting at 1 (byte count)
+	UTF16Column int // column number, starting at 1 (UTF-16 code units)
+}
+
+// NewSourceMap returns the SourceMap of src, which is split into lines in
+// the same way as ParseFile.
+func NewSourceMap(src []byte) *SourceMap {
+	m := &SourceMap{size: len(src)}
+	offset, parsed := 0, 0
+	for offset < len(src) {
+		n := len(src) - offset
+		next := len(src)
+		for i, c := range src[offset:] {
+			if c == '\n' {
+				n, next = i, offset+i+1
+				break
+			}
+		}
+		if n > 0 && src[offset+n-1] == '\r' {
+			n--
+		}
+		text := string(src[offset : offset+n])
+		m.lines = append(m.lines, sourceLine{text: text, offset: offset, parsed: parsed})
+		offset = next
+		parsed += len(text) + 1
+	}
+	return m
+}
+
+// Position returns the position of pos in the source. pos is adjusted to
+// the nearest position in the source, e.g. the end of the source for the
+// position after the last line.
+func (m *SourceMap) Position(pos ast.Pos) Position {
+	return m.position(pos.Line-1, pos.Column)
+}
+
+// OffsetPosition returns the position in the source of offset, which is
+// Offset of ast.Pos or ErrVimlParser.
+func (m *SourceMap) OffsetPosition(offset int) Position {
+	i := sort.Search(len(m.lines), func(i int) bool { return m.lines[i].parsed > offset }) - 1
+	if i < 0 {
+		return m.position(0, 1)
+	}
+	return m.position(i, offset-m.lines[i].parsed+1)
+}
+
+// UTF16Pos returns ast.Pos of the position which is given by the line
+// number and the column number in UTF-16 code units, starting at 1, e.g.
+// the position of LSP. A column in the middle of a character is moved to
+// the next character.
+func (m *SourceMap) UTF16Pos(line, column int) ast.Pos {
+	if line < 1 || line > len(m.lines) {
+		return m.parsedPos(m.position(line-1, 1))
+	}
+	text := m.lines[line-1].text
+	col, n := len(text)+1, 1
+	for i, r := range text {
+		if n >= column {
+			col = i + 1
+			break
+		}
+		n += utf16.RuneLen(r)
+	}
+	return m.parsedPos(m.position(line-1, col))
+}
+
+// position returns the position at the byte column of the i-th line.
+func (m *SourceMap) position(i, column int) Position {
+	if i < 0 {
+		i, column = 0, 1
+	}
+	if i >= len(m.lines) {
+		return Position{Offset: m.size, Line: len(m.lines) + 1, Column: 1, UTF16Column: 1}
+	}
+	l := m.lines[i]
+	if column < 1 {
+		column = 1
+	} else if column > len(l.text)+1 {
+		column = len(l.text) + 1
+	}
+	u := 1
+	for _, r := range l.text[:column-1] {
+		u += utf16.RuneLen(r)
+	}
+	return Position{Offset: l.offset + column - 1, Line: i + 1, Column: column, UTF16Column: u}
+}
+
+// parsedPos returns ast.Pos of p with the offset of the parser.
+func (m *SourceMap) parsedPos(p Position) ast.Pos {
+	offset := 0
+	if p.Line-1 < len(m.lines) {
+		offset = m.lines[p.Line-1].parsed + p.Column - 1
+	} else if len(m.lines) > 0 {
+		l := m.lines[len(m.lines)-1]
+		offset = l.parsed + len(l.text) + 1
+	}
+	return ast.Pos{Offset: offset, Line: p.Line, Column: p.Column}
+}
@@ -0,0 +1,21 @@
+if 1 " c0
+  call G() " c1
+elseif 2 " c2
+  let x = 1 " c3
+else " c4
+  call H()
+endif " c5
+call F() " c6
+function! F() " c7
+  return 1 " c8
+endfunction
+for x in [] " c9
+endfor " c10
+while 0 " c11
+endwhile
+try " c12
+catch /x/ " c13
+finally " c14
+endtry " c15
+let y = [1,
+  \ 2] " c16
@@ -79,6 +79,10 @@ const (
 	ARROW
 
 	STRING // "abc", 'abc'
+
+	// Vim9 script
+	DOTDOT // ..
+	FALSY  // ??
 )
 
 var tokens = [...]string{
@@ -151,6 +155,9 @@ var tokens = [...]string{
 	ARROW:      "->",
 
 	STRING: "<STRING>",
+
+	DOTDOT: "..",
+	FALSY:  "??",
 }
 
 // String returns the string corresponding to the token tok.
@@ -0,0 +1,200 @@
+package types
+
+import "github.com/vim-jp/go-vimlparser/builtin"
+
+// builtinFunc is the signature of a builtin function for type inference.
+type builtinFunc struct {
+	result *Type // type of the result; or nil if it's of the first argument
+
+	// args is the kinds accepted as the first argument; or nil for any.
+	// err is the error for the first argument of the other kinds.
+	args []Kind
+	err  string
+}
+
+var (
+	listOfNumber = NewList(Typ[Number])
+	listOfString = NewList(Typ[String])
+	listOfDict   = NewList(Typ[Dict])
+)
+
+// builtinFuncs is the signatures of frequently used builtin functions. The
+// results of the other functions are from the builtin package. Functions
+// whose result depends on the arguments, e.g. getline() and get(), are Any.
+var builtinFuncs = map[string]builtinFunc{
+	"abs":             {result: nil, args: []Kind{Number, Float, String}},
+	"add":             {result: nil, args: []Kind{List, Blob}, err: "E897: List or Blob required"},
+	"and":             {result: Typ[Number]},
+	"append":          {result: Typ[Number]},
+	"argc":            {result: Typ[Number]},
+	"atan":            {result: Typ[Float]},
+	"bufexists":       {result: Typ[Number]},
+	"buflisted":       {result: Typ[Number]},
+	"bufloaded":       {result: Typ[Number]},
+	"bufname":         {result: Typ[String]},
+	"bufnr":           {result: Typ[Number]},
+	"bufwinid":        {result: Typ[Number]},
+	"bufwinnr":        {result: Typ[Number]},
+	"byteidx":         {result: Typ[Number]},
+	"ceil":            {result: Typ[Float]},
+	"ch_open":         {result: Typ[Channel]},
+	"ch_status":       {result: Typ[String]},
+	"char2nr":         {result: Typ[Number]},
+	"col":             {result: Typ[Number]},
+	"copy":            {result: nil},
+	"cos":             {result: Typ[Float]},
+	"count":           {result: Typ[Number], args: []Kind{String, List, Dict}, err: "E712: Argument of count() must be a List or Dictionary"},
+	"cursor":          {result: Typ[Number]},
+	"deepcopy":        {result: nil},
+	"delete":          {result: Typ[Number]},
+	"empty":           {result: Typ[Number]},
+	"escape":          {result: Typ[String]},
+	"executable":      {result: Typ[Number]},
+	"execute":         {result: Typ[String]},
+	"exists":          {result: Typ[Number]},
+	"exp":             {result: Typ[Float]},
+	"extend":          {result: nil, args: []Kind{List, Dict}, err: "E712: Argument of extend() must be a List or Dictionary"},
+	"filereadable":    {result: Typ[Number]},
+	"filewritable":    {result: Typ[Number]},
+	"filter":          {result: nil, args: []Kind{String, List, Dict, Blob}, err: "E712: Argument of filter() must be a List or Dictionary"},
+	"float2nr":        {result: Typ[Number]},
+	"floor":           {result: Typ[Float]},
+	"fnameescape":     {result: Typ[String]},
+	"fnamemodify":     {result: Typ[String]},
+	"foldclosed":      {result: Typ[Number]},
+	"foldlevel":       {result: Typ[Number]},
+	"funcref":         {result: Typ[Funcref]},
+	"function":        {result: Typ[Funcref]},
+	"getbufinfo":      {result: listOfDict},
+	"getcmdline":      {result: Typ[String]},
+	"getcmdtype":      {result: Typ[String]},
+	"getcurpos":       {result: listOfNumber},
+	"getcwd":          {result: Typ[String]},
+	"getfsize":        {result: Typ[Number]},
+	"getftime":        {result: Typ[Number]},
+	"getftype":        {result: Typ[String]},
+	"getpos":          {result: listOfNumber},
+	"gettabinfo":      {result: listOfDict},
+	"getwininfo":      {result: listOfDict},
+	"has":             {result: Typ[Number]},
+	"has_key":         {result: Typ[Number], args: []Kind{Dict}, err: "E715: Dictionary required"},
+	"hlexists":        {result: Typ[Number]},
+	"hlID":            {result: Typ[Number]},
+	"iconv":           {result: Typ[String]},
+	"indent":          {result: Typ[Number]},
+	"index":           {result: Typ[Number], args: []Kind{List, Blob}, err: "E714: List required"},
+	"input":           {result: Typ[String]},
+	"insert":          {result: nil, args: []Kind{List, Blob}, err: "E899: Argument of insert() must be a List or Blob"},
+	"isdirectory":     {result: Typ[Number]},
+	"items":           {result: NewList(Typ[List]), args: []Kind{Dict, List, String}, err: "E1225: String, List or Dictionary required for argument 1"},
+	"job_getchannel":  {result: Typ[Channel]},
+	"job_start":       {result: Typ[Job]},
+	"job_status":      {result: Typ[String]},
+	"join":            {result: Typ[String], args: []Kind{List}, err: "E714: List required"},
+	"json_encode":     {result: Typ[String]},
+	"keys":            {result: listOfString, args: []Kind{Dict}, err: "E715: Dictionary required"},
+	"len":             {result: Typ[Number], args: []Kind{String, Number, List, Dict, Blob}, err: "E701: Invalid type for len()"},
+	"line":            {result: Typ[Number]},
+	"localtime":       {result: Typ[Number]},
+	"log":             {result: Typ[Float]},
+	"log10":           {result: Typ[Float]},
+	"map":             {result: nil, args: []Kind{String, List, Dict, Blob}, err: "E712: Argument of map() must be a List or Dictionary"},
+	"match":           {result: Typ[Number]},
+	"matchend":        {result: Typ[Number]},
+	"matchlist":       {result: listOfString},
+	"matchstr":        {result: Typ[String]},
+	"max":             {result: Typ[Number], args: []Kind{List, Dict}, err: "E712: Argument of max() must be a List or Dictionary"},
+	"min":             {result: Typ[Number], args: []Kind{List, Dict}, err: "E712: Argument of min() must be a List or Dictionary"},
+	"mkdir":           {result: Typ[Number]},
+	"mode":            {result: Typ[String]},
+	"nr2char":         {result: Typ[String]},
+	"or":              {result: Typ[Number]},
+	"pow":             {result: Typ[Float]},
+	"printf":          {result: Typ[String]},
+	"range":           {result: listOfNumber},
+	"readfile":        {result: listOfString},
+	"reltimefloat":    {result: Typ[Float]},
+	"reltimestr":      {result: Typ[String]},
+	"rename":          {result: Typ[Number]},
+	"repeat":          {result: nil},
+	"resolve":         {result: Typ[String]},
+	"reverse":         {result: nil, args: []Kind{String, List, Blob}, err: "E899: Argument of reverse() must be a List or Blob"},
+	"round":           {result: Typ[Float]},
+	"search":          {result: Typ[Number]},
+	"searchpair":      {result: Typ[Number]},
+	"setline":         {result: Typ[Number]},
+	"setpos":          {result: Typ[Number]},
+	"setreg":          {result: Typ[Number]},
+	"sha256":          {result: Typ[String]},
+	"shellescape":     {result: Typ[String]},
+	"simplify":        {result: Typ[String]},
+	"sin":             {result: Typ[Float]},
+	"sort":            {result: nil, args: []Kind{List}, err: "E686: Argument of sort() must be a List"},
+	"split":           {result: listOfString},
+	"sqrt":            {result: Typ[Float]},
+	"str2float":       {result: Typ[Float]},
+	"str2list":        {result: listOfNumber},
+	"str2nr":          {result: Typ[Number]},
+	"strcharpart":     {result: Typ[String]},
+	"strchars":        {result: Typ[Number]},
+	"strdisplaywidth": {result: Typ[Number]},
+	"strftime":        {result: Typ[String]},
+	"stridx":          {result: Typ[Number]},
+	"string":          {result: Typ[String]},
+	"strlen":          {result: Typ[Number]},
+	"strpart":         {result: Typ[String]},
+	"strridx":         {result: Typ[Number]},
+	"strtrans":        {result: Typ[String]},
+	"strwidth":        {result: Typ[Number]},
+	"substitute":      {result: Typ[String]},
+	"system":          {result: Typ[String]},
+	"systemlist":      {result: listOfString},
+	"tabpagebuflist":  {result: listOfNumber},
+	"tabpagenr":       {result: Typ[Number]},
+	"tan":             {result: Typ[Float]},
+	"tempname":        {result: Typ[String]},
+	"timer_start":     {result: Typ[Number]},
+	"tolower":         {result: Typ[String]},
+	"toupper":         {result: Typ[String]},
+	"tr":              {result: Typ[String]},
+	"trim":            {result: Typ[String]},
+	"trunc":           {result: Typ[Float]},
+	"type":            {result: Typ[Number]},
+	"uniq":            {result: nil, args: []Kind{List}, err: "E686: Argument of uniq() must be a List"},
+	"values":          {result: Typ[List], args: []Kind{Dict}, err: "E715: Dictionary required"},
+	"virtcol":         {result: Typ[Number]},
+	"visualmode":      {result: Typ[String]},
+	"win_getid":       {result: Typ[Number]},
+	"wincol":          {result: Typ[Number]},
+	"winheight":       {result: Typ[Number]},
+	"winline":         {result: Typ[Number]},
+	"winnr":           {result: Typ[Number]},
+	"winwidth":        {result: Typ[Number]},
+	"xor":             {result: Typ[Number]},
+}
+
+// resultTypes maps the results of builtin.Function to the types. Functions
+// which return Bool in Vim9 script return Number in legacy script.
+var resultTypes = map[string]*Type{
+	"number":    Typ[Number],
+	"float":     Typ[Float],
+	"string":    Typ[String],
+	"bool":      Typ[Number],
+	"list<any>": Typ[List],
+	"dict<any>": Typ[Dict],
+	"func":      Typ[Funcref],
+	"blob":      Typ[Blob],
+	"job":       Typ[Job],
+	"channel":   Typ[Channel],
+}
+
+// resultOf returns the type of the result of the builtin function name
+// which is not in builtinFuncs.
+func resultOf(name string) *Type {
+	if f := builtin.LookupFunction(name); f != nil {
+		if t, ok := resultTypes[f.Result]; ok {
+			return t
+		}
+	}
+	return Typ[Any]
+}
@@ -0,0 +1,768 @@
+package types
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+	"github.com/vim-jp/go-vimlparser/builtin"
+	"github.com/vim-jp/go-vimlparser/scope"
+	"github.com/vim-jp/go-vimlparser/token"
+)
+
+// maxRounds is the maximum number of walks to infer the types of variables
+// assigned from other variables.
+const maxRounds = 8
+
+// vimVars is the types of v: variables.
+var vimVars = map[string]*Type{
+	"v:true":         Typ[Bool],
+	"v:false":        Typ[Bool],
+	"v:null":         Typ[Special],
+	"v:none":         Typ[Special],
+	"v:count":        Typ[Number],
+	"v:count1":       Typ[Number],
+	"v:errmsg":       Typ[String],
+	"v:errors":       listOfString,
+	"v:exception":    Typ[String],
+	"v:lnum":         Typ[Number],
+	"v:oldfiles":     listOfString,
+	"v:progname":     Typ[String],
+	"v:shell_error":  Typ[Number],
+	"v:this_session": Typ[String],
+	"v:throwpoint":   Typ[String],
+	"v:val":          Typ[Any],
+	"v:key":          Typ[Any],
+	"v:version":      Typ[Number],
+	"v:t_number":     Typ[Number],
+	"v:t_string":     Typ[Number],
+	"v:t_func":       Typ[Number],
+	"v:t_list":       Typ[Number],
+	"v:t_dict":       Typ[Number],
+	"v:t_float":      Typ[Number],
+	"v:t_bool":       Typ[Number],
+	"v:t_none":       Typ[Number],
+	"v:t_job":        Typ[Number],
+	"v:t_channel":    Typ[Number],
+	"v:t_blob":       Typ[Number],
+}
+
+// Check infers the types of expressions in f and reports errors.
+func Check(f *ast.File) *Info {
+	c := &checker{info: &Info{Types: make(map[ast.Expr]*Type)}}
+	if f.Vim9 {
+		return c.info
+	}
+	c.scope = scope.Resolve(f)
+
+	// Variables assigned from other variables get their types in the
+	// later walks. A walk doesn't assign nil, i.e. unknown yet, to them.
+	c.vars = make(map[*scope.Symbol]*Type)
+	for i := 0; i < maxRounds; i++ {
+		c.assigned = make(map[*scope.Symbol]*Type)
+		c.info.Types = make(map[ast.Expr]*Type)
+		ast.Walk(c, f)
+		if sameVars(c.vars, c.assigned) {
+			break
+		}
+		c.vars = c.assigned
+	}
+
+	c.final = true
+	c.info.Types = make(map[ast.Expr]*Type)
+	ast.Walk(c, f)
+	sort.SliceStable(c.info.Errors, func(i, j int) bool {
+		p, q := c.info.Errors[i].Pos, c.info.Errors[j].Pos
+		return p.Line < q.Line || p.Line == q.Line && p.Column < q.Column
+	})
+	return c.info
+}
+
+func sameVars(a, b map[*scope.Symbol]*Type) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for sym, t := range a {
+		if u := b[sym]; u == nil || !Identical(t, u) {
+			return false
+		}
+	}
+	return true
+}
+
+type checker struct {
+	info  *Info
+	scope *scope.Info
+
+	vars     map[*scope.Symbol]*Type // types of variables from the last walk
+	assigned map[*scope.Symbol]*Type // types of variables in the current walk
+
+	// final is set in the last walk, which records errors. Types of
+	// variables which are not assigned are Any in it.
+	final bool
+}
+
+func (c *checker) Visit(n ast.Node) ast.Visitor {
+	switch n := n.(type) {
+	case *ast.Def:
+		return nil
+	case *ast.Let:
+		c.let(n)
+		return nil
+	case *ast.For:
+		c.forLoop(n)
+		for _, s := range n.Body {
+			ast.Walk(c, s)
+		}
+		return nil
+	case ast.Expr:
+		c.expr(n)
+		return nil
+	}
+	return c
+}
+
+// errorf records the error at x in the last walk.
+func (c *checker) errorf(x ast.Node, msg string) {
+	if c.final {
+		c.info.Errors = append(c.info.Errors, &Error{Pos: start(x), End: x.End(), Msg: msg})
+	}
+}
+
+// start returns the position of the first character of x. Pos of some
+// expressions is the position of the operator, e.g. "+" of `1 + 2`.
+func start(x ast.Node) ast.Pos {
+	switch x := x.(type) {
+	case *ast.BinaryExpr:
+		return start(x.Left)
+	case *ast.TernaryExpr:
+		return start(x.Condition)
+	case *ast.SubscriptExpr:
+		return start(x.Left)
+	case *ast.SliceExpr:
+		return start(x.X)
+	case *ast.DotExpr:
+		return start(x.Left)
+	case *ast.CallExpr:
+		return start(x.Fun)
+	case *ast.MethodExpr:
+		return start(x.Left)
+	}
+	return x.Pos()
+}
+
+// assign joins t to the type of the variable x.
+func (c *checker) assign(x ast.Expr, t *Type) {
+	sym := c.scope.Defs[x]
+	if sym == nil || t == nil || c.final {
+		return
+	}
+	if u := c.assigned[sym]; u != nil {
+		t = Join(u, t)
+	}
+	c.assigned[sym] = t
+}
+
+// variable returns the type of the variable x.
+func (c *checker) variable(x ast.Expr) *Type {
+	if id, ok := x.(*ast.Ident); ok {
+		if t := vimVars[id.Name]; t != nil {
+			return t
+		}
+		switch id.Name {
+		case "a:0":
+			return Typ[Number]
+		case "a:000":
+			return Typ[List]
+		}
+	}
+	sym := c.scope.Defs[x]
+	if sym == nil {
+		sym = c.scope.Uses[x]
+	}
+	if t := c.vars[sym]; sym != nil && t != nil {
+		return t
+	}
+	if c.final || sym == nil || sym.Func || len(sym.Defs) == 0 {
+		// variables which are not assigned in the file
+		return Typ[Any]
+	}
+	return nil
+}
+
+func (c *checker) let(n *ast.Let) {
+	var rhs *Type
+	if h, ok := n.Right.(*ast.HeredocExpr); ok {
+		rhs = listOfString
+		c.info.Types[h] = rhs
+	} else {
+		rhs = c.expr(n.Right)
+	}
+	for _, x := range append([]ast.Expr{n.Left, n.Rest}, n.List...) {
+		if x != nil {
+			c.target(x)
+		}
+	}
+	if n.Left != nil {
+		t := rhs
+		if op := compoundOps[n.Op]; op != token.ILLEGAL {
+			t = c.binary(n, op, c.variable(n.Left), rhs, nil, n.Right)
+		}
+		c.assign(n.Left, t)
+		return
+	}
+	c.destructure(n.List, n.Rest, n.Right, rhs)
+}
+
+// compoundOps maps assignment operators to binary operators.
+var compoundOps = map[string]token.Token{
+	"+=":  token.PLUS,
+	"-=":  token.MINUS,
+	"*=":  token.STAR,
+	"/=":  token.SLASH,
+	"%=":  token.PERCENT,
+	".=":  token.DOT,
+	"..=": token.DOTDOT,
+}
+
+// target infers the types in the lhs x of an assignment.
+func (c *checker) target(x ast.Expr) {
+	switch x := x.(type) {
+	case *ast.Ident:
+		c.info.Types[x] = c.variable(x)
+	case *ast.SubscriptExpr:
+		c.expr(x.Left)
+		c.expr(x.Right)
+	case *ast.SliceExpr:
+		c.expr(x.X)
+		c.expr(x.Low)
+		c.expr(x.High)
+	case *ast.DotExpr:
+		c.expr(x.Left)
+	default:
+		c.expr(x)
+	}
+}
+
+// destructure assigns items of rhs to the variables of [list; rest].
+func (c *checker) destructure(list []ast.Expr, rest ast.Expr, right ast.Expr, rhs *Type) {
+	if l, ok := right.(*ast.List); ok && rhs == c.info.Types[l] && len(l.Values) >= len(list) {
+		for i, x := range list {
+			c.assign(x, c.info.Types[l.Values[i]])
+		}
+		if rest != nil {
+			elem := Typ[Any]
+			if vs := l.Values[len(list):]; len(vs) > 0 {
+				elem = c.info.Types[vs[0]]
+				for _, v := range vs[1:] {
+					elem = join(elem, c.info.Types[v])
+				}
+			}
+			c.assign(rest, listOf(elem))
+		}
+		return
+	}
+	if rhs == nil {
+		return
+	}
+	if rhs.Kind != List && rhs.Kind != Any {
+		c.errorf(right, "E714: List required")
+		return
+	}
+	elem := Typ[Any]
+	if rhs.Kind == List {
+		elem = rhs.Elem
+	}
+	for _, x := range list {
+		c.assign(x, elem)
+	}
+	if rest != nil {
+		c.assign(rest, NewList(elem))
+	}
+}
+
+func (c *checker) forLoop(n *ast.For) {
+	rhs := c.expr(n.Right)
+	for _, x := range append([]ast.Expr{n.Left, n.Rest}, n.List...) {
+		if x != nil {
+			c.target(x)
+		}
+	}
+	if rhs == nil {
+		return
+	}
+	var elem *Type
+	switch rhs.Kind {
+	case List:
+		elem = rhs.Elem
+	case String:
+		elem = Typ[String]
+	case Blob:
+		elem = Typ[Number]
+	case Any:
+		elem = Typ[Any]
+	default:
+		c.errorf(n.Right, "E1098: String, List or Blob required")
+		return
+	}
+	if n.Left != nil {
+		c.assign(n.Left, elem)
+		return
+	}
+	if elem.Kind == List || elem.Kind == Any {
+		c.destructure(n.List, n.Rest, n.Right, elem)
+	}
+}
+
+// join is Join which returns nil if t or u is nil.
+func join(t, u *Type) *Type {
+	if t == nil || u == nil {
+		return nil
+	}
+	return Join(t, u)
+}
+
+// listOf is NewList which returns nil if elem is nil.
+func listOf(elem *Type) *Type {
+	if elem == nil {
+		return nil
+	}
+	return NewList(elem)
+}
+
+// expr infers the type of x and returns it. It returns nil if x has
+// variables whose types are unknown yet.
+func (c *checker) expr(x ast.Expr) *Type {
+	if x == nil {
+		return nil
+	}
+	t := c.infer(x)
+	if t == nil && c.final {
+		t = Typ[Any]
+	}
+	c.info.Types[x] = t
+	return t
+}
+
+func (c *checker) infer(x ast.Expr) *Type {
+	switch x := x.(type) {
+	case *ast.BasicLit:
+		return literal(x)
+
+	case *ast.Ident:
+		return c.variable(x)
+
+	case *ast.CurlyName:
+		for _, p := range x.Parts {
+			if e, ok := p.(*ast.CurlyNameExpr); ok {
+				c.expr(e.Value)
+			}
+		}
+		return Typ[Any]
+
+	case *ast.List:
+		if len(x.Values) == 0 {
+			return Typ[List]
+		}
+		elem := c.expr(x.Values[0])
+		for _, v := range x.Values[1:] {
+			elem = join(elem, c.expr(v))
+		}
+		return listOf(elem)
+
+	case *ast.Dict:
+		var elem *Type
+		for i, kv := range x.Entries {
+			c.expr(kv.Key)
+			if t := c.expr(kv.Value); i == 0 {
+				elem = t
+			} else {
+				elem = join(elem, t)
+			}
+		}
+		if len(x.Entries) == 0 {
+			return Typ[Dict]
+		}
+		if elem == nil {
+			return nil
+		}
+		return NewDict(elem)
+
+	case *ast.LambdaExpr:
+		c.expr(x.Expr)
+		return Typ[Funcref]
+
+	case *ast.ParenExpr:
+		return c.expr(x.X)
+
+	case *ast.HeredocExpr:
+		return listOfString
+
+	case *ast.UnaryExpr:
+		t := c.expr(x.X)
+		if t == nil {
+			return nil
+		}
+		if x.Op == token.NOT {
+			c.asNumber(x.X, t)
+			return Typ[Number]
+		}
+		if t.Kind == Float {
+			return t
+		}
+		c.asNumber(x.X, t)
+		return Typ[Number]
+
+	case *ast.BinaryExpr:
+		return c.binary(x, x.Op, c.expr(x.Left), c.expr(x.Right), x.Left, x.Right)
+
+	case *ast.TernaryExpr:
+		c.expr(x.Condition)
+		return join(c.expr(x.Left), c.expr(x.Right))
+
+	case *ast.SubscriptExpr:
+		return c.subscript(x)
+
+	case *ast.SliceExpr:
+		t := c.expr(x.X)
+		c.expr(x.Low)
+		c.expr(x.High)
+		if t == nil {
+			return nil
+		}
+		switch t.Kind {
+		case String, List, Blob, Any:
+			return t
+		case Number:
+			return Typ[String]
+		}
+		c.cannotIndex(x.X, t)
+		return Typ[Any]
+
+	case *ast.DotExpr:
+		t := c.expr(x.Left)
+		if t == nil {
+			return nil
+		}
+		switch t.Kind {
+		case Dict:
+			return t.Elem
+		case Any:
+			return Typ[Any]
+		case List, Funcref, Blob, Job, Channel:
+			c.errorf(x.Left, "E715: Dictionary required")
+			return Typ[Any]
+		}
+		// e.g. s.id is concatenation of s and id in legacy script.
+		return c.binary(x, token.DOT, t, nil, x.Left, nil)
+
+	case *ast.CallExpr:
+		var args []*Type
+		for _, a := range x.Args {
+			args = append(args, c.expr(a))
+		}
+		if id, ok := x.Fun.(*ast.Ident); ok && isBuiltinFunc(id.Name) && c.scope.Uses[id] == nil {
+			return c.call(id.Name, x.Args, args)
+		}
+		c.expr(x.Fun)
+		return Typ[Any]
+
+	case *ast.MethodExpr:
+		args := []*Type{c.expr(x.Left)}
+		for _, a := range x.Args {
+			args = append(args, c.expr(a))
+		}
+		if id, ok := x.Method.(*ast.Ident); ok && isBuiltinFunc(id.Name) {
+			return c.call(id.Name, append([]ast.Expr{x.Left}, x.Args...), args)
+		}
+		c.expr(x.Method)
+		return Typ[Any]
+	}
+	return Typ[Any]
+}
+
+var floatLit = regexp.MustCompile(`^\d+\.\d+([eE][-+]?\d+)?$`)
+
+func literal(x *ast.BasicLit) *Type {
+	switch x.Kind {
+	case token.NUMBER:
+		if floatLit.MatchString(x.Value) {
+			return Typ[Float]
+		}
+		return Typ[Number]
+	case token.STRING, token.ENV, token.REG:
+		return Typ[String]
+	case token.BLOB:
+		return Typ[Blob]
+	case token.OPTION:
+		name := strings.TrimPrefix(x.Value, "&")
+		if len(name) > 2 && name[1] == ':' {
+			name = name[2:]
+		}
+		if opt := builtin.LookupOption(name); opt != nil {
+			if opt.Type == builtin.String {
+				return Typ[String]
+			}
+			return Typ[Number]
+		}
+	}
+	return Typ[Any]
+}
+
+// isBuiltinFunc reports whether the function name is a builtin function,
+// i.e. it starts with lower case letter without scope and "#".
+func isBuiltinFunc(name string) bool {
+	return name != "" && 'a' <= name[0] && name[0] <= 'z' &&
+		!strings.ContainsAny(name, ":#")
+}
+
+// call returns the result of the builtin function name. args are the types
+// of xs.
+func (c *checker) call(name string, xs []ast.Expr, args []*Type) *Type {
+	f, ok := builtinFuncs[name]
+	if !ok {
+		return resultOf(name)
+	}
+	var first *Type
+	if len(args) > 0 {
+		first = args[0]
+		if first == nil {
+			return nil
+		}
+		if f.args != nil && first.Kind != Any && !hasKind(f.args, first.Kind) {
+			c.errorf(xs[0], f.err)
+			return f.result
+		}
+	}
+	switch name {
+	case "abs":
+		if first != nil && first.Kind == Float {
+			return Typ[Float]
+		}
+		return Typ[Number]
+	case "len":
+		// A Number is used as a String, e.g. len(42) is 2.
+		if first != nil && first.Kind == Number {
+			c.errorf(xs[0], "Number is converted to String for len()")
+		}
+	case "map":
+		if first != nil && (first.Kind == List || first.Kind == Dict) {
+			return &Type{Kind: first.Kind, Elem: Typ[Any]}
+		}
+	case "values":
+		if first != nil && first.Kind == Dict {
+			return NewList(first.Elem)
+		}
+	}
+	if f.result != nil {
+		return f.result
+	}
+	if first == nil {
+		return Typ[Any]
+	}
+	return first
+}
+
+func hasKind(kinds []Kind, k Kind) bool {
+	for _, x := range kinds {
+		if x == k {
+			return true
+		}
+	}
+	return false
+}
+
+// numberErrors is errors of using values as Numbers.
+var numberErrors = map[Kind]string{
+	List:    "E745: Using a List as a Number",
+	Dict:    "E728: Using a Dictionary as a Number",
+	Funcref: "E703: Using a Funcref as a Number",
+	Blob:    "E974: Using a Blob as a Number",
+	Job:     "E910: Using a Job as a Number",
+	Channel: "E913: Using a Channel as a Number",
+}
+
+// stringErrors is errors of using values as Strings.
+var stringErrors = map[Kind]string{
+	Float:   "E806: Using Float as a String",
+	List:    "E730: Using List as a String",
+	Dict:    "E731: Using Dictionary as a String",
+	Funcref: "E729: Using Funcref as a String",
+	Blob:    "E976: Using a Blob as a String",
+	Job:     "E908: Using an invalid value as a String: job",
+	Channel: "E908: Using an invalid value as a String: channel",
+}
+
+var numericString = regexp.MustCompile(`^\s*[-+]?\d`)
+
+// asNumber reports x of type t used as a Number. A string literal which
+// doesn't start with a number is reported because it's always 0.
+func (c *checker) asNumber(x ast.Expr, t *Type) {
+	if msg, ok := numberErrors[t.Kind]; ok {
+		c.errorf(x, msg)
+		return
+	}
+	if lit, ok := unparen(x).(*ast.BasicLit); ok && lit.Kind == token.STRING && !numericString.MatchString(unquote(lit.Value)) {
+		c.errorf(x, lit.Value+" is converted to Number 0")
+	}
+}
+
+func unparen(x ast.Expr) ast.Expr {
+	for {
+		p, ok := x.(*ast.ParenExpr)
+		if !ok {
+			return x
+		}
+		x = p.X
+	}
+}
+
+// unquote returns the value of the string literal s roughly, which is
+// enough to see whether it starts with a number.
+func unquote(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	return s[1 : len(s)-1]
+}
+
+// binary returns the type of the binary operation. x is the expression
+// which has the operation and l and r are the operands; or nil for the
+// variable of compound assignments.
+func (c *checker) binary(x ast.Node, op token.Token, lt, rt *Type, l, r ast.Expr) *Type {
+	operand := func(e ast.Expr) ast.Node {
+		if e == nil {
+			return x
+		}
+		return e
+	}
+	switch op {
+	case token.OROR, token.ANDAND:
+		for i, t := range []*Type{lt, rt} {
+			if t != nil {
+				c.asNumber([]ast.Expr{l, r}[i], t)
+			}
+		}
+		return Typ[Number]
+
+	case token.DOT, token.DOTDOT:
+		for i, t := range []*Type{lt, rt} {
+			if t == nil {
+				continue
+			}
+			if msg, ok := stringErrors[t.Kind]; ok {
+				c.errorf(operand([]ast.Expr{l, r}[i]), msg)
+			}
+		}
+		return Typ[String]
+
+	case token.FALSY:
+		return join(lt, rt)
+
+	case token.PLUS, token.MINUS, token.STAR, token.SLASH, token.PERCENT:
+		if lt == nil || rt == nil {
+			return nil
+		}
+		if op == token.PLUS && lt.Kind == rt.Kind && (lt.Kind == List || lt.Kind == Blob) {
+			return Join(lt, rt)
+		}
+		if op == token.PLUS && (lt.Kind == Any || rt.Kind == Any) {
+			// e.g. getline(1, 2) + [1] may be a List.
+			for _, t := range []*Type{lt, rt} {
+				if t.Kind == List || t.Kind == Blob {
+					return t
+				}
+			}
+			return Typ[Any]
+		}
+		for i, t := range []*Type{lt, rt} {
+			if e := []ast.Expr{l, r}[i]; e != nil {
+				c.asNumber(e, t)
+			} else if msg, ok := numberErrors[t.Kind]; ok {
+				c.errorf(x, msg)
+			}
+		}
+		if lt.Kind == Float || rt.Kind == Float {
+			if op == token.PERCENT {
+				c.errorf(x, "E804: Cannot use '%' with Float")
+			}
+			return Typ[Float]
+		}
+		if lt.Kind == Any || rt.Kind == Any {
+			return Typ[Any]
+		}
+		return Typ[Number]
+	}
+
+	// comparison
+	if lt != nil && rt != nil && lt.Kind != Any && rt.Kind != Any {
+		c.compare(x, op, lt, rt)
+	}
+	return Typ[Number]
+}
+
+// compare reports comparison of values which can't be compared.
+func (c *checker) compare(x ast.Node, op token.Token, lt, rt *Type) {
+	is := op >= token.IS && op <= token.ISNOTCS
+	eq := op >= token.EQEQ && op <= token.NEQCS
+	for _, k := range []Kind{List, Dict, Funcref, Blob} {
+		if lt.Kind != k && rt.Kind != k || is {
+			continue
+		}
+		if lt.Kind != rt.Kind {
+			c.errorf(x, compareErrors[k])
+		} else if !eq {
+			c.errorf(x, invalidOpErrors[k])
+		}
+		return
+	}
+}
+
+var compareErrors = map[Kind]string{
+	List:    "E691: Can only compare List with List",
+	Dict:    "E735: Can only compare Dictionary with Dictionary",
+	Funcref: "E693: Can only compare Funcref with Funcref",
+	Blob:    "E977: Can only compare Blob with Blob",
+}
+
+var invalidOpErrors = map[Kind]string{
+	List:    "E692: Invalid operation for List",
+	Dict:    "E736: Invalid operation for Dictionary",
+	Funcref: "E694: Invalid operation for Funcrefs",
+	Blob:    "E978: Invalid operation for Blob",
+}
+
+func (c *checker) subscript(x *ast.SubscriptExpr) *Type {
+	t := c.expr(x.Left)
+	c.expr(x.Right)
+	if t == nil {
+		return nil
+	}
+	switch t.Kind {
+	case List, Dict:
+		return t.Elem
+	case String, Number:
+		return Typ[String]
+	case Blob:
+		return Typ[Number]
+	case Any:
+		return Typ[Any]
+	}
+	c.cannotIndex(x.Left, t)
+	return Typ[Any]
+}
+
+// cannotIndex reports indexing x of type t.
+func (c *checker) cannotIndex(x ast.Expr, t *Type) {
+	switch t.Kind {
+	case Funcref:
+		c.errorf(x, "E695: Cannot index a Funcref")
+	case Float:
+		c.errorf(x, "E806: Using Float as a String")
+	case Bool, Special:
+		c.errorf(x, "E909: Cannot index a special variable")
+	default:
+		c.errorf(x, "E689: Can only index a List, Dictionary or Blob")
+	}
+}
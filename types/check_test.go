@@ -0,0 +1,209 @@
+package types
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vim-jp/go-vimlparser"
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+func check(t *testing.T, src string) (*ast.File, *Info) {
+	t.Helper()
+	f, err := vimlparser.ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f, Check(f)
+}
+
+// echoTypes returns the types of expressions of :echo in f.
+func echoTypes(f *ast.File, info *Info) []string {
+	var types []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		if e, ok := n.(*ast.EchoCmd); ok {
+			for _, x := range e.Exprs {
+				types = append(types, info.TypeOf(x).String())
+			}
+		}
+		return true
+	})
+	return types
+}
+
+func TestCheck_types(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"1", "number"},
+		{"0x1F", "number"},
+		{"1.5", "float"},
+		{"1.0e-3", "float"},
+		{"'abc'", "string"},
+		{`"abc"`, "string"},
+		{"0zFF00", "blob"},
+		{"$HOME", "string"},
+		{"@a", "string"},
+		{"&tabstop", "number"},
+		{"&l:wrap", "number"},
+		{"&filetype", "string"},
+		{"v:true", "bool"},
+		{"v:null", "special"},
+		{"[]", "list<any>"},
+		{"[1, 2]", "list<number>"},
+		{"[1, 'a']", "list<any>"},
+		{"[[1], [2]]", "list<list<number>>"},
+		{"{}", "dict<any>"},
+		{"{'a': 'x'}", "dict<string>"},
+		{"#{a: [1]}", "dict<list<number>>"},
+		{"{x -> x}", "func"},
+		{"1 + 2", "number"},
+		{"1 + 2.0", "float"},
+		{"[1] + [2]", "list<number>"},
+		{"0z00 + 0z01", "blob"},
+		{"getline(1, 2) + [1]", "list<number>"},
+		{"F() + 1", "any"},
+		{"'a' . 1", "string"},
+		{"'a' .. 'b'", "string"},
+		{"1 == 2", "number"},
+		{"-1.5", "float"},
+		{"!'1'", "number"},
+		{"1 ? 'a' : 'b'", "string"},
+		{"1 ? 'a' : 1", "any"},
+		{"[1, 2][0]", "number"},
+		{"'abc'[0]", "string"},
+		{"'abc'[1:]", "string"},
+		{"[1, 2][1:]", "list<number>"},
+		{"0z0102[0]", "number"},
+		{"{'a': 1}.a", "number"},
+		{"s:y.x", "string"},
+		{"s:x.x", "string"},
+		{"len('abc')", "number"},
+		{"split('a b')", "list<string>"},
+		{"keys({})", "list<string>"},
+		{"values({'a': 1.0})", "list<float>"},
+		{"copy([1])", "list<number>"},
+		{"map([1], 'v:val')", "list<any>"},
+		{"[1]->sort()", "list<number>"},
+		{"'abc'->len()", "number"},
+		{"abs(-1.5)", "float"},
+		{"hostname()", "string"},
+		{"getpid()", "number"},
+		{"function('F')", "func"},
+		{"job_start('ls')", "job"},
+		{"F()", "any"},
+		{"getline(1)", "any"},
+		{"s:x", "number"},
+		{"s:y", "string"},
+		{"s:z", "any"},
+		{"s:list", "list<string>"},
+		{"s:a", "number"},
+		{"s:b", "string"},
+		{"s:rest", "list<string>"},
+		{"s:item", "string"},
+		{"s:k", "string"},
+		{"s:n", "string"},
+		{"s:h", "list<string>"},
+		{"s:count", "number"},
+		{"s:copied", "number"},
+	}
+	var src strings.Builder
+	src.WriteString(`let s:x = 1
+let s:y = 'a'
+let s:y .= 'b'
+let s:z = 1
+let s:z = 'a'
+let s:list = split('a b')
+let [s:a, s:b; s:rest] = [1, 'a', 'b', 'c']
+for s:item in s:list
+endfor
+for [s:k, s:n] in [['a', 'b']]
+endfor
+let s:h =<< END
+text
+END
+let s:count = 0
+let s:copied = s:count
+let s:count += 1
+`)
+	for _, tt := range tests {
+		src.WriteString("echo " + tt.expr + "\n")
+	}
+	f, info := check(t, src.String())
+	if len(info.Errors) > 0 {
+		t.Fatalf("errors: %v", info.Errors)
+	}
+	got := echoTypes(f, info)
+	for i, tt := range tests {
+		if got[i] != tt.want {
+			t.Errorf("%s: got %s, want %s", tt.expr, got[i], tt.want)
+		}
+	}
+}
+
+func TestCheck_errors(t *testing.T) {
+	src := `echo "abc" + 1
+echo "12" + 1
+echo [1] + 1
+echo {} * 2
+echo 'a' . [1]
+echo 1.5 % 2
+echo [1] == 1
+echo [1] < [2]
+echo [1] is 1
+echo len(42)
+echo 42->len()
+echo keys([])
+let s:l = [1]
+echo s:l - 1
+let s:n = 1
+let s:n .= {}
+echo function('F')[0]
+let [s:a, s:b] = 1
+for s:x in {}
+endfor
+function! F(x) abort
+  return a:x - 1 - [a:x]
+endfunction
+echo s:l.x
+echo len(1.5)
+`
+	_, info := check(t, src)
+	var got []string
+	for _, e := range info.Errors {
+		got = append(got, fmt.Sprintf("%d:%d-%d:%d: %s", e.Pos.Line, e.Pos.Column, e.End.Line, e.End.Column, e.Msg))
+	}
+	want := []string{
+		`1:6-1:11: "abc" is converted to Number 0`,
+		"3:6-3:9: E745: Using a List as a Number",
+		"4:6-4:8: E728: Using a Dictionary as a Number",
+		"5:12-5:15: E730: Using List as a String",
+		"6:6-6:13: E804: Cannot use '%' with Float",
+		"7:6-7:14: E691: Can only compare List with List",
+		"8:6-8:15: E692: Invalid operation for List",
+		"10:10-10:12: Number is converted to String for len()",
+		"11:6-11:8: Number is converted to String for len()",
+		"12:11-12:13: E715: Dictionary required",
+		"14:6-14:9: E745: Using a List as a Number",
+		"16:12-16:14: E731: Using Dictionary as a String",
+		"17:6-17:19: E695: Cannot index a Funcref",
+		"18:18-18:19: E714: List required",
+		"19:12-19:14: E1098: String, List or Blob required",
+		"22:20-22:25: E745: Using a List as a Number",
+		"24:6-24:9: E715: Dictionary required",
+		"25:10-25:13: E701: Invalid type for len()",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCheck_vim9(t *testing.T) {
+	_, info := check(t, "vim9script\necho 'a' + 1\n")
+	if len(info.Errors) != 0 || len(info.Types) != 0 {
+		t.Errorf("Check() = %+v", info)
+	}
+}
@@ -0,0 +1,153 @@
+// Package types infers types of legacy Vim script expressions and reports
+// operations which fail or are likely mistakes, e.g. `[1] + 1` and
+// `"abc" + 1`.
+//
+// Check walks an *ast.File and infers the type of each expression from
+// literals, operators, builtin functions and the values assigned to the
+// variables by :let and :for. The inference doesn't depend on the control
+// flow: the type of a variable is the type of all values assigned to it in
+// the file, or Any if they differ. Expressions in :def functions and Vim9
+// script are not checked.
+package types
+
+import (
+	"fmt"
+
+	"github.com/vim-jp/go-vimlparser/ast"
+)
+
+// Kind represents the kind of types.
+type Kind int
+
+const (
+	Any     Kind = iota // unknown type
+	Number              // Number, including Booleans of legacy script
+	Float               // Float
+	String              // String
+	Bool                // v:true and v:false
+	Special             // v:null and v:none
+	List                // List
+	Dict                // Dictionary
+	Funcref             // Funcref and Partial
+	Blob                // Blob
+	Job                 // Job
+	Channel             // Channel
+)
+
+var kindNames = [...]string{
+	Any:     "any",
+	Number:  "number",
+	Float:   "float",
+	String:  "string",
+	Bool:    "bool",
+	Special: "special",
+	List:    "list",
+	Dict:    "dict",
+	Funcref: "func",
+	Blob:    "blob",
+	Job:     "job",
+	Channel: "channel",
+}
+
+func (k Kind) String() string {
+	if 0 <= k && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Type is a type of Vim script values.
+type Type struct {
+	Kind Kind
+	Elem *Type // type of items of List and Dict; or nil for the other kinds
+}
+
+// Typ is the types of the kinds other than List and Dict. Typ[List] and
+// Typ[Dict] are list<any> and dict<any>.
+var Typ = [...]*Type{
+	Any:     {Kind: Any},
+	Number:  {Kind: Number},
+	Float:   {Kind: Float},
+	String:  {Kind: String},
+	Bool:    {Kind: Bool},
+	Special: {Kind: Special},
+	List:    {Kind: List, Elem: &Type{Kind: Any}},
+	Dict:    {Kind: Dict, Elem: &Type{Kind: Any}},
+	Funcref: {Kind: Funcref},
+	Blob:    {Kind: Blob},
+	Job:     {Kind: Job},
+	Channel: {Kind: Channel},
+}
+
+// NewList returns list<elem>.
+func NewList(elem *Type) *Type {
+	return &Type{Kind: List, Elem: elem}
+}
+
+// NewDict returns dict<elem>.
+func NewDict(elem *Type) *Type {
+	return &Type{Kind: Dict, Elem: elem}
+}
+
+// String returns the type in the syntax of Vim9 script, e.g.
+// "list<number>".
+func (t *Type) String() string {
+	switch t.Kind {
+	case List, Dict:
+		return fmt.Sprintf("%s<%s>", t.Kind, t.Elem)
+	}
+	return t.Kind.String()
+}
+
+// Identical reports whether t and u are the same type.
+func Identical(t, u *Type) bool {
+	if t.Kind != u.Kind {
+		return false
+	}
+	if t.Elem == nil || u.Elem == nil {
+		return t.Elem == u.Elem
+	}
+	return Identical(t.Elem, u.Elem)
+}
+
+// Join returns the type of values of t or u, e.g. list<any> for
+// list<number> and list<string>. It's Any if their kinds differ.
+func Join(t, u *Type) *Type {
+	switch {
+	case Identical(t, u):
+		return t
+	case t.Kind != u.Kind:
+		return Typ[Any]
+	case t.Kind == List:
+		return NewList(Join(t.Elem, u.Elem))
+	case t.Kind == Dict:
+		return NewDict(Join(t.Elem, u.Elem))
+	}
+	return t
+}
+
+// Info is the result of Check.
+type Info struct {
+	Types  map[ast.Expr]*Type // types of expressions
+	Errors []*Error           // errors in the order of positions
+}
+
+// TypeOf returns the type of x; or Any if x is not checked.
+func (info *Info) TypeOf(x ast.Expr) *Type {
+	if t := info.Types[x]; t != nil {
+		return t
+	}
+	return Typ[Any]
+}
+
+// Error is an operation on values of wrong types. Msg of operations which
+// Vim accepts, e.g. "x" * 2, doesn't have the error number.
+type Error struct {
+	Pos ast.Pos // position of the expression
+	End ast.Pos // position immediately after the expression
+	Msg string  // message with the error number of Vim, e.g. "E745: ..."
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %s", e.Pos, e.Msg)
+}
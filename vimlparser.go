@@ -12,7 +12,7 @@ import (
 // ErrVimlParser represents VimLParser error.
 type ErrVimlParser struct {
 	Filename string
-	Offset   int
+	Offset   int // byte offset as Offset of ast.Pos
 	Line     int
 	Column   int
 	Msg      string
@@ -25,9 +25,40 @@ func (e *ErrVimlParser) Error() string {
 	return fmt.Sprintf("vimlparser: %v: line %d col %d", e.Msg, e.Line, e.Column)
 }
 
+// Pos returns the position of the error.
+func (e *ErrVimlParser) Pos() ast.Pos {
+	return ast.Pos{Offset: e.Offset, Line: e.Line, Column: e.Column, Filename: e.Filename}
+}
+
+// ErrorList is a list of *ErrVimlParser.
+// ParseFile returns ErrorList as error in recover mode.
+type ErrorList []*ErrVimlParser
+
+func (p ErrorList) Error() string {
+	switch len(p) {
+	case 0:
+		return "no errors"
+	case 1:
+		return p[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", p[0], len(p)-1)
+}
+
 // ParseOption is option for Parse().
 type ParseOption struct {
 	Neovim bool
+
+	// AllCommands makes the parser accept the commands of both Vim and
+	// Neovim, e.g. :shell and :rshada, to analyze scripts for both editors.
+	// Neovim is ignored if it's set.
+	AllCommands bool
+
+	// Recover enables error-recovering mode. When a command cannot be
+	// parsed, the parser skips to the next line or `|` and continues
+	// parsing. ParseFile returns the partial *ast.File which contains
+	// *ast.BadStmt and *ast.BadExpr for broken parts and ErrorList which
+	// contains all errors.
+	Recover bool
 }
 
 // ParseFile parses Vim script.
@@ -56,7 +87,29 @@ func ParseFile(r io.Reader, filename string, opt *ParseOption) (node *ast.File,
 	if opt != nil {
 		neovim = opt.Neovim
 	}
-	node = internal.NewVimLParser(neovim).Parse(reader, filename).(*ast.File)
+	p := internal.NewVimLParser(neovim)
+	if opt != nil && opt.AllCommands {
+		p = internal.NewVimLParserAll()
+	}
+	if opt != nil && opt.Recover {
+		n, errs := p.ParseRecover(reader, filename)
+		node = n.(*ast.File)
+		if len(errs) > 0 {
+			list := make(ErrorList, 0, len(errs))
+			for _, e := range errs {
+				list = append(list, &ErrVimlParser{
+					Filename: filename,
+					Offset:   e.Offset,
+					Line:     e.Line,
+					Column:   e.Column,
+					Msg:      e.Msg,
+				})
+			}
+			err = list
+		}
+		return
+	}
+	node = p.Parse(reader, filename).(*ast.File)
 	return
 }
 
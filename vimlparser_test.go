@@ -2,11 +2,14 @@ package vimlparser
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/vim-jp/go-vimlparser/ast"
 	"github.com/vim-jp/go-vimlparser/compiler"
 )
 
@@ -57,6 +60,30 @@ func TestParseFile_error(t *testing.T) {
 	}
 }
 
+func TestParseFile_allCommands(t *testing.T) {
+	src := "shell\nrsh\nwshada!\nnnoremap x <Cmd>tearoff File<CR>\n"
+	for _, opt := range []*ParseOption{nil, {Neovim: true}} {
+		if _, err := ParseFile(strings.NewReader(src), "", opt); err == nil {
+			t.Errorf("ParseFile(%+v) succeeded", opt)
+		}
+	}
+	f, err := ParseFile(strings.NewReader(src), "", &ParseOption{AllCommands: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		if c, ok := n.(ast.ExCommand); ok {
+			names = append(names, c.Cmd().Name)
+		}
+		return true
+	})
+	want := []string{"shell", "rshada", "wshada", "nnoremap", "tearoff"}
+	if !reflect.DeepEqual(names, want) {
+		t.Errorf("commands = %v, want %v", names, want)
+	}
+}
+
 func TestParseExpr_Compile(t *testing.T) {
 	node, err := ParseExpr(strings.NewReader("x + 1"))
 	if err != nil {
@@ -86,3 +113,814 @@ func TestParseExpr_Parser_err(t *testing.T) {
 		}
 	}
 }
+
+func TestParseFile_recover(t *testing.T) {
+	src := `let x = 1
+hoge fuga
+function! F() abort
+  if x ==
+    echo 1
+  endif
+  let y = [1, | echo 2
+  while 1
+endfunction
+echo 3
+`
+	f, err := ParseFile(strings.NewReader(src), "t.vim", &ParseOption{Recover: true})
+	if f == nil {
+		t.Fatalf("ParseFile() returns nil node: %v", err)
+	}
+	errs, ok := err.(ErrorList)
+	if !ok {
+		t.Fatalf("Error type is %T, want %T", err, ErrorList{})
+	}
+	wantErrs := []string{
+		"t.vim:2:1: vimlparser: E492: Not an editor command: hoge fuga",
+		"t.vim:4:10: vimlparser: unexpected token: <EOL>",
+		"t.vim:7:15: vimlparser: unexpected token: |",
+		"t.vim:9:1: vimlparser: E170: Missing :endwhile:    ENDFUNCTION",
+	}
+	if len(errs) != len(wantErrs) {
+		t.Fatalf("got %d errors, want %d: %v", len(errs), len(wantErrs), errs)
+	}
+	for i, e := range errs {
+		if got := e.Error(); got != wantErrs[i] {
+			t.Errorf("errs[%d] = %q, want %q", i, got, wantErrs[i])
+		}
+	}
+
+	if len(f.Body) != 4 {
+		t.Fatalf("len(f.Body) = %d, want 4", len(f.Body))
+	}
+	if bad, ok := f.Body[1].(*ast.BadStmt); !ok || bad.Text != "hoge fuga" {
+		t.Errorf("f.Body[1] = %#v, want BadStmt", f.Body[1])
+	}
+	fn, ok := f.Body[2].(*ast.Function)
+	if !ok {
+		t.Fatalf("f.Body[2] = %T, want *ast.Function", f.Body[2])
+	}
+	if fn.EndFunction == nil {
+		t.Error("EndFunction is nil")
+	}
+	if len(fn.Body) != 4 {
+		t.Fatalf("len(fn.Body) = %d, want 4", len(fn.Body))
+	}
+	if ifnode, ok := fn.Body[0].(*ast.If); !ok {
+		t.Errorf("fn.Body[0] = %T, want *ast.If", fn.Body[0])
+	} else if _, ok := ifnode.Condition.(*ast.BadExpr); !ok || ifnode.EndIf == nil {
+		t.Errorf("unexpected if node: %#v", ifnode)
+	}
+	if _, ok := fn.Body[1].(*ast.BadStmt); !ok {
+		t.Errorf("fn.Body[1] = %T, want *ast.BadStmt", fn.Body[1])
+	}
+	if _, ok := fn.Body[2].(*ast.EchoCmd); !ok {
+		t.Errorf("fn.Body[2] = %T, want *ast.EchoCmd", fn.Body[2])
+	}
+	if w, ok := fn.Body[3].(*ast.While); !ok || w.EndWhile != nil {
+		t.Errorf("fn.Body[3] = %#v, want unclosed *ast.While", fn.Body[3])
+	}
+	if _, ok := f.Body[3].(*ast.EchoCmd); !ok {
+		t.Errorf("f.Body[3] = %T, want *ast.EchoCmd", f.Body[3])
+	}
+}
+
+func TestParseFile_recover_unclosed(t *testing.T) {
+	want := "t.vim:3:0: vimlparser: E171: Missing :endif:    TOPLEVEL (and 1 more errors)"
+	f, err := ParseFile(strings.NewReader("for x in [1]\nif x\n"), "t.vim", &ParseOption{Recover: true})
+	if err == nil {
+		t.Fatal("want error")
+	}
+	if got := err.Error(); got != want {
+		t.Errorf("err = %q, want %q", got, want)
+	}
+	if len(f.Body) != 1 {
+		t.Errorf("len(f.Body) = %d, want 1", len(f.Body))
+	}
+}
+
+func TestParseFile_recover_noerror(t *testing.T) {
+	f, err := ParseFile(strings.NewReader("let x = 1"), "", &ParseOption{Recover: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.Body) != 1 {
+		t.Errorf("len(f.Body) = %d, want 1", len(f.Body))
+	}
+}
+
+func TestParseFile_comments(t *testing.T) {
+	src := `" standalone
+let x = 1 " trailing
+
+let y = [
+      \ 1,
+      "\ c1
+      "\ c2
+      \ 2]
+if x | " bar
+endif " end
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, g := range f.Comments {
+		got = append(got, fmt.Sprintf("%d:%q", g.Pos().Line, g.Text()))
+	}
+	want := []string{`2:"trailing"`, `6:"c1\nc2"`, `10:"end"`}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("f.Comments = %v, want %v", got, want)
+	}
+	if want := []int{3}; !reflect.DeepEqual(f.BlankLines, want) {
+		t.Errorf("f.BlankLines = %v, want %v", f.BlankLines, want)
+	}
+	// standalone comments are kept in the body.
+	if c, ok := f.Body[0].(*ast.Comment); !ok || c.Text != " standalone" {
+		t.Errorf("f.Body[0] = %#v, want standalone comment", f.Body[0])
+	}
+	if c, ok := f.Body[3].(*ast.If).Body[0].(*ast.Comment); !ok || c.Text != " bar" {
+		t.Errorf("comment after | = %#v, want comment statement", c)
+	}
+}
+
+func TestParseFile_end(t *testing.T) {
+	src := `let x = {'a': [1, 2]} " comment
+call F(x, 'b')
+let f = {a, b -> a + b}
+let s = 'a' .
+      \ 'b'
+let t =<< trim END
+  foo
+END
+function! G(a, ...) abort
+  return a:a
+endfunction
+if x | echo 'ｘ' | endif
+normal! gg
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.Let, *ast.Dict, *ast.List, *ast.ExCall, *ast.CallExpr,
+			*ast.LambdaExpr, *ast.BinaryExpr, *ast.HeredocExpr, *ast.Function,
+			*ast.Return, *ast.If, *ast.EchoCmd, *ast.Excmd, *ast.Comment:
+			got = append(got, src[n.Pos().Offset:n.End().Offset])
+		}
+		return true
+	})
+	want := []string{
+		"let x = {'a': [1, 2]}",
+		"{'a': [1, 2]}",
+		"[1, 2]",
+		"call F(x, 'b')",
+		"(x, 'b')",
+		"let f = {a, b -> a + b}",
+		"{a, b -> a + b}",
+		"+ b", // BinaryExpr starts at the operator
+		"let s = 'a' .\n      \\ 'b'",
+		".\n      \\ 'b'",
+		"let t =<< trim END\n  foo\nEND",
+		"let t =<< trim END\n  foo\nEND", // HeredocExpr starts at the command
+		"function! G(a, ...) abort\n  return a:a\nendfunction",
+		"return a:a",
+		"if x | echo 'ｘ' | endif",
+		"echo 'ｘ'",
+		"normal! gg",
+		`" comment`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestParseFile_vim9(t *testing.T) {
+	src := `vim9script
+import autoload 'foo.vim' as foo
+export const MAX = 10
+var names: list<string> = ['a',
+  'b', # comment
+  ]
+def Add(x: number, y = 1, ...rest: list<any>): number
+  count += 1
+  var F = (a, b): number => a + b
+  names->add($"x = {x}")
+  return x
+    + <number>foo.Get() ?? y
+enddef
+abstract class Shape extends Base implements I
+  public static var total = 0
+endclass
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !f.Vim9 {
+		t.Error("f.Vim9 = false, want true")
+	}
+	var got []string
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.Import, *ast.VarDecl, *ast.Def, *ast.Param, *ast.Type,
+			*ast.Assign, *ast.ExprStmt, *ast.Return, *ast.Vim9LambdaExpr,
+			*ast.InterpolatedString, *ast.CastExpr, *ast.Class, *ast.Comment:
+			got = append(got, src[n.Pos().Offset:n.End().Offset])
+		}
+		return true
+	})
+	want := []string{
+		"import autoload 'foo.vim' as foo",
+		"export const MAX = 10",
+		"var names: list<string> = ['a',\n  'b', # comment\n  ]",
+		"list<string>",
+		"def Add(x: number, y = 1, ...rest: list<any>): number\n  count += 1\n  var F = (a, b): number => a + b\n  names->add($\"x = {x}\")\n  return x\n    + <number>foo.Get() ?? y\nenddef",
+		"x: number",
+		"number",
+		"y = 1",
+		"...rest: list<any>",
+		"list<any>",
+		"number",
+		"count += 1",
+		"var F = (a, b): number => a + b",
+		"(a, b): number => a + b",
+		"a",
+		"b",
+		"number",
+		"names->add($\"x = {x}\")",
+		"$\"x = {x}\"",
+		"return x\n    + <number>foo.Get() ?? y",
+		"<number>foo.Get()",
+		"number",
+		"abstract class Shape extends Base implements I\n  public static var total = 0\nendclass",
+		"public static var total = 0",
+		"# comment",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestParseFile_vim9_commands(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ast.Statement
+	}{
+		{"unlet g:x g:y # comment", &ast.UnLet{}},
+		{"lockvar 2 g:x # comment", &ast.LockVar{}},
+		{"unlockvar g:x # comment", &ast.UnLockVar{}},
+		{"p =<< trim eval END\n  a {1}\nEND", &ast.Assign{}},
+		{"list\n  # comment\n  ->filter((_, v) => v)", &ast.ExprStmt{}},
+		{":%!xxd", &ast.Excmd{}},
+	}
+	for _, tt := range tests {
+		f, err := ParseFile(strings.NewReader("vim9script\n"+tt.in), "", nil)
+		if err != nil {
+			t.Errorf("ParseFile(%q) = %v", tt.in, err)
+			continue
+		}
+		if got, want := reflect.TypeOf(f.Body[1]), reflect.TypeOf(tt.want); got != want {
+			t.Errorf("ParseFile(%q).Body[1] = %v, want %v", tt.in, got, want)
+		}
+	}
+}
+
+func TestParseFile_vim9_error(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"vim9script\nlet x = 1", "a.vim:2:1: vimlparser: E1126: Cannot use :let in Vim9 script"},
+		{"def F()\n  var x = 1", "a.vim:3:0: vimlparser: E1057: Missing :enddef:    TOPLEVEL"},
+		{"vim9script\nvar F = () => {\n  return 1\n", "a.vim:4:0: vimlparser: E1171: Missing } after inline function"},
+	}
+	for _, tt := range tests {
+		_, err := ParseFile(strings.NewReader(tt.in), "a.vim", nil)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("ParseFile(%q) = %v, want %v", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestParseFile_autocmd(t *testing.T) {
+	src := `augroup vimrc
+  autocmd!
+  au vimrc BufRead,BufNewFile *.{c,h},*.go ++once nested setlocal ts=4 | call F()
+  autocmd! User | echo 1
+augroup END " vimrc
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g, ok := f.Body[0].(*ast.Augroup); !ok || g.Name != "vimrc" || g.IsEnd {
+		t.Errorf("f.Body[0] = %#v, want augroup vimrc", f.Body[0])
+	}
+	if g, ok := f.Body[5].(*ast.Augroup); !ok || !g.IsEnd {
+		t.Errorf("f.Body[5] = %#v, want augroup END", f.Body[5])
+	}
+	a := f.Body[2].(*ast.Autocmd)
+	if a.Group != "vimrc" || !a.Once || !a.Nested ||
+		!reflect.DeepEqual(a.Events, []string{"BufRead", "BufNewFile"}) ||
+		!reflect.DeepEqual(a.Patterns, []string{"*.{c,h}", "*.go"}) {
+		t.Errorf("autocmd = %#v", a)
+	}
+	if len(a.Command) != 2 {
+		t.Fatalf("len(a.Command) = %d, want 2", len(a.Command))
+	}
+	if _, ok := a.Command[1].(*ast.ExCall); !ok {
+		t.Errorf("a.Command[1] = %T, want *ast.ExCall", a.Command[1])
+	}
+	if got, want := src[a.Pos().Offset:a.End().Offset], "au vimrc BufRead,BufNewFile *.{c,h},*.go ++once nested setlocal ts=4 | call F()"; got != want {
+		t.Errorf("autocmd text = %q, want %q", got, want)
+	}
+	// "|" after the patterns separates the next command.
+	if a := f.Body[3].(*ast.Autocmd); a.Command != nil || !reflect.DeepEqual(a.Events, []string{"User"}) {
+		t.Errorf("autocmd! User = %#v", a)
+	}
+	if _, ok := f.Body[4].(*ast.EchoCmd); !ok {
+		t.Errorf("f.Body[4] = %T, want *ast.EchoCmd", f.Body[4])
+	}
+}
+
+func TestParseFile_map(t *testing.T) {
+	src := `nnoremap <silent><buffer> <Leader>f :call F()<CR>
+inoremap <expr> <Tab> pumvisible() ? "\<C-n>" : "\<Tab>"
+nnoremap x <Cmd>echo 1 <Bar> call F("<lt>")<CR>
+map! a\ b c | echo 2
+vunmap <buffer> z
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := f.Body[0].(*ast.Map)
+	if m.Modes != "n" || !m.Noremap || !m.Attr.Silent || !m.Attr.Buffer || m.Lhs != "<Leader>f" || m.Rhs != ":call F()<CR>" {
+		t.Errorf("nnoremap = %#v", m)
+	}
+	m = f.Body[1].(*ast.Map)
+	if _, ok := m.RhsExpr.(*ast.TernaryExpr); !ok || !m.Attr.Expr {
+		t.Errorf("m.RhsExpr = %T, want *ast.TernaryExpr", m.RhsExpr)
+	}
+	m = f.Body[2].(*ast.Map)
+	if len(m.RhsCmds) != 2 {
+		t.Fatalf("len(m.RhsCmds) = %d, want 2", len(m.RhsCmds))
+	}
+	c := m.RhsCmds[1].(*ast.ExCall)
+	if got, want := src[c.Pos().Offset:c.End().Offset], `call F("<lt>")`; got != want {
+		t.Errorf("call text = %q, want %q", got, want)
+	}
+	if s := c.FuncCall.Args[0].(*ast.BasicLit); s.Value != `"<"` {
+		t.Errorf("argument = %q, want %q", s.Value, `"<"`)
+	}
+	m = f.Body[3].(*ast.Map)
+	if m.Modes != "ic" || m.Noremap || m.Lhs != `a\ b` || m.Rhs != "c " {
+		t.Errorf("map! = %#v", m)
+	}
+	if _, ok := f.Body[4].(*ast.EchoCmd); !ok {
+		t.Errorf("f.Body[4] = %T, want *ast.EchoCmd", f.Body[4])
+	}
+	m = f.Body[5].(*ast.Map)
+	if m.Modes != "xs" || !m.Unmap || !m.Attr.Buffer || m.Lhs != "z" || m.Rhs != "" {
+		t.Errorf("vunmap = %#v", m)
+	}
+
+	// Vim9 script doesn't have comments after mappings.
+	f, err = ParseFile(strings.NewReader("vim9script\nnnoremap x y # z"), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m := f.Body[1].(*ast.Map); m.Rhs != "y # z" {
+		t.Errorf("m.Rhs = %q, want %q", m.Rhs, "y # z")
+	}
+
+	// The rhs isn't checked until the mapping is used.
+	src = `nnoremap x <Cmd>echo (<CR>
+nnoremap x <Cmd>let g:x = "<C-R>=1<CR>"<CR>
+nnoremap <expr> x (
+`
+	f, err = ParseFile(strings.NewReader(src), "a.vim", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rhs := range []string{`<Cmd>echo (<CR>`, `<Cmd>let g:x = "<C-R>=1<CR>"<CR>`, `(`} {
+		if m := f.Body[i].(*ast.Map); m.Rhs != rhs || m.RhsExpr != nil || m.RhsCmds != nil {
+			t.Errorf("f.Body[%d] = %#v, want Rhs %q without RhsExpr and RhsCmds", i, m, rhs)
+		}
+	}
+}
+
+func TestParseFile_usercommand(t *testing.T) {
+	src := `command! -nargs=* -complete=customlist,s:Complete -bang -range=% Foo call s:foo(<bang>0, <line1>, <f-args>) | echo "<q-args>"
+command -count Bar <line1>,<line2>delete
+command Baz echo (
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := f.Body[0].(*ast.UserCommand)
+	want := ast.UserCommandAttr{Nargs: "*", Range: "%", Complete: "customlist", CompleteFunc: "s:Complete", Bang: true}
+	if c.Name != "Foo" || c.Attr != want || c.Replacement != `call s:foo(<bang>0, <line1>, <f-args>) | echo "<q-args>"` {
+		t.Errorf("command = %#v", c)
+	}
+	var names []string
+	for _, p := range c.Placeholders {
+		names = append(names, p.Name)
+	}
+	if want := []string{"bang", "line1", "f-args", "q-args"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("placeholders = %q, want %q", names, want)
+	}
+	if len(c.Body) != 2 {
+		t.Fatalf("len(c.Body) = %d, want 2", len(c.Body))
+	}
+	args := c.Body[0].(*ast.ExCall).FuncCall.Args
+	if p, ok := args[2].(*ast.Placeholder); !ok || p.Name != "f-args" || src[p.Pos().Offset:p.End().Offset] != "<f-args>" {
+		t.Errorf("args[2] = %#v, want <f-args>", args[2])
+	}
+	if s := c.Body[1].(*ast.EchoCmd).Exprs[0].(*ast.BasicLit); s.Value != `"<q-args>"` {
+		t.Errorf("string = %q, want %q", s.Value, `"<q-args>"`)
+	}
+
+	c = f.Body[1].(*ast.UserCommand)
+	if c.Attr.Count != "0" {
+		t.Errorf("c.Attr.Count = %q, want %q", c.Attr.Count, "0")
+	}
+	if e := c.Body[0].(*ast.Excmd); e.Command != "<line1>,<line2>delete" {
+		t.Errorf("e.Command = %q, want %q", e.Command, "<line1>,<line2>delete")
+	}
+
+	// The replacement isn't checked until the command is used.
+	if c := f.Body[2].(*ast.UserCommand); c.Body != nil {
+		t.Errorf("c.Body = %#v, want nil", c.Body)
+	}
+
+	_, err = ParseFile(strings.NewReader("command -foo Foo echo 1"), "a.vim", nil)
+	if want := "a.vim:1:9: vimlparser: E181: Invalid attribute: -foo"; err == nil || err.Error() != want {
+		t.Errorf("err = %v, want %v", err, want)
+	}
+}
+
+func TestParseFile_highlight(t *testing.T) {
+	src := `hi Comment ctermfg=12 guifg=#80a0ff font='Monospace 10' | hi clear Foo
+hi! def link vimFoo Comment
+highlight Bar NONE
+hi Baz guifg=red " comment
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := f.Body[0].(*ast.Highlight)
+	var attrs []string
+	for _, a := range h.Attrs {
+		attrs = append(attrs, a.Key+"="+a.Value)
+	}
+	if want := []string{"ctermfg=12", "guifg=#80a0ff", "font='Monospace 10'"}; h.Group != "Comment" || !reflect.DeepEqual(attrs, want) {
+		t.Errorf("highlight = %#v, attrs = %q", h, attrs)
+	}
+	if h := f.Body[1].(*ast.Highlight); !h.Clear || h.Group != "Foo" {
+		t.Errorf("highlight clear = %#v", h)
+	}
+	if h := f.Body[2].(*ast.Highlight); !h.Default || !h.Link || h.Group != "vimFoo" || h.To != "Comment" {
+		t.Errorf("highlight link = %#v", h)
+	}
+	if h := f.Body[3].(*ast.Highlight); !h.None || h.Group != "Bar" {
+		t.Errorf("highlight NONE = %#v", h)
+	}
+	if h, ok := f.Body[4].(*ast.Highlight); !ok || h.Group != "Baz" || len(h.Attrs) != 1 {
+		t.Errorf("f.Body[4] = %#v, want highlight with a comment", f.Body[4])
+	}
+}
+
+func TestParseFile_syntax(t *testing.T) {
+	src := `syn keyword vimTodo contained TODO FIXME
+syntax match vimNumber /\<\d\+|x/ms=s+1 display contains=@Spell
+syntax region vimString matchgroup=vimQuote start=+"+ skip=+\\"+ end=+"+ oneline
+syn cluster vimGroup contains=vimTodo,vimNumber
+syn include @vimLua syntax/lua.vim
+syntax sync fromstart
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := f.Body[0].(*ast.Syntax)
+	if s.Sub != "keyword" || s.Group != "vimTodo" || !reflect.DeepEqual(s.Keywords, []string{"TODO", "FIXME"}) ||
+		len(s.Options) != 1 || s.Options[0].Name != "contained" {
+		t.Errorf("syntax keyword = %#v", s)
+	}
+	s = f.Body[1].(*ast.Syntax)
+	if len(s.Patterns) != 1 || *s.Patterns[0] != (ast.SyntaxPattern{Pos: s.Patterns[0].Pos, Pattern: `\<\d\+|x`, Delim: "/", Offsets: "ms=s+1"}) {
+		t.Errorf("syntax match patterns = %#v", s.Patterns)
+	}
+	if len(s.Options) != 2 || s.Options[1].Name != "contains" || s.Options[1].Value != "@Spell" {
+		t.Errorf("syntax match options = %#v", s.Options)
+	}
+	s = f.Body[2].(*ast.Syntax)
+	var patterns []string
+	for _, p := range s.Patterns {
+		patterns = append(patterns, p.MatchGroup+" "+p.Key+"="+p.Pattern)
+	}
+	if want := []string{`vimQuote start="`, `vimQuote skip=\\"`, `vimQuote end="`}; !reflect.DeepEqual(patterns, want) {
+		t.Errorf("syntax region patterns = %q, want %q", patterns, want)
+	}
+	if s := f.Body[3].(*ast.Syntax); s.Group != "vimGroup" || s.Options[0].Value != "vimTodo,vimNumber" {
+		t.Errorf("syntax cluster = %#v", s)
+	}
+	if s := f.Body[4].(*ast.Syntax); s.Group != "@vimLua" || s.File != "syntax/lua.vim" {
+		t.Errorf("syntax include = %#v", s)
+	}
+	if s := f.Body[5].(*ast.Syntax); s.Sub != "sync" || s.Args != "fromstart" {
+		t.Errorf("syntax sync = %#v", s)
+	}
+}
+
+func TestParseFile_set(t *testing.T) {
+	src := `set ts=4 nowrap invlist ft? sw< ai! cpo&vim tabstp=4
+setlocal path+=** iskeyword-=# tags^=./tags;
+setglobal statusline=%f\ %m t_Co=256 all&
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, n := range f.Body {
+		s := n.(*ast.Set)
+		for _, o := range s.Options {
+			got = append(got, fmt.Sprintf("%s|%s|%s|%s|%s", o.Prefix, o.Name, o.Full, o.Op, o.Value))
+		}
+	}
+	want := []string{
+		"|ts|tabstop|=|4",
+		"no|wrap|wrap||",
+		"inv|list|list||",
+		"|ft|filetype|?|",
+		"|sw|shiftwidth|<|",
+		"|ai|autoindent|!|",
+		"|cpo|cpoptions|&|vim",
+		"|tabstp||=|4",
+		"|path|path|+=|**",
+		"|iskeyword|iskeyword|-=|#",
+		"|tags|tags|^=|./tags;",
+		`|statusline|statusline|=|%f\ %m`,
+		"|t_Co|t_Co|=|256",
+		"|all|all|&|",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("options = %q, want %q", got, want)
+	}
+	s := f.Body[0].(*ast.Set)
+	if o := s.Options[1]; o.Pos.Column != 10 || o.EndPos.Column != 16 {
+		t.Errorf("nowrap = %v-%v, want 1:10-1:16", o.Pos, o.EndPos)
+	}
+}
+
+func TestParseFile_foreigncode(t *testing.T) {
+	src := `lua << EOF
+local x = 1
+EOF
+function F() abort
+  python3 << trim
+    if x:
+        pass
+  .
+  echo 1
+endfunction
+ruby puts 1
+`
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := f.Body[0].(*ast.ForeignCode)
+	if c.Language != "lua" || c.Marker != "EOF" || c.Trim || !reflect.DeepEqual(c.Lines, []string{"local x = 1"}) {
+		t.Errorf("lua = %#v", c)
+	}
+	if c.BodyPos.Line != 2 || c.BodyPos.Column != 1 || c.End().Line != 3 || c.End().Column != 4 {
+		t.Errorf("lua = %v-%v body %v", c.Pos(), c.End(), c.BodyPos)
+	}
+	fn := f.Body[1].(*ast.Function)
+	c = fn.Body[0].(*ast.ForeignCode)
+	if c.Language != "python3" || c.Marker != "." || !c.Trim || c.Code() != "if x:\n    pass" {
+		t.Errorf("python3 = %#v", c)
+	}
+	if e, ok := fn.Body[1].(*ast.EchoCmd); !ok || e.Pos().Line != 9 {
+		t.Errorf("statement after heredoc = %#v", fn.Body[1])
+	}
+	c = f.Body[2].(*ast.ForeignCode)
+	if c.Language != "ruby" || c.Marker != "" || !reflect.DeepEqual(c.Lines, []string{"puts 1"}) || c.BodyPos.Column != 6 {
+		t.Errorf("ruby = %#v", c)
+	}
+}
+
+func TestSourceMap(t *testing.T) {
+	src := "let x = [\r\n\t\\ 'é𝐀',\r\n\"\\ comment\r\n\t\\ 1]\r\necho x\r\n"
+	f, err := ParseFile(strings.NewReader(src), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewSourceMap([]byte(src))
+	list := f.Body[0].(*ast.Let).Right.(*ast.List)
+	tests := []struct {
+		pos  ast.Pos
+		want Position
+	}{
+		{list.Values[0].Pos(), Position{Offset: 14, Line: 2, Column: 4, UTF16Column: 4}},
+		{list.Values[0].End(), Position{Offset: 22, Line: 2, Column: 12, UTF16Column: 9}},
+		{list.Values[1].Pos(), Position{Offset: 40, Line: 4, Column: 4, UTF16Column: 4}},
+		{f.Body[1].Pos(), Position{Offset: 44, Line: 5, Column: 1, UTF16Column: 1}},
+		{f.Body[1].End(), Position{Offset: 50, Line: 5, Column: 7, UTF16Column: 7}},
+	}
+	for _, tt := range tests {
+		if got := m.Position(tt.pos); got != tt.want {
+			t.Errorf("Position(%v) = %+v, want %+v", tt.pos, got, tt.want)
+		}
+		if got := m.OffsetPosition(tt.pos.Offset); got != tt.want {
+			t.Errorf("OffsetPosition(%d) = %+v, want %+v", tt.pos.Offset, got, tt.want)
+		}
+	}
+	if got := m.Position(ast.Pos{Line: 6}); got != (Position{Offset: 52, Line: 6, Column: 1, UTF16Column: 1}) {
+		t.Errorf("Position(EOF) = %+v", got)
+	}
+	if got, want := m.UTF16Pos(2, 9), list.Values[0].End(); got != want {
+		t.Errorf("UTF16Pos(2, 9) = %#v, want %#v", got, want)
+	}
+	// in the middle of the surrogate pair
+	if got := m.UTF16Pos(2, 7); got.Column != 11 {
+		t.Errorf("UTF16Pos(2, 7).Column = %d, want 11", got.Column)
+	}
+
+	src = "echo 1\r\n\techo 'é' +\r\n"
+	_, err = ParseFile(strings.NewReader(src), "", nil)
+	e, ok := err.(*ErrVimlParser)
+	if !ok {
+		t.Fatalf("err = %v", err)
+	}
+	m = NewSourceMap([]byte(src))
+	if got, want := m.Position(e.Pos()), (Position{Offset: 20, Line: 2, Column: 13, UTF16Column: 12}); got != want {
+		t.Errorf("Position(%v) = %+v, want %+v", e.Pos(), got, want)
+	}
+
+	// Offset of errors is the byte offset even after multibyte characters
+	// and in continuation lines.
+	for _, tt := range []struct {
+		src  string
+		want Position
+	}{
+		{"echo \"あいうえお\" +\n", Position{Offset: 24, Line: 1, Column: 25, UTF16Column: 15}},
+		{"echo 1\r\necho \"あ\"\r\n\\ + (\r\n\\ 'い' +\r\n", Position{Offset: 36, Line: 4, Column: 10, UTF16Column: 8}},
+	} {
+		_, err = ParseFile(strings.NewReader(tt.src), "", nil)
+		e, ok := err.(*ErrVimlParser)
+		if !ok {
+			t.Fatalf("err = %v", err)
+		}
+		m = NewSourceMap([]byte(tt.src))
+		if got := m.OffsetPosition(e.Offset); got != tt.want {
+			t.Errorf("OffsetPosition(%d) = %+v, want %+v (%v)", e.Offset, got, tt.want, e)
+		}
+		if got := m.Position(e.Pos()); got != tt.want {
+			t.Errorf("Position(%v) = %+v, want %+v", e.Pos(), got, tt.want)
+		}
+	}
+}
+
+func TestReparse(t *testing.T) {
+	edit := func(l1, c1, l2, c2 int, text string) TextEdit {
+		return TextEdit{Start: ast.Pos{Line: l1, Column: c1}, End: ast.Pos{Line: l2, Column: c2}, NewText: text}
+	}
+	tests := []struct {
+		name   string
+		old    string
+		edits  []TextEdit
+		new    string
+		reused []int // indexes of statements of old reused in order
+	}{
+		{
+			name: "function",
+			old: `function! F() abort
+  return 1
+endfunction
+
+" comment
+function! G() abort
+  return 2
+endfunction
+echo F() | call G() " trailing
+let x = [1,
+  \ 2]
+`,
+			edits: []TextEdit{edit(7, 1, 7, 11, "  let y = 2\n  return y")},
+			new: `function! F() abort
+  return 1
+endfunction
+
+" comment
+function! G() abort
+  let y = 2
+  return y
+endfunction
+echo F() | call G() " trailing
+let x = [1,
+  \ 2]
+`,
+			reused: []int{0, 1, 3, 4, 5},
+		},
+		{
+			name:   "continuation",
+			old:    "echo 1\nlet x = 2\necho 3\n",
+			edits:  []TextEdit{edit(2, 1, 2, 10, `  \ + 2`)},
+			new:    "echo 1\n  \\ + 2\necho 3\n",
+			reused: []int{2},
+		},
+		{
+			name:   "crlf",
+			old:    "echo 1\r\necho 2\r\n\r\necho 3\r\n",
+			edits:  []TextEdit{edit(1, 6, 1, 7, "10")},
+			new:    "echo 10\r\necho 2\r\n\r\necho 3\r\n",
+			reused: []int{1, 2},
+		},
+		{
+			name:   "edits",
+			old:    "echo 1\necho 2\necho 3\necho 4\necho 5\n",
+			edits:  []TextEdit{edit(2, 6, 2, 7, "20"), edit(4, 1, 5, 1, "")},
+			new:    "echo 1\necho 20\necho 3\necho 5\n",
+			reused: []int{0},
+		},
+		{
+			name:   "delete",
+			old:    "echo 1\necho 2\necho 3\necho 4\necho 5\n",
+			edits:  []TextEdit{edit(2, 1, 3, 1, "")},
+			new:    "echo 1\necho 3\necho 4\necho 5\n",
+			reused: []int{0, 3, 4},
+		},
+		{
+			name:  "heredoc",
+			old:   "echo 1\necho 2\nEOF\n",
+			edits: []TextEdit{edit(1, 1, 1, 7, "lua << EOF")},
+			new:   "lua << EOF\necho 2\nEOF\n",
+		},
+		{
+			name:  "loadkeymap",
+			old:   "echo 1\necho 2\necho 3\n",
+			edits: []TextEdit{edit(2, 1, 2, 7, "loadkeymap")},
+			new:   "echo 1\nloadkeymap\necho 3\n",
+		},
+		{
+			name:  "finish",
+			old:   "echo 1\nfinish\necho 2\n",
+			edits: []TextEdit{edit(3, 6, 3, 7, "3")},
+			new:   "echo 1\nfinish\necho 3\n",
+		},
+		{
+			name:   "range",
+			old:    "echo 1\necho 2\necho 3\n",
+			edits:  []TextEdit{edit(2, 1, 2, 7, ":5")},
+			new:    "echo 1\n:5\necho 3\n",
+			reused: []int{0, 2},
+		},
+		{
+			name:  "if",
+			old:   "echo 1\nif 2\nendif\n",
+			edits: []TextEdit{edit(1, 1, 1, 7, "if 1")},
+			new:   "if 1\nif 2\nendif\n",
+		},
+	}
+	for _, tt := range tests {
+		old, err := ParseFile(strings.NewReader(tt.old), "a.vim", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		body := append([]ast.Statement(nil), old.Body...)
+		got, err := Reparse(old, []byte(tt.new), tt.edits, nil)
+		want, wantErr := ParseFile(strings.NewReader(tt.new), "a.vim", nil)
+		if fmt.Sprint(err) != fmt.Sprint(wantErr) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, wantErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: Reparse() is different from ParseFile()", tt.name)
+			continue
+		}
+		var reused []int
+		for i, s := range body {
+			for _, n := range got.Body {
+				if s == n {
+					reused = append(reused, i)
+				}
+			}
+		}
+		if !reflect.DeepEqual(reused, tt.reused) {
+			t.Errorf("%s: reused = %v, want %v", tt.name, reused, tt.reused)
+		}
+	}
+}